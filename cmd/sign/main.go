@@ -19,8 +19,8 @@ import (
 func main() {
 	var (
 		data = make([]byte, 2048)
-		hash = make([]byte, ghash.Size256)
-		sign = make([]byte, gkeys.SignatureSize256)
+		hash []byte
+		sign []byte
 	)
 
 	if len(os.Args) == 1 {
@@ -34,15 +34,18 @@ func main() {
 		os.Exit(2)
 	}
 
+	// First byte of the private key: K256 or K512.
+	hprov := gkeys.ProvType(priv.Bytes()[0]).Hash()
+
 	if len(os.Args) > 2 {
 		data = []byte(strings.Join(os.Args[2:], " "))
-		hash = ghash.Sum(ghash.H256, data)
+		hash = ghash.Sum(hprov, data)
 		sign = signHash(priv, hash)
 		fmt.Println(hex.EncodeToString(sign))
 		os.Exit(0)
 	}
 
-	hasher := ghash.New(ghash.H256)
+	hasher := ghash.New(hprov)
 	reader := bufio.NewReader(os.Stdin)
 	for {
 		n, err := reader.Read(data)
@@ -54,11 +57,11 @@ func main() {
 
 	hash = hasher.Sum(nil)
 	sign = signHash(priv, hash)
-	fmt.Println(hex.EncodeToString(hash))
+	fmt.Println(hex.EncodeToString(sign))
 }
 
 func signHash(priv gkeys.PrivKey, hash []byte) []byte {
-	sign, err := priv.Sign(hash)
+	sign, err := priv.SignDigest(hash, gkeys.AT_SIGNATURE)
 	if err != nil {
 		fmt.Println(err.Error())
 		os.Exit(10)
@@ -19,8 +19,8 @@ import (
 func main() {
 	var (
 		data = make([]byte, 2048)
-		hash = make([]byte, ghash.Size256)
-		sign = make([]byte, gkeys.SignatureSize256)
+		hash []byte
+		sign []byte
 	)
 
 	switch len(os.Args) {
@@ -38,16 +38,24 @@ func main() {
 		os.Exit(3)
 	}
 
+	// First byte of the public key: K256 or K512.
+	prov := gkeys.ProvType(pub.Bytes()[0])
+	hprov := prov.Hash()
+
 	sign = decodeHex(os.Args[2])
+	if len(sign) != prov.SignatureSize() {
+		fmt.Println("error: length of signature")
+		os.Exit(4)
+	}
 
 	if len(os.Args) > 3 {
 		data = []byte(strings.Join(os.Args[3:], " "))
-		hash = ghash.Sum(ghash.H256, data)
+		hash = ghash.Sum(hprov, data)
 		fmt.Println("Correct:", verifyHash(pub, hash, sign))
 		os.Exit(0)
 	}
 
-	hasher := ghash.New(ghash.H256)
+	hasher := ghash.New(hprov)
 	reader := bufio.NewReader(os.Stdin)
 	for {
 		n, err := reader.Read(data)
@@ -71,5 +79,5 @@ func decodeHex(hexdata string) []byte {
 }
 
 func verifyHash(pub gkeys.PubKey, hash, sign []byte) bool {
-	return pub.VerifySignature(hash, sign)
+	return pub.VerifyDigest(hash, sign)
 }
@@ -0,0 +1,36 @@
+package cms
+
+import (
+	"bytes"
+	"encoding/asn1"
+
+	ghash "github.com/towleeee/go-cryptopro/gost_r_34_11_2012"
+	"github.com/towleeee/go-cryptopro/internal/oids"
+	"github.com/towleeee/go-cryptopro/x509"
+)
+
+/*
+ * CERTIFICATE
+ */
+
+// Identifier of the certificate in SignerInfo.
+func certIssuerAndSerial(cert *x509.Certificate) issuerAndSerial {
+	return issuerAndSerial{
+		Issuer:       asn1.RawValue{FullBytes: cert.RawIssuer},
+		SerialNumber: cert.SerialNumber,
+	}
+}
+
+func certMatch(cert *x509.Certificate, sid issuerAndSerial) bool {
+	return bytes.Equal(cert.RawIssuer, sid.Issuer.FullBytes) &&
+		cert.SerialNumber.Cmp(sid.SerialNumber) == 0
+}
+
+// Digest of the certificate key: GOST R 34.11-2012 256 or 512.
+func certDigest(cert *x509.Certificate) (ghash.ProvType, asn1.ObjectIdentifier) {
+	digestAlg := oids.DigestOf(cert.PublicKeyAlgorithm)
+	if digestAlg.Equal(oids.GostR3411_12_512) {
+		return ghash.H512, digestAlg
+	}
+	return ghash.H256, digestAlg
+}
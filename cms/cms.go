@@ -0,0 +1,298 @@
+// CMS SignedData (RFC 5652), CAdES-BES (ETSI EN 319 122)
+// with GOST R 34.10-2012 / GOST R 34.11-2012 (RFC 9215).
+// https://datatracker.ietf.org/doc/html/rfc5652
+package cms
+
+import (
+	"bytes"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/towleeee/go-cryptopro/internal/oids"
+)
+
+const (
+	PemType = "CMS"
+)
+
+/*
+ * ASN.1
+ */
+
+type contentInfo struct {
+	ContentType asn1.ObjectIdentifier
+	Content     asn1.RawValue `asn1:"explicit,optional,tag:0"`
+}
+
+type signedData struct {
+	Version          int
+	DigestAlgorithms []pkix.AlgorithmIdentifier `asn1:"set"`
+	EncapContentInfo encapContentInfo
+	Certificates     asn1.RawValue `asn1:"optional,tag:0"`
+	CRLs             asn1.RawValue `asn1:"optional,tag:1"`
+	SignerInfos      []signerInfo  `asn1:"set"`
+}
+
+type encapContentInfo struct {
+	EContentType asn1.ObjectIdentifier
+	EContent     []byte `asn1:"explicit,optional,tag:0"`
+}
+
+type signerInfo struct {
+	Version            int
+	SID                issuerAndSerial
+	DigestAlgorithm    pkix.AlgorithmIdentifier
+	SignedAttrs        asn1.RawValue `asn1:"optional,tag:0"`
+	SignatureAlgorithm pkix.AlgorithmIdentifier
+	Signature          []byte
+	UnsignedAttrs      asn1.RawValue `asn1:"optional,tag:1"`
+}
+
+type issuerAndSerial struct {
+	Issuer       asn1.RawValue
+	SerialNumber *big.Int
+}
+
+type attribute struct {
+	Type   asn1.ObjectIdentifier
+	Values []asn1.RawValue `asn1:"set"`
+}
+
+type signingCertificateV2 struct {
+	Certs []essCertIDv2
+}
+
+type essCertIDv2 struct {
+	HashAlgorithm pkix.AlgorithmIdentifier `asn1:"optional"`
+	CertHash      []byte
+	IssuerSerial  issuerSerial `asn1:"optional"`
+}
+
+type issuerSerial struct {
+	Issuer       []asn1.RawValue
+	SerialNumber *big.Int
+}
+
+/*
+ * SIGNED DATA
+ */
+
+// Parsed CMS ContentInfo with SignedData content.
+type SignedData struct {
+	sd signedData
+}
+
+// Parsing of DER ContentInfo with SignedData.
+func Parse(der []byte) (*SignedData, error) {
+	var ci contentInfo
+	rest, err := asn1.Unmarshal(der, &ci)
+	if err != nil {
+		return nil, fmt.Errorf("error: parse content info: %w", err)
+	}
+	if len(rest) != 0 {
+		return nil, fmt.Errorf("error: trailing data after content info")
+	}
+	if !ci.ContentType.Equal(oids.SignedData) {
+		return nil, fmt.Errorf("error: content type is not signed data")
+	}
+
+	var sd signedData
+	rest, err = asn1.Unmarshal(ci.Content.Bytes, &sd)
+	if err != nil {
+		return nil, fmt.Errorf("error: parse signed data: %w", err)
+	}
+	if len(rest) != 0 {
+		return nil, fmt.Errorf("error: trailing data after signed data")
+	}
+
+	return &SignedData{sd: sd}, nil
+}
+
+// Parsing of PEM block "CMS" (or "PKCS7") with SignedData.
+func ParsePEM(data []byte) (*SignedData, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("error: pem block not found")
+	}
+	switch block.Type {
+	case PemType, "PKCS7":
+		// pass
+	default:
+		return nil, fmt.Errorf("error: pem type %q", block.Type)
+	}
+	return Parse(block.Bytes)
+}
+
+// DER encoding of ContentInfo.
+func (s *SignedData) Bytes() ([]byte, error) {
+	inner, err := asn1.Marshal(s.sd)
+	if err != nil {
+		return nil, err
+	}
+	return asn1.Marshal(contentInfo{
+		ContentType: oids.SignedData,
+		Content: asn1.RawValue{
+			Class:      asn1.ClassContextSpecific,
+			Tag:        0,
+			IsCompound: true,
+			Bytes:      inner,
+		},
+	})
+}
+
+// PEM encoding of ContentInfo.
+func (s *SignedData) PEM() ([]byte, error) {
+	der, err := s.Bytes()
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{
+		Type:  PemType,
+		Bytes: der,
+	}), nil
+}
+
+// Content is not included in SignedData.
+func (s *SignedData) Detached() bool {
+	return s.sd.EncapContentInfo.EContent == nil
+}
+
+// Number of signers (SignerInfo) of SignedData.
+func (s *SignedData) Signers() int {
+	return len(s.sd.SignerInfos)
+}
+
+// Certificates (DER) included in SignedData.
+func (s *SignedData) Certificates() ([][]byte, error) {
+	var (
+		list [][]byte
+		rest = s.sd.Certificates.Bytes
+	)
+	for len(rest) > 0 {
+		var cert asn1.RawValue
+		next, err := asn1.Unmarshal(rest, &cert)
+		if err != nil {
+			return nil, fmt.Errorf("error: parse certificates: %w", err)
+		}
+		list = append(list, cert.FullBytes)
+		rest = next
+	}
+	return list, nil
+}
+
+// Adding a certificate if it is not included yet.
+func (s *SignedData) addCertificate(cert []byte) error {
+	certs, err := s.Certificates()
+	if err != nil {
+		return err
+	}
+	for _, v := range certs {
+		if bytes.Equal(v, cert) {
+			return nil
+		}
+	}
+	s.sd.Certificates = asn1.RawValue{
+		Class:      asn1.ClassContextSpecific,
+		Tag:        0,
+		IsCompound: true,
+		Bytes:      append(append([]byte{}, s.sd.Certificates.Bytes...), cert...),
+	}
+	return nil
+}
+
+// Adding a digest algorithm if it is not included yet.
+func (s *SignedData) addDigestAlgorithm(oid asn1.ObjectIdentifier) {
+	for _, v := range s.sd.DigestAlgorithms {
+		if v.Algorithm.Equal(oid) {
+			return
+		}
+	}
+	s.sd.DigestAlgorithms = append(
+		s.sd.DigestAlgorithms,
+		pkix.AlgorithmIdentifier{Algorithm: oid},
+	)
+}
+
+/*
+ * ATTRIBUTES
+ */
+
+// DER SET OF attributes sorted by encoding.
+func marshalAttributes(attrs []attribute) ([]byte, error) {
+	var list [][]byte
+	for _, v := range attrs {
+		enc, err := asn1.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, enc)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return bytes.Compare(list[i], list[j]) < 0
+	})
+	return bytes.Join(list, []byte{}), nil
+}
+
+func parseAttributes(raw asn1.RawValue) ([]attribute, error) {
+	var (
+		list []attribute
+		rest = raw.Bytes
+	)
+	for len(rest) > 0 {
+		var attr attribute
+		next, err := asn1.Unmarshal(rest, &attr)
+		if err != nil {
+			return nil, fmt.Errorf("error: parse attributes: %w", err)
+		}
+		list = append(list, attr)
+		rest = next
+	}
+	return list, nil
+}
+
+// The single value of the attribute by type.
+func findAttribute(attrs []attribute, oid asn1.ObjectIdentifier) (asn1.RawValue, bool) {
+	for _, v := range attrs {
+		if v.Type.Equal(oid) && len(v.Values) == 1 {
+			return v.Values[0], true
+		}
+	}
+	return asn1.RawValue{}, false
+}
+
+// All values of the attributes by type.
+func findAttributes(attrs []attribute, oid asn1.ObjectIdentifier) []asn1.RawValue {
+	var list []asn1.RawValue
+	for _, v := range attrs {
+		if v.Type.Equal(oid) {
+			list = append(list, v.Values...)
+		}
+	}
+	return list
+}
+
+// Signed attributes are signed with the tag SET OF
+// instead of the implicit [0] (RFC 5652, 5.4).
+func attributesForSign(raw asn1.RawValue) ([]byte, error) {
+	return asn1.Marshal(asn1.RawValue{
+		Class:      asn1.ClassUniversal,
+		Tag:        asn1.TagSet,
+		IsCompound: true,
+		Bytes:      raw.Bytes,
+	})
+}
+
+func newAttribute(oid asn1.ObjectIdentifier, value interface{}) (attribute, error) {
+	enc, err := asn1.Marshal(value)
+	if err != nil {
+		return attribute{}, err
+	}
+	return attribute{
+		Type:   oid,
+		Values: []asn1.RawValue{{FullBytes: enc}},
+	}, nil
+}
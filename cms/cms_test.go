@@ -0,0 +1,296 @@
+// go test -v -bench=. -benchtime=100x
+package cms
+
+import (
+	"bytes"
+	"crypto/x509/pkix"
+	"testing"
+	"time"
+
+	gkeys "github.com/towleeee/go-cryptopro/gost_r_34_10_2012"
+	"github.com/towleeee/go-cryptopro/x509"
+)
+
+const (
+	TEST_SUBJECT  = "subject"
+	TEST_PASSWORD = "password"
+)
+
+var (
+	TEST_MESSAGE_1 = []byte("hello, world!")
+	TEST_MESSAGE_2 = []byte("qwerty")
+)
+
+var (
+	PRIVATE_KEY gkeys.PrivKey
+	CERTIFICATE []byte
+
+	PRIVATE_KEY_2 gkeys.PrivKey
+	CERTIFICATE_2 []byte
+)
+
+func init() {
+	PRIVATE_KEY, CERTIFICATE = testKey(TEST_SUBJECT)
+	PRIVATE_KEY_2, CERTIFICATE_2 = testKey(TEST_SUBJECT + "_2")
+}
+
+// Container key with the self-signed certificate.
+func testKey(subject string) (gkeys.PrivKey, []byte) {
+	cfg := gkeys.NewConfig(gkeys.K256, subject, TEST_PASSWORD)
+	err := gkeys.GenPrivKey(cfg)
+	if err != nil {
+		println("test warning: key already exist?")
+	}
+
+	priv, err := gkeys.NewPrivKey(cfg)
+	if err != nil {
+		panic("test failed: new priv key")
+	}
+
+	cert, err := testCertificate(priv, subject)
+	if err != nil {
+		panic("test failed: create certificate")
+	}
+
+	return priv, cert
+}
+
+// Self-signed certificate of the container key.
+func testCertificate(priv gkeys.PrivKey, subject string) ([]byte, error) {
+	template := &x509.Certificate{
+		Subject: x509.Name{
+			Name: pkix.Name{CommonName: subject},
+		},
+		NotAfter: time.Now().AddDate(1, 0, 0),
+		KeyUsage: x509.KeyUsageDigitalSignature,
+	}
+	return x509.CreateCertificate(template, nil, priv.PubKey(gkeys.AT_SIGNATURE), priv)
+}
+
+func TestSignDetached(t *testing.T) {
+	signingTime := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	sd, err := SignDetached(TEST_MESSAGE_1, CERTIFICATE, PRIVATE_KEY, &SignOptions{
+		SigningTime: signingTime,
+	})
+	if err != nil {
+		t.Errorf("test failed: sign detached")
+		return
+	}
+
+	if !sd.Detached() {
+		t.Errorf("test failed: detached")
+		return
+	}
+
+	if err := sd.Verify(TEST_MESSAGE_1); err != nil {
+		t.Errorf("test failed: verify (1): %s", err)
+		return
+	}
+
+	if err := sd.Verify(TEST_MESSAGE_2); err == nil {
+		t.Errorf("test failed: verify (2)")
+		return
+	}
+
+	certs, err := sd.Certificates()
+	if err != nil || len(certs) != 1 || !bytes.Equal(certs[0], CERTIFICATE) {
+		t.Errorf("test failed: certificates")
+		return
+	}
+
+	stime, err := sd.SigningTime(0)
+	if err != nil || !stime.Equal(signingTime) {
+		t.Errorf("test failed: signing time")
+		return
+	}
+}
+
+func TestEncoding(t *testing.T) {
+	sd, err := SignDetached(TEST_MESSAGE_1, CERTIFICATE, PRIVATE_KEY, nil)
+	if err != nil {
+		t.Errorf("test failed: sign detached")
+		return
+	}
+
+	der, err := sd.Bytes()
+	if err != nil {
+		t.Errorf("test failed: bytes")
+		return
+	}
+
+	sd, err = Parse(der)
+	if err != nil {
+		t.Errorf("test failed: parse")
+		return
+	}
+
+	pemBytes, err := sd.PEM()
+	if err != nil {
+		t.Errorf("test failed: pem")
+		return
+	}
+
+	sd, err = ParsePEM(pemBytes)
+	if err != nil {
+		t.Errorf("test failed: parse pem")
+		return
+	}
+
+	if err := sd.Verify(TEST_MESSAGE_1); err != nil {
+		t.Errorf("test failed: verify (1): %s", err)
+		return
+	}
+
+	sd.sd.SignerInfos[0].Signature[7] ^= byte(0x1)
+
+	if err := sd.Verify(TEST_MESSAGE_1); err == nil {
+		t.Errorf("test failed: verify (2)")
+		return
+	}
+}
+
+func TestWrongCertificate(t *testing.T) {
+	_, err := SignDetached(TEST_MESSAGE_1, CERTIFICATE, PRIVATE_KEY_2, nil)
+	if err == nil {
+		t.Errorf("test failed: sign with foreign certificate")
+		return
+	}
+}
+
+func TestSignAttached(t *testing.T) {
+	sd, err := SignAttached(TEST_MESSAGE_1, CERTIFICATE, PRIVATE_KEY, nil)
+	if err != nil {
+		t.Errorf("test failed: sign attached")
+		return
+	}
+
+	der, err := sd.Bytes()
+	if err != nil {
+		t.Errorf("test failed: bytes")
+		return
+	}
+
+	sd, err = Parse(der)
+	if err != nil {
+		t.Errorf("test failed: parse")
+		return
+	}
+
+	if sd.Detached() {
+		t.Errorf("test failed: attached")
+		return
+	}
+
+	// The data is ignored for attached SignedData.
+	if err := sd.Verify(nil); err != nil {
+		t.Errorf("test failed: verify: %s", err)
+		return
+	}
+}
+
+func TestCoSign(t *testing.T) {
+	sd, err := SignAttached(TEST_MESSAGE_1, CERTIFICATE, PRIVATE_KEY, nil)
+	if err != nil {
+		t.Errorf("test failed: sign attached")
+		return
+	}
+
+	err = sd.CoSign(nil, CERTIFICATE_2, PRIVATE_KEY_2, nil)
+	if err != nil {
+		t.Errorf("test failed: co-sign")
+		return
+	}
+
+	certs, err := sd.Certificates()
+	if err != nil || len(certs) != 2 || sd.Signers() != 2 {
+		t.Errorf("test failed: signers")
+		return
+	}
+
+	ok, list := sd.VerifySigners(nil)
+	if !ok || len(list) != 2 {
+		t.Errorf("test failed: verify signers (1)")
+		return
+	}
+
+	sd.sd.SignerInfos[1].Signature[7] ^= byte(0x1)
+
+	ok, list = sd.VerifySigners(nil)
+	if ok || !list[0] || list[1] {
+		t.Errorf("test failed: verify signers (2)")
+		return
+	}
+}
+
+func TestCounterSign(t *testing.T) {
+	sd, err := SignDetached(TEST_MESSAGE_1, CERTIFICATE, PRIVATE_KEY, nil)
+	if err != nil {
+		t.Errorf("test failed: sign detached")
+		return
+	}
+
+	err = sd.CounterSign(0, CERTIFICATE_2, PRIVATE_KEY_2, nil)
+	if err != nil {
+		t.Errorf("test failed: countersign")
+		return
+	}
+
+	err = sd.CounterSign(1, CERTIFICATE_2, PRIVATE_KEY_2, nil)
+	if err == nil {
+		t.Errorf("test failed: countersign out of range")
+		return
+	}
+
+	der, err := sd.Bytes()
+	if err != nil {
+		t.Errorf("test failed: bytes")
+		return
+	}
+
+	sd, err = Parse(der)
+	if err != nil {
+		t.Errorf("test failed: parse")
+		return
+	}
+
+	if err := sd.Verify(TEST_MESSAGE_1); err != nil {
+		t.Errorf("test failed: verify (1): %s", err)
+		return
+	}
+
+	// The last byte of the unsigned attributes is
+	// the last byte of the countersignature value.
+	attrs := sd.sd.SignerInfos[0].UnsignedAttrs.Bytes
+	attrs[len(attrs)-1] ^= byte(0x1)
+
+	ok, list := sd.VerifySigners(TEST_MESSAGE_1)
+	if ok || len(list) != 1 || list[0] {
+		t.Errorf("test failed: verify (2)")
+		return
+	}
+}
+
+func BenchmarkSignDetached(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, err := SignDetached(TEST_MESSAGE_1, CERTIFICATE, PRIVATE_KEY, nil)
+		if err != nil {
+			b.Errorf("benchmark failed: sign detached")
+			break
+		}
+	}
+}
+
+func BenchmarkVerify(b *testing.B) {
+	sd, err := SignDetached(TEST_MESSAGE_1, CERTIFICATE, PRIVATE_KEY, nil)
+	if err != nil {
+		b.Errorf("benchmark failed: sign detached")
+		return
+	}
+	for i := 0; i < b.N; i++ {
+		if err := sd.Verify(TEST_MESSAGE_1); err != nil {
+			b.Errorf("benchmark failed: verify")
+			break
+		}
+	}
+}
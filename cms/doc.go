@@ -0,0 +1,63 @@
+/*
+func SignDetached(data, cert []byte, priv gkeys.PrivKey, opts *SignOptions) (*SignedData, error) {}
+func SignAttached(data, cert []byte, priv gkeys.PrivKey, opts *SignOptions) (*SignedData, error) {}
+func (s *SignedData) CoSign(data, cert []byte, priv gkeys.PrivKey, opts *SignOptions) error {}
+func (s *SignedData) CounterSign(signer int, cert []byte, priv gkeys.PrivKey, opts *SignOptions) error {}
+
+func Parse(der []byte) (*SignedData, error) {}
+func ParsePEM(data []byte) (*SignedData, error) {}
+func (s *SignedData) Bytes() ([]byte, error) {}
+func (s *SignedData) PEM() ([]byte, error) {}
+func (s *SignedData) Detached() bool {}
+func (s *SignedData) Signers() int {}
+func (s *SignedData) Certificates() ([][]byte, error) {}
+func (s *SignedData) SigningTime(signer int) (time.Time, error) {}
+func (s *SignedData) Verify(data []byte) error {}
+func (s *SignedData) VerifySigners(data []byte) (bool, []bool) {}
+*/
+package cms
+
+/*
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/towleeee/go-cryptopro/cms"
+	gkeys "github.com/towleeee/go-cryptopro/gost_r_34_10_2012"
+)
+
+func main() {
+	cfg := gkeys.NewConfig(gkeys.K256, "username", "password")
+
+	priv, err := gkeys.NewPrivKey(cfg)
+	if err != nil {
+		panic(err)
+	}
+
+	// Certificate (DER) of the container key.
+	cert, err := os.ReadFile("username.cer")
+	if err != nil {
+		panic(err)
+	}
+
+	msg := []byte("hello, world!")
+	sd, err := cms.SignDetached(msg, cert, priv, nil)
+	if err != nil {
+		panic(err)
+	}
+
+	sig, err := sd.PEM()
+	if err != nil {
+		panic(err)
+	}
+
+	sd, err = cms.ParsePEM(sig)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Printf("%s\nSuccess: %t;\n", sig, sd.Verify(msg) == nil)
+}
+*/
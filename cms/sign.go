@@ -0,0 +1,296 @@
+package cms
+
+import (
+	"bytes"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"fmt"
+	"time"
+
+	gkeys "github.com/towleeee/go-cryptopro/gost_r_34_10_2012"
+	ghash "github.com/towleeee/go-cryptopro/gost_r_34_11_2012"
+	"github.com/towleeee/go-cryptopro/internal/keyblob"
+	"github.com/towleeee/go-cryptopro/internal/oids"
+	"github.com/towleeee/go-cryptopro/x509"
+)
+
+// Options of the signature.
+type SignOptions struct {
+	// Value of the signingTime attribute,
+	// the current time is used if zero.
+	SigningTime time.Time
+}
+
+// Creation of detached SignedData (CAdES-BES):
+// the content is not included, the certificate is included,
+// signed attributes are contentType, signingTime,
+// messageDigest and signingCertificateV2.
+// The private key must belong to the certificate (DER).
+func SignDetached(data, cert []byte, priv gkeys.PrivKey, opts *SignOptions) (*SignedData, error) {
+	s := &SignedData{
+		sd: signedData{
+			Version: 1,
+			EncapContentInfo: encapContentInfo{
+				EContentType: oids.Data,
+			},
+		},
+	}
+
+	err := s.addSigner(data, cert, priv, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Creation of attached SignedData (CAdES-BES):
+// the same as SignDetached, but the content is included.
+func SignAttached(data, cert []byte, priv gkeys.PrivKey, opts *SignOptions) (*SignedData, error) {
+	s := &SignedData{
+		sd: signedData{
+			Version: 1,
+			EncapContentInfo: encapContentInfo{
+				EContentType: oids.Data,
+				EContent:     append([]byte{}, data...),
+			},
+		},
+	}
+
+	err := s.addSigner(data, cert, priv, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Adding of a parallel signature (co-signing) to SignedData.
+// For detached SignedData the data is the signed content,
+// otherwise the data is ignored and the encapsulated content is signed.
+func (s *SignedData) CoSign(data, cert []byte, priv gkeys.PrivKey, opts *SignOptions) error {
+	if len(s.sd.SignerInfos) == 0 {
+		return fmt.Errorf("error: signer infos are empty")
+	}
+	return s.addSigner(s.content(data), cert, priv, opts)
+}
+
+// Adding of a countersignature (RFC 5652, 11.4)
+// over the signature value of the signer by index.
+func (s *SignedData) CounterSign(signer int, cert []byte, priv gkeys.PrivKey, opts *SignOptions) error {
+	if signer < 0 || signer >= len(s.sd.SignerInfos) {
+		return fmt.Errorf("error: signer index out of range")
+	}
+
+	si := &s.sd.SignerInfos[signer]
+
+	csi, err := newSignerInfo(nil, si.Signature, cert, priv, opts)
+	if err != nil {
+		return err
+	}
+
+	enc, err := asn1.Marshal(csi)
+	if err != nil {
+		return err
+	}
+
+	attrs, err := parseAttributes(si.UnsignedAttrs)
+	if err != nil {
+		return err
+	}
+	attrs = append(attrs, attribute{
+		Type:   oids.AttrCounterSignature,
+		Values: []asn1.RawValue{{FullBytes: enc}},
+	})
+
+	raw, err := marshalAttributes(attrs)
+	if err != nil {
+		return err
+	}
+
+	si.UnsignedAttrs = asn1.RawValue{
+		Class:      asn1.ClassContextSpecific,
+		Tag:        1,
+		IsCompound: true,
+		Bytes:      raw,
+	}
+
+	return s.addCertificate(cert)
+}
+
+// Creation and adding of SignerInfo over the content.
+func (s *SignedData) addSigner(content, cert []byte, priv gkeys.PrivKey, opts *SignOptions) error {
+	si, err := newSignerInfo(s.sd.EncapContentInfo.EContentType, content, cert, priv, opts)
+	if err != nil {
+		return err
+	}
+
+	s.sd.SignerInfos = append(s.sd.SignerInfos, si)
+	s.addDigestAlgorithm(si.DigestAlgorithm.Algorithm)
+
+	return s.addCertificate(cert)
+}
+
+// Creation of SignerInfo with signed attributes over the content.
+// The contentType attribute is omitted for nil content type
+// (countersignature).
+func newSignerInfo(contentType asn1.ObjectIdentifier, content, cert []byte, priv gkeys.PrivKey, opts *SignOptions) (signerInfo, error) {
+	if opts == nil {
+		opts = &SignOptions{}
+	}
+
+	crt, err := x509.ParseCertificate(cert)
+	if err != nil {
+		return signerInfo{}, err
+	}
+
+	signer, err := newSigner(crt, priv)
+	if err != nil {
+		return signerInfo{}, err
+	}
+
+	hprov, digestAlg := certDigest(crt)
+
+	signingTime := opts.SigningTime
+	if signingTime.IsZero() {
+		signingTime = time.Now()
+	}
+
+	digest, err := ghash.SumE(hprov, content)
+	if err != nil {
+		return signerInfo{}, err
+	}
+	attrs, err := signedAttributes(
+		contentType,
+		digest,
+		signingTime,
+		crt,
+		hprov,
+		digestAlg,
+	)
+	if err != nil {
+		return signerInfo{}, err
+	}
+
+	raw, err := marshalAttributes(attrs)
+	if err != nil {
+		return signerInfo{}, err
+	}
+
+	signedAttrs := asn1.RawValue{
+		Class:      asn1.ClassContextSpecific,
+		Tag:        0,
+		IsCompound: true,
+		Bytes:      raw,
+	}
+
+	tbs, err := attributesForSign(signedAttrs)
+	if err != nil {
+		return signerInfo{}, err
+	}
+
+	digest, err = ghash.SumE(hprov, tbs)
+	if err != nil {
+		return signerInfo{}, err
+	}
+	sign, err := signer.Sign(nil, digest, nil)
+	if err != nil {
+		return signerInfo{}, err
+	}
+	// Signature value of CMS is big-endian s || r (RFC 4491).
+	sign, err = gkeys.ConvertSignature(gkeys.ProvType(hprov), sign, gkeys.FormatCryptoAPI, gkeys.FormatRFC4491)
+	if err != nil {
+		return signerInfo{}, err
+	}
+
+	return signerInfo{
+		Version:         1,
+		SID:             certIssuerAndSerial(crt),
+		DigestAlgorithm: pkix.AlgorithmIdentifier{Algorithm: digestAlg},
+		SignedAttrs:     signedAttrs,
+		SignatureAlgorithm: pkix.AlgorithmIdentifier{
+			Algorithm: crt.PublicKeyAlgorithm,
+		},
+		Signature: sign,
+	}, nil
+}
+
+// Signer of the container key bound to the certificate.
+func newSigner(crt *x509.Certificate, priv gkeys.PrivKey) (*gkeys.Signer, error) {
+	signer, err := gkeys.NewSigner(priv)
+	if err != nil {
+		return nil, err
+	}
+
+	certKey, err := keyblob.Parse(crt.PublicKey.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	privKey, err := keyblob.Parse(signer.Public().(gkeys.PubKey).Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	if certKey.Prov != privKey.Prov || !bytes.Equal(certKey.Point, privKey.Point) {
+		return nil, fmt.Errorf("error: certificate does not match private key")
+	}
+
+	return signer, nil
+}
+
+func signedAttributes(
+	contentType asn1.ObjectIdentifier,
+	digest []byte,
+	signingTime time.Time,
+	crt *x509.Certificate,
+	hprov ghash.ProvType,
+	digestAlg asn1.ObjectIdentifier,
+) ([]attribute, error) {
+	var attrs []attribute
+
+	if contentType != nil {
+		attr, err := newAttribute(oids.AttrContentType, contentType)
+		if err != nil {
+			return nil, err
+		}
+		attrs = append(attrs, attr)
+	}
+
+	attr, err := newAttribute(oids.AttrSigningTime, signingTime.UTC().Truncate(time.Second))
+	if err != nil {
+		return nil, err
+	}
+	attrs = append(attrs, attr)
+
+	attr, err = newAttribute(oids.AttrMessageDigest, digest)
+	if err != nil {
+		return nil, err
+	}
+	attrs = append(attrs, attr)
+
+	hash, err := ghash.SumE(hprov, crt.Raw)
+	if err != nil {
+		return nil, err
+	}
+	attr, err = newAttribute(oids.AttrSigningCertificateV2, signingCertificateV2{
+		Certs: []essCertIDv2{{
+			HashAlgorithm: pkix.AlgorithmIdentifier{Algorithm: digestAlg},
+			CertHash:      hash,
+			IssuerSerial: issuerSerial{
+				Issuer: []asn1.RawValue{{
+					Class:      asn1.ClassContextSpecific,
+					Tag:        4,
+					IsCompound: true,
+					Bytes:      crt.RawIssuer,
+				}},
+				SerialNumber: crt.SerialNumber,
+			},
+		}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	attrs = append(attrs, attr)
+
+	return attrs, nil
+}
@@ -0,0 +1,263 @@
+package cms
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/asn1"
+	"fmt"
+	"time"
+
+	gkeys "github.com/towleeee/go-cryptopro/gost_r_34_10_2012"
+	ghash "github.com/towleeee/go-cryptopro/gost_r_34_11_2012"
+	"github.com/towleeee/go-cryptopro/internal/oids"
+	"github.com/towleeee/go-cryptopro/x509"
+)
+
+// Checking all signatures of SignedData.
+// For detached SignedData the data is the signed content,
+// otherwise the data is ignored and the encapsulated content is used.
+func (s *SignedData) Verify(data []byte) error {
+	if len(s.sd.SignerInfos) == 0 {
+		return fmt.Errorf("error: signer infos are empty")
+	}
+
+	certs, err := s.parseCertificates()
+	if err != nil {
+		return err
+	}
+
+	var (
+		content     = s.content(data)
+		contentType = s.sd.EncapContentInfo.EContentType
+	)
+	for i, si := range s.sd.SignerInfos {
+		err := verifySigner(si, contentType, content, certs)
+		if err != nil {
+			return fmt.Errorf("error: signer %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+// Checking each signature of SignedData with its countersignatures.
+// The result is the same as of BatchVerifier: the list
+// contains the result for each signer in the order of SignerInfos.
+func (s *SignedData) VerifySigners(data []byte) (bool, []bool) {
+	var (
+		res  = len(s.sd.SignerInfos) != 0
+		list []bool
+	)
+
+	certs, err := s.parseCertificates()
+	if err != nil {
+		return false, make([]bool, len(s.sd.SignerInfos))
+	}
+
+	var (
+		content     = s.content(data)
+		contentType = s.sd.EncapContentInfo.EContentType
+	)
+	for _, si := range s.sd.SignerInfos {
+		ok := verifySigner(si, contentType, content, certs) == nil
+		if !ok {
+			res = false
+		}
+		list = append(list, ok)
+	}
+
+	return res, list
+}
+
+// Value of the signingTime attribute of the signer.
+func (s *SignedData) SigningTime(signer int) (time.Time, error) {
+	var signingTime time.Time
+
+	if signer < 0 || signer >= len(s.sd.SignerInfos) {
+		return signingTime, fmt.Errorf("error: signer index out of range")
+	}
+
+	attrs, err := parseAttributes(s.sd.SignerInfos[signer].SignedAttrs)
+	if err != nil {
+		return signingTime, err
+	}
+
+	value, ok := findAttribute(attrs, oids.AttrSigningTime)
+	if !ok {
+		return signingTime, fmt.Errorf("error: signing time not found")
+	}
+
+	_, err = asn1.Unmarshal(value.FullBytes, &signingTime)
+	return signingTime, err
+}
+
+func (s *SignedData) content(data []byte) []byte {
+	if s.Detached() {
+		return data
+	}
+	return s.sd.EncapContentInfo.EContent
+}
+
+func (s *SignedData) parseCertificates() ([]*x509.Certificate, error) {
+	raws, err := s.Certificates()
+	if err != nil {
+		return nil, err
+	}
+	var certs []*x509.Certificate
+	for _, v := range raws {
+		crt, err := x509.ParseCertificate(v)
+		if err != nil {
+			return nil, err
+		}
+		certs = append(certs, crt)
+	}
+	return certs, nil
+}
+
+func findCertificate(certs []*x509.Certificate, sid issuerAndSerial) (*x509.Certificate, error) {
+	for _, v := range certs {
+		if certMatch(v, sid) {
+			return v, nil
+		}
+	}
+	return nil, fmt.Errorf("error: certificate of signer not found")
+}
+
+// Checking of SignerInfo over the content
+// with the certificate from SignedData,
+// then checking of its countersignatures.
+func verifySigner(si signerInfo, contentType asn1.ObjectIdentifier, content []byte, certs []*x509.Certificate) error {
+	crt, err := findCertificate(certs, si.SID)
+	if err != nil {
+		return err
+	}
+
+	hprov, digestAlg := certDigest(crt)
+	if !si.DigestAlgorithm.Algorithm.Equal(digestAlg) {
+		return fmt.Errorf("error: digest algorithm %s", si.DigestAlgorithm.Algorithm)
+	}
+
+	signed := content
+	if len(si.SignedAttrs.Bytes) != 0 {
+		digest, err := ghash.SumE(hprov, content)
+		if err != nil {
+			return err
+		}
+		err = checkAttributes(si, contentType, digest, crt)
+		if err != nil {
+			return err
+		}
+		signed, err = attributesForSign(si.SignedAttrs)
+		if err != nil {
+			return err
+		}
+	}
+
+	digest, err := ghash.SumE(hprov, signed)
+	if err != nil {
+		return err
+	}
+	err = crt.PublicKey.VerifyDigestE(digest, si.Signature, gkeys.FormatRFC4491)
+	if err != nil {
+		return err
+	}
+
+	return verifyCounterSignatures(si, certs)
+}
+
+// Checking of countersignatures over the signature value (RFC 5652, 11.4).
+func verifyCounterSignatures(si signerInfo, certs []*x509.Certificate) error {
+	attrs, err := parseAttributes(si.UnsignedAttrs)
+	if err != nil {
+		return err
+	}
+
+	for i, v := range findAttributes(attrs, oids.AttrCounterSignature) {
+		var csi signerInfo
+		_, err := asn1.Unmarshal(v.FullBytes, &csi)
+		if err != nil {
+			return fmt.Errorf("error: parse countersignature: %w", err)
+		}
+		err = verifySigner(csi, nil, si.Signature, certs)
+		if err != nil {
+			return fmt.Errorf("error: countersignature %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+// Checking of contentType, messageDigest and signingCertificateV2.
+// The contentType attribute must be absent for nil content type
+// (countersignature).
+func checkAttributes(si signerInfo, contentType asn1.ObjectIdentifier, digest []byte, crt *x509.Certificate) error {
+	attrs, err := parseAttributes(si.SignedAttrs)
+	if err != nil {
+		return err
+	}
+
+	value, ok := findAttribute(attrs, oids.AttrContentType)
+	switch {
+	case contentType == nil && ok:
+		return fmt.Errorf("error: content type attribute in countersignature")
+	case contentType != nil && !ok:
+		return fmt.Errorf("error: content type attribute not found")
+	case ok:
+		var ct asn1.ObjectIdentifier
+		if _, err := asn1.Unmarshal(value.FullBytes, &ct); err != nil {
+			return fmt.Errorf("error: parse content type: %w", err)
+		}
+		if !ct.Equal(contentType) {
+			return fmt.Errorf("error: content type mismatch")
+		}
+	}
+
+	value, ok = findAttribute(attrs, oids.AttrMessageDigest)
+	if !ok {
+		return fmt.Errorf("error: message digest attribute not found")
+	}
+	var md []byte
+	if _, err := asn1.Unmarshal(value.FullBytes, &md); err != nil {
+		return fmt.Errorf("error: parse message digest: %w", err)
+	}
+	if !bytes.Equal(md, digest) {
+		return fmt.Errorf("error: message digest mismatch")
+	}
+
+	value, ok = findAttribute(attrs, oids.AttrSigningCertificateV2)
+	if !ok {
+		return nil
+	}
+	var scv2 signingCertificateV2
+	if _, err := asn1.Unmarshal(value.FullBytes, &scv2); err != nil {
+		return fmt.Errorf("error: parse signing certificate: %w", err)
+	}
+	if len(scv2.Certs) == 0 {
+		return fmt.Errorf("error: signing certificate is empty")
+	}
+	hash, err := certHash(scv2.Certs[0].HashAlgorithm.Algorithm, crt.Raw)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(hash, scv2.Certs[0].CertHash) {
+		return fmt.Errorf("error: signing certificate mismatch")
+	}
+
+	return nil
+}
+
+// Hash of the certificate for ESSCertIDv2,
+// id-sha256 is the default algorithm (RFC 5035).
+func certHash(alg asn1.ObjectIdentifier, cert []byte) ([]byte, error) {
+	switch {
+	case len(alg) == 0:
+		hash := sha256.Sum256(cert)
+		return hash[:], nil
+	case alg.Equal(oids.GostR3411_12_256):
+		return ghash.SumE(ghash.H256, cert)
+	case alg.Equal(oids.GostR3411_12_512):
+		return ghash.SumE(ghash.H512, cert)
+	default:
+		return nil, fmt.Errorf("error: certificate hash algorithm %s", alg)
+	}
+}
@@ -0,0 +1,137 @@
+// Logging of the CSP failures and the debug messages
+// of the packages of the module.
+// Nothing is logged until SetLogger is called.
+package csplog
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+)
+
+/*
+ * LEVELS
+ */
+
+// Level of the message, the same values as in log/slog.
+type Level int
+
+const (
+	LevelDebug Level = -4
+	LevelInfo  Level = 0
+	LevelWarn  Level = 4
+	LevelError Level = 8
+)
+
+func (l Level) String() string {
+	switch {
+	case l <= LevelDebug:
+		return "DEBUG"
+	case l <= LevelInfo:
+		return "INFO"
+	case l <= LevelWarn:
+		return "WARN"
+	default:
+		return "ERROR"
+	}
+}
+
+/*
+ * LOGGER
+ */
+
+// Key-value pair of the message.
+// CSP failures have the attributes "op", "code", "func" and "last_error".
+type Attr struct {
+	Key   string
+	Value interface{}
+}
+
+// Receiver of the messages (slog.Handler in a short form).
+// Implementations must be safe for concurrent use.
+type Logger interface {
+	Enabled(level Level) bool
+	Log(level Level, msg string, attrs ...Attr)
+}
+
+// Logger of all messages calling the function.
+type LoggerFunc func(level Level, msg string, attrs ...Attr)
+
+func (f LoggerFunc) Enabled(level Level) bool {
+	return true
+}
+
+func (f LoggerFunc) Log(level Level, msg string, attrs ...Attr) {
+	f(level, msg, attrs...)
+}
+
+var (
+	mtx    sync.RWMutex
+	logger Logger
+)
+
+// Setting of the logger of the module, nil disables logging.
+func SetLogger(l Logger) {
+	mtx.Lock()
+	defer mtx.Unlock()
+	logger = l
+}
+
+// Checking that messages of the level are logged.
+func Enabled(level Level) bool {
+	mtx.RLock()
+	defer mtx.RUnlock()
+	return logger != nil && logger.Enabled(level)
+}
+
+// Passing of the message to the logger of SetLogger if it is set.
+func Log(level Level, msg string, attrs ...Attr) {
+	mtx.RLock()
+	l := logger
+	mtx.RUnlock()
+	if l == nil || !l.Enabled(level) {
+		return
+	}
+	l.Log(level, msg, attrs...)
+}
+
+/*
+ * TEXT LOGGER
+ */
+
+type textLogger struct {
+	mtx   sync.Mutex
+	w     io.Writer
+	level Level
+}
+
+// Logger writing messages of the level and above in the form
+// "level=ERROR msg=... key=value" to w, e.g. os.Stderr.
+func NewTextLogger(w io.Writer, level Level) Logger {
+	return &textLogger{w: w, level: level}
+}
+
+func (t *textLogger) Enabled(level Level) bool {
+	return level >= t.level
+}
+
+func (t *textLogger) Log(level Level, msg string, attrs ...Attr) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "level=%s msg=%q", level, msg)
+	for _, v := range attrs {
+		switch x := v.Value.(type) {
+		case string:
+			fmt.Fprintf(&b, " %s=%q", v.Key, x)
+		case uint32:
+			fmt.Fprintf(&b, " %s=0x%08X", v.Key, x)
+		default:
+			fmt.Fprintf(&b, " %s=%v", v.Key, x)
+		}
+	}
+	b.WriteByte('\n')
+
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	_, _ = io.WriteString(t.w, b.String())
+}
@@ -0,0 +1,60 @@
+// go test -v -bench=. -benchtime=100x
+package csplog
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestSetLogger(t *testing.T) {
+	defer SetLogger(nil)
+
+	var msgs []string
+	SetLogger(LoggerFunc(func(level Level, msg string, attrs ...Attr) {
+		msgs = append(msgs, msg)
+	}))
+	if !Enabled(LevelDebug) {
+		t.Errorf("test failed: logger is disabled")
+		return
+	}
+	Log(LevelError, "first")
+
+	SetLogger(nil)
+	if Enabled(LevelError) {
+		t.Errorf("test failed: logger is enabled")
+		return
+	}
+	Log(LevelError, "second")
+
+	if len(msgs) != 1 || msgs[0] != "first" {
+		t.Errorf("test failed: messages %q", msgs)
+		return
+	}
+}
+
+func TestTextLogger(t *testing.T) {
+	defer SetLogger(nil)
+
+	var buf bytes.Buffer
+	SetLogger(NewTextLogger(&buf, LevelError))
+
+	Log(LevelDebug, "debug")
+	Log(LevelError, "csp error",
+		Attr{"op", "sign"},
+		Attr{"code", -3},
+		Attr{"last_error", uint32(0x80090016)},
+	)
+
+	const want = `level=ERROR msg="csp error" op="sign" code=-3 last_error=0x80090016` + "\n"
+	if buf.String() != want {
+		t.Errorf("test failed: %q != %q", buf.String(), want)
+		return
+	}
+}
+
+func BenchmarkLogDisabled(b *testing.B) {
+	SetLogger(nil)
+	for i := 0; i < b.N; i++ {
+		Log(LevelError, "csp error", Attr{"code", -1})
+	}
+}
@@ -0,0 +1,29 @@
+/*
+func SetLogger(l Logger) {}
+func Enabled(level Level) bool {}
+func Log(level Level, msg string, attrs ...Attr) {}
+func NewTextLogger(w io.Writer, level Level) Logger {}
+func SlogLogger(h slog.Handler) Logger {}
+*/
+package csplog
+
+/*
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/towleeee/go-cryptopro/csplog"
+	gkeys "github.com/towleeee/go-cryptopro/gost_r_34_10_2012"
+)
+
+func main() {
+	csplog.SetLogger(csplog.SlogLogger(slog.NewTextHandler(os.Stderr, nil)))
+
+	_, err := gkeys.NewPrivKey(gkeys.NewConfig(gkeys.K256, "username", "wrong password"))
+	fmt.Println(errors.Is(err, gkeys.ErrWrongPassword))
+}
+*/
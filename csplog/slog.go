@@ -0,0 +1,32 @@
+//go:build go1.21
+// +build go1.21
+
+package csplog
+
+import (
+	"context"
+	"log/slog"
+)
+
+type slogLogger struct {
+	h slog.Handler
+}
+
+// Logger passing messages to the handler of log/slog,
+// e.g. SlogLogger(slog.Default().Handler()).
+func SlogLogger(h slog.Handler) Logger {
+	return slogLogger{h: h}
+}
+
+func (s slogLogger) Enabled(level Level) bool {
+	return s.h.Enabled(context.Background(), slog.Level(level))
+}
+
+func (s slogLogger) Log(level Level, msg string, attrs ...Attr) {
+	logger := slog.New(s.h)
+	args := make([]slog.Attr, 0, len(attrs))
+	for _, v := range attrs {
+		args = append(args, slog.Any(v.Key, v.Value))
+	}
+	logger.LogAttrs(context.Background(), slog.Level(level), msg, args...)
+}
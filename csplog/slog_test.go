@@ -0,0 +1,31 @@
+//go:build go1.21
+// +build go1.21
+
+package csplog
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestSlogLogger(t *testing.T) {
+	defer SetLogger(nil)
+
+	var buf bytes.Buffer
+	SetLogger(SlogLogger(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelWarn})))
+
+	Log(LevelDebug, "debug")
+	Log(LevelError, "csp error", Attr{"func", "SignHash: CryptSignHash"})
+
+	out := buf.String()
+	if strings.Contains(out, "debug") {
+		t.Errorf("test failed: debug message is logged")
+		return
+	}
+	if !strings.Contains(out, `level=ERROR msg="csp error" func="SignHash: CryptSignHash"`) {
+		t.Errorf("test failed: %q", out)
+		return
+	}
+}
@@ -0,0 +1,354 @@
+//go:build cgo && !purego
+// +build cgo,!purego
+
+package gost_r_34_10_2012
+
+/*
+#cgo linux,amd64 CFLAGS: -I/opt/cprocsp/include/cpcsp -DUNIX -DLINUX -DSIZEOF_VOID_P=8
+#cgo linux,386 CFLAGS: -I/opt/cprocsp/include/cpcsp -DUNIX -DLINUX -DSIZEOF_VOID_P=4
+#cgo linux,amd64 LDFLAGS: -L/opt/cprocsp/lib/amd64/ -lcapi10 -lcapi20 -lrdrsup -lssp
+#cgo linux,386 LDFLAGS: -L/opt/cprocsp/lib/ia32/ -lcapi10 -lcapi20 -lrdrsup -lssp
+
+#include "gost.h"
+*/
+import "C"
+import (
+	"bytes"
+	"unsafe"
+
+	"github.com/towleeee/go-cryptopro/internal/backend"
+	"github.com/towleeee/go-cryptopro/internal/csperr"
+)
+
+var (
+	_ backend.Session  = &cspSession{}
+	_ backend.Verifier = &cspVerifier{}
+)
+
+/*
+ * CRYPTOPRO CSP
+ */
+
+var provider backend.Keys = cspKeys{}
+
+type cspKeys struct{}
+
+func (cspKeys) CreateContainer(op string, prov byte, container, password string, keys uint32, exportable bool, paramSet, digestSet string) error {
+	defer csperr.Lock()()
+	ret := C.CreateContainer(
+		C.uchar(prov),
+		toCstring(container),
+		toCstring(password),
+		C.uint(keys),
+		C.uint(btoi(exportable)),
+		toCstringOpt(paramSet),
+		toCstringOpt(digestSet),
+	)
+	if ret != 0 {
+		return csperr.Last(op, csperr.KindCreate, int(ret))
+	}
+	return nil
+}
+
+func (cspKeys) CheckContainer(op string, prov byte, container, password string) error {
+	defer csperr.Lock()()
+	ret := C.CheckContainer(C.uchar(prov), toCstring(container), toCstring(password))
+	return containerError(op, ret)
+}
+
+func (cspKeys) DeleteContainer(op string, prov byte, container, password string) error {
+	defer csperr.Lock()()
+	ret := C.DeleteContainer(C.uchar(prov), toCstring(container), toCstring(password))
+	return containerError(op, ret)
+}
+
+func (cspKeys) CopyContainer(op string, prov byte, src, srcPassword, dst, dstPassword string) error {
+	defer csperr.Lock()()
+	ret := C.CopyContainer(
+		C.uchar(prov),
+		toCstring(src),
+		toCstring(srcPassword),
+		toCstring(dst),
+		toCstring(dstPassword),
+	)
+	return containerError(op, ret)
+}
+
+func (cspKeys) ChangePassword(op string, prov byte, container, password, newPassword string) error {
+	defer csperr.Lock()()
+	ret := C.ChangePassword(
+		C.uchar(prov),
+		toCstring(container),
+		toCstring(password),
+		toCstring(newPassword),
+	)
+	return containerError(op, ret)
+}
+
+func (cspKeys) EnumContainers(op string, prov byte) ([]string, error) {
+	var size C.uint
+
+	defer csperr.Lock()()
+	result := C.EnumContainers(C.uchar(prov), &size)
+	if result == nil {
+		return nil, csperr.Last(op, csperr.KindCall, -1)
+	}
+
+	resptr := unsafe.Pointer(result)
+	defer C.free(resptr)
+
+	var list []string
+	for _, v := range bytes.Split(C.GoBytes(resptr, C.int(size)), []byte{0}) {
+		if len(v) == 0 {
+			continue
+		}
+		list = append(list, string(v))
+	}
+	return list, nil
+}
+
+func (cspKeys) ContainerKeys(op string, prov byte, container string) (uint32, error) {
+	defer csperr.Lock()()
+	keys := C.ContainerKeys(C.uchar(prov), toCstring(container))
+	if keys < 0 {
+		return 0, csperr.Last(op, csperr.KindCall, int(keys))
+	}
+	return uint32(keys), nil
+}
+
+func (cspKeys) Sign(op string, prov byte, container, password string, spec uint32, data []byte, digest bool) ([]byte, error) {
+	var (
+		reslen C.uint
+		result *C.uchar
+	)
+
+	defer csperr.Lock()()
+	if digest {
+		result = C.SignHash(
+			C.uchar(prov),
+			toCstring(container),
+			toCstring(password),
+			toCbytes(data),
+			C.uint(len(data)),
+			&reslen,
+			C.uint(spec),
+		)
+	} else {
+		result = C.SignMessage(
+			C.uchar(prov),
+			toCstring(container),
+			toCstring(password),
+			toCbytes(data),
+			C.uint(len(data)),
+			&reslen,
+			C.uint(spec),
+		)
+	}
+	if result == nil {
+		return nil, csperr.Last(op, csperr.KindCall, -1)
+	}
+
+	resptr := unsafe.Pointer(result)
+	defer C.free(resptr)
+
+	return C.GoBytes(resptr, C.int(reslen)), nil
+}
+
+func (cspKeys) PubKey(op string, prov byte, container, password string, spec uint32) ([]byte, error) {
+	var (
+		hProv C.HCRYPTPROV
+		hKey  C.HCRYPTKEY
+	)
+
+	defer csperr.Lock()()
+	ret := C.OpenContainer(
+		C.uchar(prov),
+		&hProv,
+		&hKey,
+		toCstring(container),
+		toCstring(password),
+		C.uint(spec),
+	)
+	if ret < 0 {
+		return nil, csperr.Last(op, csperr.KindCall, int(ret))
+	}
+	defer func() {
+		C.CryptDestroyKey(hKey)
+		C.CryptReleaseContext(hProv, C.uint(0))
+	}()
+
+	return bytesPublicKey(op, &hKey)
+}
+
+func (cspKeys) CheckPubKey(op string, prov byte, pub []byte) error {
+	v, err := importPublicKey(op, prov, pub)
+	if err != nil {
+		return err
+	}
+	v.Close()
+	return nil
+}
+
+func (cspKeys) OpenSession(op string, prov byte, container, password string, spec uint32) (backend.Session, error) {
+	s := &cspSession{prov: prov, spec: spec}
+
+	defer csperr.Lock()()
+	ret := C.OpenSession(
+		C.uchar(prov),
+		&s.hProv,
+		&s.hKey,
+		toCstring(container),
+		toCstring(password),
+		C.uint(spec),
+	)
+	if ret != 0 {
+		return nil, containerError(op, ret)
+	}
+	return s, nil
+}
+
+func (cspKeys) ImportPubKey(op string, prov byte, pub []byte) (backend.Verifier, error) {
+	return importPublicKey(op, prov, pub)
+}
+
+func importPublicKey(op string, prov byte, pub []byte) (*cspVerifier, error) {
+	v := &cspVerifier{prov: prov}
+
+	defer csperr.Lock()()
+	ret := C.ImportPublicKey(C.uchar(prov), &v.hProv, &v.hKey, toCbytes(pub), C.uint(len(pub)))
+	if ret < 0 {
+		return nil, csperr.Last(op, csperr.KindImport, int(ret))
+	}
+	return v, nil
+}
+
+// PUBLICKEYBLOB of the key handle, the caller holds csperr.Lock.
+func bytesPublicKey(op string, hKey *C.HCRYPTKEY) ([]byte, error) {
+	var publen C.uint
+
+	pbytes := C.BytesPublicKey(hKey, &publen)
+	if pbytes == nil {
+		return nil, csperr.Last(op, csperr.KindCall, -1)
+	}
+	defer C.free(unsafe.Pointer(pbytes))
+
+	return C.GoBytes(unsafe.Pointer(pbytes), C.int(publen)), nil
+}
+
+/*
+ * SESSION
+ */
+
+// Provider and key handles of the opened container.
+type cspSession struct {
+	prov  byte
+	spec  uint32
+	hProv C.HCRYPTPROV
+	hKey  C.HCRYPTKEY
+}
+
+func (s *cspSession) PubKey(op string) ([]byte, error) {
+	defer csperr.Lock()()
+	return bytesPublicKey(op, &s.hKey)
+}
+
+func (s *cspSession) Sign(op string, data []byte, digest bool) ([]byte, error) {
+	var reslen C.uint
+
+	defer csperr.Lock()()
+	result := C.SessionSign(
+		&s.hProv,
+		C.uchar(s.prov),
+		toCbytes(data),
+		C.uint(len(data)),
+		C.uint(btoi(digest)),
+		&reslen,
+		C.uint(s.spec),
+	)
+	if result == nil {
+		return nil, csperr.Last(op, csperr.KindCall, -1)
+	}
+
+	resptr := unsafe.Pointer(result)
+	defer C.free(resptr)
+
+	return C.GoBytes(resptr, C.int(reslen)), nil
+}
+
+func (s *cspSession) Close() {
+	C.CryptDestroyKey(s.hKey)
+	C.CryptReleaseContext(s.hProv, C.uint(0))
+}
+
+/*
+ * VERIFIER
+ */
+
+// Provider and key handles of the imported public key.
+type cspVerifier struct {
+	prov  byte
+	hProv C.HCRYPTPROV
+	hKey  C.HCRYPTKEY
+}
+
+func (v *cspVerifier) Verify(op string, data, sign []byte, digest bool) error {
+	defer csperr.Lock()()
+	ret := C.VerifyPrepared(
+		C.uchar(v.prov),
+		&v.hProv,
+		&v.hKey,
+		toCbytes(sign),
+		C.uint(len(sign)),
+		toCbytes(data),
+		C.uint(len(data)),
+		C.uint(btoi(digest)),
+	)
+	if ret != 0 {
+		return csperr.Last(op, csperr.KindVerify, int(ret))
+	}
+	return nil
+}
+
+func (v *cspVerifier) Close() {
+	C.CryptDestroyKey(v.hKey)
+	C.CryptReleaseContext(v.hProv, C.uint(0))
+}
+
+/*
+ * ERRORS
+ */
+
+// Error of the container functions of gost.h
+// (csperr.KindContainer), nil on success.
+func containerError(op string, ret C.int) error {
+	if ret == 0 {
+		return nil
+	}
+	return csperr.Last(op, csperr.KindContainer, int(ret))
+}
+
+func btoi(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func toCstring(gostr string) *C.uchar {
+	return (*C.uchar)(&append([]byte(gostr), 0)[0])
+}
+
+// NULL for the empty string (provider default).
+func toCstringOpt(gostr string) *C.uchar {
+	if gostr == "" {
+		return nil
+	}
+	return toCstring(gostr)
+}
+
+func toCbytes(data []byte) *C.uchar {
+	if len(data) > 0 {
+		return (*C.uchar)(&data[0])
+	}
+	return nil
+}
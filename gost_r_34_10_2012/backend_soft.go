@@ -0,0 +1,13 @@
+//go:build purego
+// +build purego
+
+package gost_r_34_10_2012
+
+import (
+	"github.com/towleeee/go-cryptopro/internal/backend"
+	"github.com/towleeee/go-cryptopro/internal/backend/soft"
+)
+
+// Software backend without CryptoPro CSP:
+// the containers are kept in the memory of the process.
+var provider backend.Keys = soft.NewKeys()
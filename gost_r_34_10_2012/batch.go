@@ -0,0 +1,142 @@
+package gost_r_34_10_2012
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync"
+)
+
+var (
+	_ BatchVerifier = &ConcurrentBatchVerifier{}
+)
+
+/*
+ * CONCURRENT BATCH VERIFIER
+ */
+
+// Signature is not checked: the verification is stopped
+// at the first incorrect signature (see BatchOptions).
+var ErrBatchAborted = errors.New("error: batch verification aborted")
+
+type BatchOptions struct {
+	// Number of goroutines, runtime.GOMAXPROCS(0) if <= 0.
+	Workers int
+	// Stop of the verification at the first incorrect signature,
+	// the signatures not checked get ErrBatchAborted.
+	StopOnFailure bool
+}
+
+// Batch verifier checking the signatures by several goroutines.
+// Every goroutine imports each public key once.
+type ConcurrentBatchVerifier struct {
+	opts  BatchOptions
+	signs []trySign
+}
+
+// Create ConcurrentBatchVerifier.
+func NewConcurrentBatchVerifier(opts BatchOptions) *ConcurrentBatchVerifier {
+	if opts.Workers <= 0 {
+		opts.Workers = runtime.GOMAXPROCS(0)
+	}
+	return &ConcurrentBatchVerifier{opts: opts}
+}
+
+// Add a public key, data and a signature
+// for this data to the verifier object.
+// The length of the signature is checked by the key type.
+func (b *ConcurrentBatchVerifier) Add(key PubKey, message, signature []byte) error {
+	if err := checkBatchItem(key, signature); err != nil {
+		return err
+	}
+	b.signs = append(b.signs, trySign{
+		pubkey:    key,
+		message:   message,
+		signature: signature,
+	})
+	return nil
+}
+
+// Checking the entire list of signatures.
+func (b *ConcurrentBatchVerifier) Verify() (bool, []bool) {
+	ok, list, _ := b.VerifyContext(context.Background())
+	return ok, list
+}
+
+// Checking the entire list of signatures with cancellation.
+// The results are in the order of Add, the error of the signature
+// is ErrBadSignature, the error of CSP, ErrBatchAborted
+// or the error of the context if it is not checked.
+func (b *ConcurrentBatchVerifier) VerifyContext(ctx context.Context) (bool, []bool, []error) {
+	var (
+		list = make([]bool, len(b.signs))
+		errs = make([]error, len(b.signs))
+		jobs = make(chan int, len(b.signs))
+		wg   sync.WaitGroup
+	)
+
+	for i := range b.signs {
+		jobs <- i
+	}
+	close(jobs)
+
+	wctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	workers := b.opts.Workers
+	if workers > len(b.signs) {
+		workers = len(b.signs)
+	}
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			handles := make(map[string]handle)
+			defer func() {
+				for _, h := range handles {
+					h.Close()
+				}
+			}()
+
+			for i := range jobs {
+				if wctx.Err() != nil {
+					errs[i] = ctx.Err()
+					if errs[i] == nil {
+						errs[i] = ErrBatchAborted
+					}
+					continue
+				}
+				errs[i] = b.verify(handles, b.signs[i])
+				list[i] = errs[i] == nil
+				if !list[i] && b.opts.StopOnFailure {
+					cancel()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	res := true
+	for _, v := range list {
+		if !v {
+			res = false
+		}
+	}
+	return res, list, errs
+}
+
+// Checking of the signature with the key handle of the goroutine.
+func (b *ConcurrentBatchVerifier) verify(handles map[string]handle, v trySign) error {
+	key := PubKey256(v.pubkey.Bytes())
+	h, ok := handles[string(key)]
+	if !ok {
+		var err error
+		h, err = importPubKey(key)()
+		if err != nil {
+			return err
+		}
+		handles[string(key)] = h
+	}
+	return verifyHandle("verify signature", h, v.message, v.signature, false)
+}
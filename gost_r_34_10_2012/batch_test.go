@@ -0,0 +1,170 @@
+// go test -v -bench=. -benchtime=100x
+package gost_r_34_10_2012
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func testBatchSigns(t testing.TB, n int) ([]PubKey, [][]byte, [][]byte) {
+	var (
+		keys  []PubKey
+		msgs  [][]byte
+		signs [][]byte
+	)
+	for i := 0; i < n; i++ {
+		priv, pub := PRIVATE_KEY, PUBLIC_KEY
+		if i%2 == 1 {
+			priv, pub = PRIVATE_KEY_512, PUBLIC_KEY_512
+		}
+		msg := append([]byte{byte(i)}, TEST_MESSAGE_3...)
+		sign, err := priv.Sign(msg, AT_SIGNATURE)
+		if err != nil {
+			t.Errorf("test failed: sign")
+			return nil, nil, nil
+		}
+		keys = append(keys, pub)
+		msgs = append(msgs, msg)
+		signs = append(signs, sign)
+	}
+	return keys, msgs, signs
+}
+
+func TestBatchVerifierAdd(t *testing.T) {
+	sign, err := PRIVATE_KEY.Sign(TEST_MESSAGE_1, AT_SIGNATURE)
+	if err != nil {
+		t.Errorf("test failed: sign")
+		return
+	}
+
+	for _, batchv := range []BatchVerifier{
+		NewBatchVerifier(),
+		NewConcurrentBatchVerifier(BatchOptions{}),
+	} {
+		if err := batchv.Add(PUBLIC_KEY, TEST_MESSAGE_1, sign); err != nil {
+			t.Errorf("test failed: add: %v", err)
+			return
+		}
+		if err := batchv.Add(PUBLIC_KEY_512, TEST_MESSAGE_1, sign); err == nil {
+			t.Errorf("test failed: add signature 256 for key 512")
+			return
+		}
+		if err := batchv.Add(PUBLIC_KEY, TEST_MESSAGE_1, sign[1:]); err == nil {
+			t.Errorf("test failed: add short signature")
+			return
+		}
+		if ok, list := batchv.Verify(); !ok || len(list) != 1 {
+			t.Errorf("test failed: verify %v", list)
+			return
+		}
+	}
+}
+
+func TestConcurrentBatchVerifier(t *testing.T) {
+	keys, msgs, signs := testBatchSigns(t, 16)
+	if keys == nil {
+		return
+	}
+	signs[5] = append([]byte{}, signs[5]...)
+	signs[5][7] ^= byte(0x1)
+
+	batchv := NewConcurrentBatchVerifier(BatchOptions{Workers: 4})
+	for i := range keys {
+		if err := batchv.Add(keys[i], msgs[i], signs[i]); err != nil {
+			t.Errorf("test failed: add (%d): %v", i, err)
+			return
+		}
+	}
+
+	ok, list, errs := batchv.VerifyContext(context.Background())
+	if ok || len(list) != len(keys) || len(errs) != len(keys) {
+		t.Errorf("test failed: batch verify")
+		return
+	}
+	for i := range list {
+		if list[i] != (i != 5) || (errs[i] == nil) != list[i] {
+			t.Errorf("test failed: batch verify (%d): %v", i, errs[i])
+			return
+		}
+	}
+	if !errors.Is(errs[5], ErrBadSignature) {
+		t.Errorf("test failed: bad signature: %v", errs[5])
+		return
+	}
+}
+
+func TestConcurrentBatchVerifierStop(t *testing.T) {
+	keys, msgs, signs := testBatchSigns(t, 4)
+	if keys == nil {
+		return
+	}
+
+	batchv := NewConcurrentBatchVerifier(BatchOptions{Workers: 1, StopOnFailure: true})
+	_ = batchv.Add(keys[0], TEST_MESSAGE_1, signs[0])
+	for i := 1; i < len(keys); i++ {
+		_ = batchv.Add(keys[i], msgs[i], signs[i])
+	}
+
+	ok, list, errs := batchv.VerifyContext(context.Background())
+	if ok || list[0] || !errors.Is(errs[0], ErrBadSignature) {
+		t.Errorf("test failed: first signature: %v", errs[0])
+		return
+	}
+	for i := 1; i < len(keys); i++ {
+		if list[i] || !errors.Is(errs[i], ErrBatchAborted) {
+			t.Errorf("test failed: aborted (%d): %v", i, errs[i])
+			return
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, _, errs = batchv.VerifyContext(ctx)
+	for i := range errs {
+		if !errors.Is(errs[i], context.Canceled) {
+			t.Errorf("test failed: canceled (%d): %v", i, errs[i])
+			return
+		}
+	}
+}
+
+func BenchmarkBatchVerifier(b *testing.B) {
+	keys, msgs, signs := testBatchSigns(b, 64)
+	if keys == nil {
+		return
+	}
+
+	batchv := NewBatchVerifier()
+	for i := range keys {
+		_ = batchv.Add(keys[i], msgs[i], signs[i])
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if ok, _ := batchv.Verify(); !ok {
+			b.Errorf("benchmark failed: verify")
+			break
+		}
+	}
+}
+
+func BenchmarkConcurrentBatchVerifier(b *testing.B) {
+	keys, msgs, signs := testBatchSigns(b, 64)
+	if keys == nil {
+		return
+	}
+
+	batchv := NewConcurrentBatchVerifier(BatchOptions{})
+	for i := range keys {
+		_ = batchv.Add(keys[i], msgs[i], signs[i])
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if ok, _ := batchv.Verify(); !ok {
+			b.Errorf("benchmark failed: verify")
+			break
+		}
+	}
+}
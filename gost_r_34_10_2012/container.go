@@ -0,0 +1,183 @@
+package gost_r_34_10_2012
+
+import (
+	"fmt"
+	"strings"
+)
+
+/*
+ * CONTAINERS
+ */
+
+// Container available to the crypto provider.
+type ContainerInfo struct {
+	// Fully qualified container name "\\.\READER\NAME".
+	FQCN string
+	// Reader (storage) of the container, e.g. "HDIMAGE".
+	Reader string
+	// Container name without the reader.
+	Name string
+	// Key pairs stored in the container.
+	Signature bool
+	Exchange  bool
+	// Error of opening the container (e.g. the reader is removed),
+	// the key pairs are unknown in this case.
+	Err error
+}
+
+// Listing of the containers available to the provider
+// with the key pairs stored in each container.
+// The password is not required. A container which can not be opened
+// is listed with its error in ContainerInfo.Err.
+func ListContainers(prov ProvType) ([]ContainerInfo, error) {
+	switch prov {
+	case K256, K512:
+		// pass
+	default:
+		return nil, fmt.Errorf("error: read prov type")
+	}
+
+	names, err := provider.EnumContainers("enum containers", byte(prov))
+	if err != nil {
+		return nil, err
+	}
+
+	var list []ContainerInfo
+	for _, v := range names {
+		info := parseFQCN(v)
+
+		keys, err := provider.ContainerKeys("open container "+info.FQCN, byte(prov), info.FQCN)
+		if err != nil {
+			info.Err = err
+			list = append(list, info)
+			continue
+		}
+		info.Signature = keys&0x1 != 0
+		info.Exchange = keys&0x2 != 0
+
+		list = append(list, info)
+	}
+
+	return list, nil
+}
+
+// Name of the container created by the config,
+// the same as ContainerInfo.Name.
+func (cfg *Config) ContainerName() string {
+	return decodeName(cfg.container)
+}
+
+// Copy of the config with the container placed on the reader
+// (e.g. "HDIMAGE", "FAT12_A"). The reader is used by GenPrivKey,
+// DeleteContainer, CopyContainer and RenameContainer,
+// keys are found by the container name on any reader.
+func (cfg *Config) WithReader(reader string) *Config {
+	c := *cfg
+	c.reader = reader
+	return &c
+}
+
+// Container name for CSP with the reader if it is set.
+func (cfg *Config) fqcn() string {
+	if cfg.reader == "" {
+		return cfg.ContainerName()
+	}
+	return fmt.Sprintf(`\\.\%s\%s`, cfg.reader, cfg.ContainerName())
+}
+
+// Deleting the container with all its keys.
+// The password of the config is checked before deleting.
+func DeleteContainer(cfg *Config) error {
+	if cfg.err != nil {
+		return cfg.err
+	}
+	return provider.DeleteContainer(
+		"delete container",
+		byte(cfg.prov),
+		cfg.fqcn(),
+		decodeName(cfg.password),
+	)
+}
+
+// Copying the container with all its keys to the container of dst,
+// the copy is protected by the password of dst.
+// Non-exportable keys are copied too.
+func CopyContainer(src, dst *Config) error {
+	switch {
+	case src.err != nil:
+		return src.err
+	case dst.err != nil:
+		return dst.err
+	case src.prov != dst.prov:
+		return fmt.Errorf("error: prov type mismatch")
+	}
+	return provider.CopyContainer(
+		"copy container",
+		byte(src.prov),
+		src.fqcn(),
+		decodeName(src.password),
+		dst.fqcn(),
+		decodeName(dst.password),
+	)
+}
+
+// Moving the container to the name (or the reader) of dst:
+// copying and deleting of the source container.
+func RenameContainer(src, dst *Config) error {
+	err := CopyContainer(src, dst)
+	if err != nil {
+		return err
+	}
+	return DeleteContainer(src)
+}
+
+// Changing the password of the container.
+// The result is the bytes of the private key with the new password
+// (the same as NewPrivKey(cfg).Bytes() for the new password),
+// the old bytes of the private key become invalid.
+func ChangePassword(cfg *Config, newPassword string) ([]byte, error) {
+	if cfg.err != nil {
+		return nil, cfg.err
+	}
+	next, err := cfg.withPassword(newPassword)
+	if err != nil {
+		return nil, err
+	}
+	if err := changePassword(cfg, next); err != nil {
+		return nil, err
+	}
+	priv, err := NewPrivKey(next)
+	if err != nil {
+		return nil, err
+	}
+	return priv.Bytes(), nil
+}
+
+func changePassword(cfg, next *Config) error {
+	return provider.ChangePassword(
+		"change password",
+		byte(cfg.prov),
+		cfg.fqcn(),
+		decodeName(cfg.password),
+		decodeName(next.password),
+	)
+}
+
+// Splitting "\\.\READER\NAME" into the reader and the name.
+func parseFQCN(fqcn string) ContainerInfo {
+	info := ContainerInfo{
+		FQCN: fqcn,
+		Name: fqcn,
+	}
+	rest := strings.TrimPrefix(fqcn, `\\.\`)
+	if rest == fqcn {
+		return info
+	}
+	i := strings.Index(rest, `\`)
+	if i < 0 {
+		return info
+	}
+	info.Reader = rest[:i]
+	info.Name = rest[i+1:]
+	return info
+}
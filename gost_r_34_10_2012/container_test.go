@@ -0,0 +1,298 @@
+// go test -v -bench=. -benchtime=100x
+package gost_r_34_10_2012
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestListContainers(t *testing.T) {
+	cfg := NewConfig(K256, TEST_SUBJECT, TEST_PASSWORD)
+
+	list, err := ListContainers(K256)
+	if err != nil {
+		t.Errorf("test failed: list containers")
+		return
+	}
+
+	for _, v := range list {
+		if v.Name != cfg.ContainerName() {
+			continue
+		}
+		if v.Reader == "" || v.FQCN == "" {
+			t.Errorf("test failed: container reader")
+			return
+		}
+		if !v.Signature {
+			t.Errorf("test failed: container signature key")
+			return
+		}
+		return
+	}
+
+	t.Errorf("test failed: container not found")
+}
+
+func TestParseFQCN(t *testing.T) {
+	info := parseFQCN(`\\.\HDIMAGE\subject`)
+	if info.Reader != "HDIMAGE" || info.Name != "subject" {
+		t.Errorf("test failed: parse fqcn (1)")
+		return
+	}
+
+	info = parseFQCN("subject")
+	if info.Reader != "" || info.Name != "subject" {
+		t.Errorf("test failed: parse fqcn (2)")
+		return
+	}
+}
+
+func TestDeleteContainer(t *testing.T) {
+	cfg := NewConfig(K256, TEST_SUBJECT+"_delete", TEST_PASSWORD)
+	err := GenPrivKey(cfg)
+	if err != nil {
+		t.Errorf("test failed: gen priv key")
+		return
+	}
+
+	err = DeleteContainer(NewConfig(K256, TEST_SUBJECT+"_delete", TEST_PASSWORD+"_wrong"))
+	if !errors.Is(err, ErrWrongPassword) {
+		t.Errorf("test failed: delete container with wrong password")
+		return
+	}
+
+	err = DeleteContainer(cfg)
+	if err != nil {
+		t.Errorf("test failed: delete container")
+		return
+	}
+
+	_, err = NewPrivKey(cfg)
+	if err == nil {
+		t.Errorf("test failed: new priv key after delete")
+		return
+	}
+
+	err = DeleteContainer(cfg)
+	if !errors.Is(err, ErrBadKeyset) {
+		t.Errorf("test failed: delete missing container")
+		return
+	}
+}
+
+func TestRenameContainer(t *testing.T) {
+	var (
+		src = NewConfig(K256, TEST_SUBJECT+"_src", TEST_PASSWORD)
+		dst = NewConfig(K256, TEST_SUBJECT+"_dst", TEST_PASSWORD)
+		cp  = NewConfig(K256, TEST_SUBJECT+"_copy", TEST_PASSWORD)
+	)
+	defer func() {
+		DeleteContainer(src)
+		DeleteContainer(dst)
+		DeleteContainer(cp)
+	}()
+
+	err := GenPrivKey(src)
+	if err != nil {
+		t.Errorf("test failed: gen priv key")
+		return
+	}
+
+	priv, err := NewPrivKey(src)
+	if err != nil {
+		t.Errorf("test failed: new priv key")
+		return
+	}
+	pub := priv.PubKey(AT_SIGNATURE)
+
+	err = CopyContainer(src, cp)
+	if err != nil {
+		t.Errorf("test failed: copy container")
+		return
+	}
+
+	err = CopyContainer(src, cp)
+	if !errors.Is(err, ErrContainerExists) {
+		t.Errorf("test failed: copy to existing container")
+		return
+	}
+
+	err = RenameContainer(src, dst)
+	if err != nil {
+		t.Errorf("test failed: rename container")
+		return
+	}
+
+	_, err = NewPrivKey(src)
+	if err == nil {
+		t.Errorf("test failed: new priv key after rename")
+		return
+	}
+
+	for _, cfg := range []*Config{dst, cp} {
+		priv, err := NewPrivKey(cfg)
+		if err != nil {
+			t.Errorf("test failed: new priv key of copy")
+			return
+		}
+
+		sign, err := priv.Sign(TEST_MESSAGE_1, AT_SIGNATURE)
+		if err != nil {
+			t.Errorf("test failed: sign with copy")
+			return
+		}
+
+		if !pub.VerifySignature(TEST_MESSAGE_1, sign) {
+			t.Errorf("test failed: verify sign of copy")
+			return
+		}
+	}
+}
+
+func TestChangePassword(t *testing.T) {
+	cfg := NewConfig(K256, TEST_SUBJECT+"_password", TEST_PASSWORD)
+	defer DeleteContainer(NewConfig(K256, TEST_SUBJECT+"_password", TEST_PASSWORD+"_new"))
+
+	err := GenPrivKey(cfg)
+	if err != nil {
+		t.Errorf("test failed: gen priv key")
+		return
+	}
+
+	old, err := NewPrivKey(cfg)
+	if err != nil {
+		t.Errorf("test failed: new priv key")
+		return
+	}
+
+	_, err = ChangePassword(NewConfig(K256, TEST_SUBJECT+"_password", TEST_PASSWORD+"_wrong"), TEST_PASSWORD+"_new")
+	if !errors.Is(err, ErrWrongPassword) {
+		t.Errorf("test failed: change password with wrong password")
+		return
+	}
+
+	pbytes, err := ChangePassword(cfg, TEST_PASSWORD+"_new")
+	if err != nil {
+		t.Errorf("test failed: change password")
+		return
+	}
+
+	_, err = LoadPrivKey(old.Bytes())
+	if err == nil {
+		t.Errorf("test failed: load priv key with old password")
+		return
+	}
+
+	priv, err := LoadPrivKey(pbytes)
+	if err != nil {
+		t.Errorf("test failed: load priv key with new password")
+		return
+	}
+
+	cmp, err := NewPrivKey(NewConfig(K256, TEST_SUBJECT+"_password", TEST_PASSWORD+"_new"))
+	if err != nil || !priv.Equals(cmp) {
+		t.Errorf("test failed: new priv key with new password")
+		return
+	}
+
+	sign, err := priv.Sign(TEST_MESSAGE_1, AT_SIGNATURE)
+	if err != nil {
+		t.Errorf("test failed: sign with new password")
+		return
+	}
+
+	if !priv.PubKey(AT_SIGNATURE).VerifySignature(TEST_MESSAGE_1, sign) {
+		t.Errorf("test failed: verify sign with new password")
+		return
+	}
+}
+
+func TestGenKeys(t *testing.T) {
+	cfg := NewConfig(K256, TEST_SUBJECT+"_keys", TEST_PASSWORD).
+		WithGenKeys(AT_SIGNATURE, AT_KEYEXCHANGE).
+		WithExportable(false)
+	defer DeleteContainer(cfg)
+
+	err := GenPrivKey(cfg)
+	if err != nil {
+		t.Errorf("test failed: gen priv key")
+		return
+	}
+
+	list, err := ListContainers(K256)
+	if err != nil {
+		t.Errorf("test failed: list containers")
+		return
+	}
+	found := false
+	for _, v := range list {
+		if v.Name == cfg.ContainerName() {
+			found = v.Signature && v.Exchange
+		}
+	}
+	if !found {
+		t.Errorf("test failed: container keys")
+		return
+	}
+
+	priv, err := NewPrivKey(cfg.WithKeySpec(AT_KEYEXCHANGE))
+	if err != nil {
+		t.Errorf("test failed: new priv key")
+		return
+	}
+
+	// PrivContainer uses its own KeySpec.
+	var (
+		pubSign = priv.(PrivContainer).PrivKey.PubKey(AT_SIGNATURE)
+		pubExch = priv.PubKey(AT_SIGNATURE)
+	)
+	if pubSign.Equals(pubExch) {
+		t.Errorf("test failed: key pairs are equal")
+		return
+	}
+
+	sign, err := priv.Sign(TEST_MESSAGE_1, AT_KEYEXCHANGE)
+	if err != nil {
+		t.Errorf("test failed: sign with exchange key")
+		return
+	}
+	if !pubExch.VerifySignature(TEST_MESSAGE_1, sign) {
+		t.Errorf("test failed: verify sign of exchange key")
+		return
+	}
+
+	cfg = NewConfig(K256, TEST_SUBJECT+"_exchange", TEST_PASSWORD).WithKeySpec(AT_KEYEXCHANGE)
+	defer DeleteContainer(cfg)
+
+	err = GenPrivKey(cfg)
+	if err != nil {
+		t.Errorf("test failed: gen exchange key")
+		return
+	}
+
+	list, err = ListContainers(K256)
+	if err != nil {
+		t.Errorf("test failed: list containers")
+		return
+	}
+	found = false
+	for _, v := range list {
+		if v.Name == cfg.ContainerName() {
+			found = !v.Signature && v.Exchange
+		}
+	}
+	if !found {
+		t.Errorf("test failed: container exchange key")
+		return
+	}
+}
+
+func BenchmarkListContainers(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, err := ListContainers(K256)
+		if err != nil {
+			b.Errorf("benchmark failed: list containers")
+			break
+		}
+	}
+}
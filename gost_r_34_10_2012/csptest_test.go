@@ -0,0 +1,278 @@
+// go test -v -bench=. -benchtime=100x
+package gost_r_34_10_2012
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/towleeee/go-cryptopro/internal/backend/csptest"
+	"github.com/towleeee/go-cryptopro/internal/csperr"
+)
+
+func TestFakeContainers(t *testing.T) {
+	p := csptest.Install(t, &provider)
+	cfg := NewConfig(K256, "fake", TEST_PASSWORD)
+
+	p.Fail("CryptAcquireContext", csperr.NTE_EXISTS)
+	err := GenPrivKey(cfg)
+	var cerr *Error
+	if !errors.As(err, &cerr) || cerr.Code != 1 || !errors.Is(err, ErrContainerExists) {
+		t.Errorf("test failed: gen priv key: %v", err)
+		return
+	}
+	p.Fail("CryptAcquireContext", csperr.NTE_FAIL)
+	err = GenPrivKey(cfg)
+	if !errors.As(err, &cerr) || cerr.Code != -1 || errors.Is(err, ErrContainerExists) {
+		t.Errorf("test failed: gen priv key fail: %v", err)
+		return
+	}
+	p.Fail("CryptSetKeyParam", csperr.NTE_BAD_DATA)
+	if err := GenPrivKey(cfg.WithParamSet(ParamSetCryptoProA)); !errors.As(err, &cerr) || cerr.Code != -3 {
+		t.Errorf("test failed: gen priv key params: %v", err)
+		return
+	}
+	if err := GenPrivKey(cfg); err != nil {
+		t.Errorf("test failed: %s", err)
+		return
+	}
+
+	p.Fail("CryptAcquireContext", csperr.NTE_BAD_KEYSET)
+	if _, err := NewPrivKey(cfg); !errors.Is(err, ErrBadKeyset) {
+		t.Errorf("test failed: bad keyset: %v", err)
+		return
+	}
+	p.Fail("CryptSetProvParam", csperr.SCARD_W_WRONG_CHV)
+	if _, err := NewPrivKey(cfg); !errors.Is(err, ErrWrongPassword) {
+		t.Errorf("test failed: wrong password: %v", err)
+		return
+	}
+	if _, err := NewPrivKey(cfg); err != nil {
+		t.Errorf("test failed: %s", err)
+		return
+	}
+
+	p.Fail("CryptGetProvParam", csperr.NTE_FAIL)
+	if _, err := ListContainers(K256); !errors.As(err, &cerr) || cerr.LastError != csperr.NTE_FAIL {
+		t.Errorf("test failed: list containers: %v", err)
+		return
+	}
+	if err := GenPrivKey(NewConfig(K256, "fake 2", TEST_PASSWORD)); err != nil {
+		t.Errorf("test failed: %s", err)
+		return
+	}
+	p.Fail("ContainerKeys: CryptAcquireContext", csperr.NTE_BAD_KEYSET)
+	list, err := ListContainers(K256)
+	if err != nil || len(list) != 2 {
+		t.Errorf("test failed: list with unopenable container %v: %v", list, err)
+		return
+	}
+	if (list[0].Err == nil) == (list[1].Err == nil) || (list[0].Err == nil) != list[0].Signature {
+		t.Errorf("test failed: error of container %+v", list)
+		return
+	}
+	if err := DeleteContainer(NewConfig(K256, "fake 2", TEST_PASSWORD)); err != nil {
+		t.Errorf("test failed: %s", err)
+		return
+	}
+
+	dst := NewConfig(K256, "fake copy", TEST_PASSWORD)
+	p.Fail("CopyContainer: CryptAcquireContext", csperr.NTE_EXISTS)
+	if err := RenameContainer(cfg, dst); !errors.Is(err, ErrContainerExists) {
+		t.Errorf("test failed: rename container: %v", err)
+		return
+	}
+	if _, err := NewPrivKey(dst); !errors.Is(err, ErrBadKeyset) {
+		t.Errorf("test failed: copy is created: %v", err)
+		return
+	}
+	if _, err := NewPrivKey(cfg); err != nil {
+		t.Errorf("test failed: source is deleted: %s", err)
+		return
+	}
+
+	p.Fail("ChangePassword: CryptSetProvParam", csperr.NTE_FAIL)
+	if _, err := ChangePassword(cfg, "new password"); !errors.As(err, &cerr) || cerr.Code != -2 {
+		t.Errorf("test failed: change password: %v", err)
+		return
+	}
+	if _, err := NewPrivKey(cfg); err != nil {
+		t.Errorf("test failed: password is changed: %s", err)
+		return
+	}
+}
+
+// The error of the hashing of the name is returned by the functions
+// using the config.
+func TestConfigError(t *testing.T) {
+	werr := errors.New("hash failed")
+	cfg := wrapConfig(&Config{prov: K256, container: "fake", keySpec: AT_SIGNATURE}, werr)
+
+	if err := GenPrivKey(cfg.WithParamSet(ParamSetCryptoProA)); err != werr {
+		t.Errorf("test failed: gen priv key: %v", err)
+		return
+	}
+	if _, err := NewPrivKey(cfg); err != werr {
+		t.Errorf("test failed: new priv key: %v", err)
+		return
+	}
+	if err := RenameContainer(NewConfig(K256, "fake", TEST_PASSWORD), cfg); err != werr {
+		t.Errorf("test failed: rename container: %v", err)
+		return
+	}
+	if _, err := ChangePassword(cfg, "new password"); err != werr {
+		t.Errorf("test failed: change password: %v", err)
+		return
+	}
+}
+
+// The key spec is kept by the wrapping of the config
+// (the key spec of PrivContainer was 0 before).
+func TestConfigKeySpec(t *testing.T) {
+	csptest.Install(t, &provider)
+	cfg := NewConfig(K256, "fake", TEST_PASSWORD).WithGenKeys(AT_SIGNATURE, AT_KEYEXCHANGE)
+
+	if err := GenPrivKey(cfg); err != nil {
+		t.Errorf("test failed: %s", err)
+		return
+	}
+	for _, v := range []struct {
+		cfg  *Config
+		spec KeySpec
+	}{
+		{cfg, AT_SIGNATURE},
+		{cfg.WithKeySpec(AT_KEYEXCHANGE), AT_KEYEXCHANGE},
+	} {
+		priv, err := NewPrivKey(v.cfg)
+		if err != nil {
+			t.Errorf("test failed: %s", err)
+			return
+		}
+		if priv.(PrivContainer).KeySpec != v.spec {
+			t.Errorf("test failed: key spec %d != %d", priv.(PrivContainer).KeySpec, v.spec)
+			return
+		}
+		pub, err := priv.(PrivContainer).PrivKey.PubKeyE(v.spec)
+		if err != nil {
+			t.Errorf("test failed: %s", err)
+			return
+		}
+		if !pub.Equals(priv.PubKey(0)) {
+			t.Errorf("test failed: pub key of key spec %d", v.spec)
+			return
+		}
+	}
+
+	c, err := cfg.WithKeySpec(AT_KEYEXCHANGE).withPassword("new password")
+	if err != nil || c.keySpec != AT_KEYEXCHANGE {
+		t.Errorf("test failed: key spec of new password: %v", err)
+		return
+	}
+	if c := SimpleConfig(K256, "fake", TEST_PASSWORD, AT_KEYEXCHANGE); c.keySpec != AT_KEYEXCHANGE {
+		t.Errorf("test failed: key spec of simple config %d", c.keySpec)
+		return
+	}
+}
+
+func TestFakeSign(t *testing.T) {
+	p := csptest.Install(t, &provider)
+	cfg := NewConfig(K256, "fake", TEST_PASSWORD)
+
+	if err := GenPrivKey(cfg); err != nil {
+		t.Errorf("test failed: %s", err)
+		return
+	}
+	priv, err := NewPrivKey(cfg)
+	if err != nil {
+		t.Errorf("test failed: %s", err)
+		return
+	}
+
+	p.Fail("CryptGetUserKey", csperr.NTE_NO_KEY)
+	if _, err := priv.PubKeyE(AT_SIGNATURE); !errors.As(err, new(*Error)) {
+		t.Errorf("test failed: pub key: %v", err)
+		return
+	}
+	pub, err := priv.PubKeyE(AT_SIGNATURE)
+	if err != nil {
+		t.Errorf("test failed: %s", err)
+		return
+	}
+
+	p.Fail("CryptSignHash", csperr.NTE_BAD_KEY)
+	if _, err := priv.Sign(TEST_MESSAGE_1, AT_SIGNATURE); !errors.Is(err, ErrBadKey) {
+		t.Errorf("test failed: sign: %v", err)
+		return
+	}
+	sign, err := priv.Sign(TEST_MESSAGE_1, AT_SIGNATURE)
+	if err != nil {
+		t.Errorf("test failed: %s", err)
+		return
+	}
+
+	p.Fail("CryptImportKey", csperr.NTE_BAD_PUBLIC_KEY)
+	if err := pub.VerifySignatureE(TEST_MESSAGE_1, sign); !errors.Is(err, ErrBadKey) {
+		t.Errorf("test failed: import pub key: %v", err)
+		return
+	}
+	p.Fail("CryptVerifySignature", csperr.NTE_BAD_SIGNATURE)
+	if pub.VerifySignature(TEST_MESSAGE_1, sign) {
+		t.Errorf("test failed: verify signature")
+		return
+	}
+	if err := pub.VerifySignatureE(TEST_MESSAGE_1, sign); err != nil {
+		t.Errorf("test failed: %s", err)
+		return
+	}
+}
+
+func TestFakeSigningSession(t *testing.T) {
+	p := csptest.Install(t, &provider)
+	cfg := NewConfig(K256, "fake", TEST_PASSWORD)
+
+	if err := GenPrivKey(cfg); err != nil {
+		t.Errorf("test failed: %s", err)
+		return
+	}
+	priv, err := NewPrivKey(cfg)
+	if err != nil {
+		t.Errorf("test failed: %s", err)
+		return
+	}
+
+	p.Fail("CryptSetProvParam", csperr.SCARD_W_WRONG_CHV)
+	if _, err := NewSigningSession(priv, 1); !errors.Is(err, ErrWrongPassword) {
+		t.Errorf("test failed: wrong password: %v", err)
+		return
+	}
+
+	s, err := NewSigningSession(priv, 1)
+	if err != nil {
+		t.Errorf("test failed: %s", err)
+		return
+	}
+	defer s.Close()
+
+	p.Fail("signData: CryptSignHash (2)", csperr.NTE_FAIL)
+	if _, err := s.Sign(TEST_MESSAGE_1); err == nil {
+		t.Errorf("test failed: session sign")
+		return
+	}
+
+	// The handle is returned to the pool of one handle after the failure.
+	p.Reset()
+	for i := 0; i < 3; i++ {
+		sign, err := s.Sign(TEST_MESSAGE_1)
+		if err != nil {
+			t.Errorf("test failed: %s", err)
+			return
+		}
+		if !s.PubKey().VerifySignature(TEST_MESSAGE_1, sign) {
+			t.Errorf("test failed: verify signature")
+			return
+		}
+	}
+	if n := p.Calls("OpenSession: CryptGetUserKey"); n != 0 {
+		t.Errorf("test failed: container is opened %d times", n)
+		return
+	}
+}
@@ -4,8 +4,10 @@ func NewPrivKey(cfg *Config) (PrivKey, error) {}
 func LoadPrivKey(pbytes []byte) (PrivKey, error) {}
 func (key PrivKey) Bytes() []byte {}
 func (key PrivKey) String() string {}
-func (key PrivKey) Sign(dbytes []byte) ([]byte, error) {}
+func (key PrivKey) Sign(dbytes []byte, spec KeySpec, format ...SignatureFormat) ([]byte, error) {}
+func (key PrivKey) SignDigest(digest []byte, spec KeySpec, format ...SignatureFormat) ([]byte, error) {}
 func (key PrivKey) PubKey() PubKey {}
+func (key PrivKey) PubKeyE(spec KeySpec) (PubKey, error) {}
 func (key PrivKey) Equals(cmp PrivKey) bool {}
 func (key PrivKey) Type() string {}
 
@@ -13,7 +15,13 @@ func LoadPubKey(pbytes []byte) (PubKey, error) {}
 func (key PubKey) Address() Address {}
 func (key PubKey) Bytes() []byte {}
 func (key PubKey) String() string {}
-func (key PubKey) VerifySignature(dbytes, sign []byte) bool {}
+func (key PubKey) VerifySignature(dbytes, sign []byte, format ...SignatureFormat) bool {}
+func (key PubKey) VerifyDigest(digest, sign []byte, format ...SignatureFormat) bool {}
+func (key PubKey) VerifySignatureE(dbytes, sign []byte, format ...SignatureFormat) error {}
+func (key PubKey) VerifyDigestE(digest, sign []byte, format ...SignatureFormat) error {}
+func (key PubKey) Params() Params {}
+func (key PubKey) Point() []byte {}
+func (key PubKey) Coordinates() (x, y []byte) {}
 func (key PubKey) Equals(cmp PubKey) bool {}
 func (key PubKey) Type() string {}
 
@@ -21,7 +29,57 @@ func NewBatchVerifier() BatchVerifier {}
 func (b *BatchVerifier) Add(key PubKey, message, signature []byte) error {}
 func (b *BatchVerifier) Verify() (bool, []bool) {}
 
+func NewConcurrentBatchVerifier(opts BatchOptions) *ConcurrentBatchVerifier {}
+func (b *ConcurrentBatchVerifier) Add(key PubKey, message, signature []byte) error {}
+func (b *ConcurrentBatchVerifier) Verify() (bool, []bool) {}
+func (b *ConcurrentBatchVerifier) VerifyContext(ctx context.Context) (bool, []bool, []error) {}
+
+func (k ProvType) Hash() ghash.ProvType {}
+func (k ProvType) SignatureSize() int {}
+
 func NewConfig(prov ProvType, subject, password string) *Config {}
+
+func ListContainers(prov ProvType) ([]ContainerInfo, error) {}
+func (cfg *Config) ContainerName() string {}
+func (cfg *Config) WithKeySpec(spec KeySpec) *Config {}
+func (cfg *Config) WithGenKeys(specs ...KeySpec) *Config {}
+func (cfg *Config) WithExportable(exportable bool) *Config {}
+func (cfg *Config) WithParamSet(paramSet asn1.ObjectIdentifier) *Config {}
+func (cfg *Config) WithReader(reader string) *Config {}
+func DeleteContainer(cfg *Config) error {}
+func CopyContainer(src, dst *Config) error {}
+func RenameContainer(src, dst *Config) error {}
+func ChangePassword(cfg *Config, newPassword string) ([]byte, error) {}
+
+func NewSigner(priv PrivKey) (*Signer, error) {}
+func NewSignerSpec(priv PrivKey, spec KeySpec) (*Signer, error) {}
+func (s *Signer) Public() crypto.PublicKey {}
+func (s *Signer) Sign(rand io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {}
+
+func NewSigningSession(priv PrivKey, size int) (*SigningSession, error) {}
+func NewSigningSessionSpec(priv PrivKey, spec KeySpec, size int) (*SigningSession, error) {}
+func (s *SigningSession) PubKey() PubKey {}
+func (s *SigningSession) Sign(dbytes []byte, format ...SignatureFormat) ([]byte, error) {}
+func (s *SigningSession) SignDigest(digest []byte, format ...SignatureFormat) ([]byte, error) {}
+func (s *SigningSession) Close() error {}
+
+func NewPreparedPubKey(pub PubKey, size int) (*PreparedPubKey, error) {}
+func (p *PreparedPubKey) VerifySignature(dbytes, sign []byte, format ...SignatureFormat) bool {}
+func (p *PreparedPubKey) VerifySignatureE(dbytes, sign []byte, format ...SignatureFormat) error {}
+func (p *PreparedPubKey) VerifyDigest(digest, sign []byte, format ...SignatureFormat) bool {}
+func (p *PreparedPubKey) VerifyDigestE(digest, sign []byte, format ...SignatureFormat) error {}
+func (p *PreparedPubKey) Close() error {}
+
+func MarshalPKIX(pub PubKey) ([]byte, error) {}
+func ParsePKIX(der []byte) (PubKey, error) {}
+func MarshalPKIXPEM(pub PubKey) ([]byte, error) {}
+func ParsePKIXPEM(data []byte) (PubKey, error) {}
+
+func NewPubKey256(point []byte, params Params) (PubKey256, error) {}
+func NewPubKey512(point []byte, params Params) (PubKey512, error) {}
+
+func ConvertSignature(prov ProvType, sign []byte, from, to SignatureFormat) ([]byte, error) {}
+func (f SignatureFormat) String() string {}
 */
 package gost_r_34_10_2012
 
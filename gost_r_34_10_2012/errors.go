@@ -0,0 +1,28 @@
+package gost_r_34_10_2012
+
+import (
+	"github.com/towleeee/go-cryptopro/internal/csperr"
+)
+
+/*
+ * ERRORS
+ */
+
+// Error of the CSP call with the return code of the C function,
+// the failing CSP function and the value of GetLastError().
+type Error = csperr.Error
+
+var (
+	// Container does not exist (NTE_BAD_KEYSET).
+	ErrBadKeyset = csperr.ErrBadKeyset
+	// Password of the container is wrong.
+	ErrWrongPassword = csperr.ErrWrongPassword
+	// Container with the same name already exists.
+	ErrContainerExists = csperr.ErrContainerExists
+	// Signature is incorrect (NTE_BAD_SIGNATURE).
+	ErrBadSignature = csperr.ErrBadSignature
+	// Key or key blob is incorrect.
+	ErrBadKey = csperr.ErrBadKey
+	// Build without cgo and without the tag purego.
+	ErrUnavailable = csperr.ErrUnavailable
+)
@@ -0,0 +1,111 @@
+// go test -v -bench=. -benchtime=100x
+package gost_r_34_10_2012
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/towleeee/go-cryptopro/csplog"
+)
+
+func TestErrors(t *testing.T) {
+	_, err := NewPrivKey(NewConfig(K256, TEST_SUBJECT, "wrong password"))
+	if !errors.Is(err, ErrWrongPassword) {
+		t.Errorf("test failed: wrong password: %v", err)
+		return
+	}
+
+	_, err = NewPrivKey(NewConfig(K256, "subject not found", TEST_PASSWORD))
+	if !errors.Is(err, ErrBadKeyset) {
+		t.Errorf("test failed: container not found: %v", err)
+		return
+	}
+
+	var cspErr *Error
+	if !errors.As(err, &cspErr) || cspErr.Code != 1 {
+		t.Errorf("test failed: error code: %v", err)
+		return
+	}
+
+	err = GenPrivKey(NewConfig(K256, TEST_SUBJECT, TEST_PASSWORD))
+	if !errors.Is(err, ErrContainerExists) {
+		t.Errorf("test failed: container exists: %v", err)
+		return
+	}
+
+	sign, err := PRIVATE_KEY.Sign(TEST_MESSAGE_1, AT_SIGNATURE)
+	if err != nil {
+		t.Errorf("test failed: sign: %v", err)
+		return
+	}
+
+	err = PUBLIC_KEY.VerifySignatureE(TEST_MESSAGE_1, sign)
+	if err != nil {
+		t.Errorf("test failed: verify signature: %v", err)
+		return
+	}
+
+	err = PUBLIC_KEY.VerifySignatureE(TEST_MESSAGE_2, sign)
+	if !errors.Is(err, ErrBadSignature) {
+		t.Errorf("test failed: bad signature: %v", err)
+		return
+	}
+
+	pub, err := PRIVATE_KEY.PubKeyE(AT_SIGNATURE)
+	if err != nil || !pub.Equals(PUBLIC_KEY) {
+		t.Errorf("test failed: pub key: %v", err)
+		return
+	}
+
+	// Type of the key blob (PUBLICKEYBLOB) is broken.
+	broken := append([]byte{}, PUBLIC_KEY.Bytes()...)
+	broken[1] = 0
+	_, err = LoadPubKey(broken)
+	if !errors.As(err, &cspErr) {
+		t.Errorf("test failed: broken pub key: %v", err)
+		return
+	}
+}
+
+func TestLogger(t *testing.T) {
+	var (
+		mtx    sync.Mutex
+		levels = map[csplog.Level]int{}
+		funcs  []interface{}
+	)
+	csplog.SetLogger(csplog.LoggerFunc(func(level csplog.Level, msg string, attrs ...csplog.Attr) {
+		mtx.Lock()
+		defer mtx.Unlock()
+		levels[level]++
+		for _, v := range attrs {
+			if v.Key == "func" {
+				funcs = append(funcs, v.Value)
+			}
+		}
+	}))
+	Debug = true
+	defer func() {
+		csplog.SetLogger(nil)
+		Debug = false
+	}()
+
+	_, err := NewPrivKey(NewConfig(K256, TEST_SUBJECT, "wrong password"))
+	if err == nil {
+		t.Errorf("test failed: wrong password")
+		return
+	}
+	_ = PRIVATE_KEY.PubKey(AT_SIGNATURE)
+
+	mtx.Lock()
+	defer mtx.Unlock()
+	// The wrong password is expected by the callers: LevelDebug.
+	if levels[csplog.LevelError] != 0 || len(funcs) != 1 || funcs[0] == "" {
+		t.Errorf("test failed: csp errors %v %v", levels, funcs)
+		return
+	}
+	if levels[csplog.LevelDebug] == 0 {
+		t.Errorf("test failed: debug messages")
+		return
+	}
+}
@@ -1,13 +1,204 @@
+//go:build cgo && !purego
+// +build cgo,!purego
+
 #include "gost.h"
 
-extern int CreateContainer(BYTE prov, BYTE *container, BYTE *password) {
-	HCRYPTPROV hProv;
+static int changePin(HCRYPTPROV hProv, BYTE *password) {
+	CRYPT_PIN_PARAM param;
+
+	param.type = CRYPT_PIN_PASSWD;
+	param.dest.passwd = (char*)password;
+
+	return CryptSetProvParam(hProv, PP_CHANGE_PIN, (BYTE*)&param, 0);
+}
+
+static int openContainer(BYTE prov, HCRYPTPROV *hProv, BYTE *container, BYTE *password) {
+	if (!CryptAcquireContext(hProv, container, NULL, prov, CRYPT_SILENT)) {
+		PRINT_ERROR("openContainer: CryptAcquireContext");
+		if (CspErrorCode() == NTE_BAD_KEYSET) {
+			return 1;
+		}
+		return -1;
+	}
+
+	if (!CryptSetProvParam(*hProv, PP_SIGNATURE_PIN, password, 0)) {
+		PRINT_ERROR("openContainer: CryptSetProvParam");
+		CryptReleaseContext(*hProv, 0);
+		return 2;
+	}
+
+	return 0;
+}
+
+static int genKey(HCRYPTPROV hProv, DWORD spec, DWORD flags, BYTE *paramset, BYTE *hashset) {
 	HCRYPTKEY hKey;
 
-	if(!CryptAcquireContext(&hProv, container, NULL, prov, CRYPT_SILENT | CRYPT_NEWKEYSET)) {
+	if (paramset == NULL) {
+		if (!CryptGenKey(hProv, spec, flags, &hKey)) {
+			PRINT_ERROR("genKey: CryptGenKey");
+			return 0;
+		}
+		CryptDestroyKey(hKey);
+		return 1;
+	}
+
+	if (!CryptGenKey(hProv, spec, flags | CRYPT_PREGEN, &hKey)) {
+		PRINT_ERROR("genKey: CryptGenKey (CRYPT_PREGEN)");
+		return 0;
+	}
+
+	if (!CryptSetKeyParam(hKey, KP_DHOID, paramset, 0)) {
+		PRINT_ERROR("genKey: CryptSetKeyParam (KP_DHOID)");
+		CryptDestroyKey(hKey);
+		return 0;
+	}
+
+	if (hashset != NULL && !CryptSetKeyParam(hKey, KP_HASHOID, hashset, 0)) {
+		PRINT_ERROR("genKey: CryptSetKeyParam (KP_HASHOID)");
+		CryptDestroyKey(hKey);
+		return 0;
+	}
+
+	if (!CryptSetKeyParam(hKey, KP_X, NULL, 0)) {
+		PRINT_ERROR("genKey: CryptSetKeyParam (KP_X)");
+		CryptDestroyKey(hKey);
+		return 0;
+	}
+
+	CryptDestroyKey(hKey);
+	return 1;
+}
+
+static BYTE *signData(HCRYPTPROV hProv, BYTE prov, BYTE *data, DWORD size, DWORD digest, DWORD *dwSigLen, DWORD spec) {
+	HCRYPTHASH hHash;
+	DWORD hashtype;
+	DWORD hashsize;
+	BYTE *output;
+
+	switch (prov) {
+		case PROV_GOST_2012_256:
+			hashtype = CALG_GR3411_2012_256;
+			hashsize = 32;
+		break;
+		case PROV_GOST_2012_512:
+			hashtype = CALG_GR3411_2012_512;
+			hashsize = 64;
+		break;
+		default:
+			return NULL;
+	}
+
+	if (digest && size != hashsize) {
+		return NULL;
+	}
+
+	if (!CryptCreateHash(hProv, hashtype, 0, 0, &hHash)) {
+		PRINT_ERROR("signData: CryptCreateHash");
+		return NULL;
+	}
+
+	if (digest) {
+		if (!CryptSetHashParam(hHash, HP_HASHVAL, data, 0)) {
+			PRINT_ERROR("signData: CryptSetHashParam");
+			CryptDestroyHash(hHash);
+			return NULL;
+		}
+	} else {
+		if (!CryptHashData(hHash, data, size, 0)) {
+			PRINT_ERROR("signData: CryptHashData");
+			CryptDestroyHash(hHash);
+			return NULL;
+		}
+	}
+
+	if (!CryptSignHash(hHash, spec, NULL, 0, NULL, dwSigLen)) {
+		PRINT_ERROR("signData: CryptSignHash (1)");
+		CryptDestroyHash(hHash);
+		return NULL;
+	}
+
+	output = (BYTE*)malloc(sizeof(BYTE)*(*dwSigLen));
+
+	if (!CryptSignHash(hHash, spec, NULL, 0, output, dwSigLen)) {
+		PRINT_ERROR("signData: CryptSignHash (2)");
+		free(output);
+		CryptDestroyHash(hHash);
+		return NULL;
+	}
+
+	CryptDestroyHash(hHash);
+
+	return output;
+}
+
+static int verifyData(HCRYPTPROV hProv, BYTE prov, HCRYPTKEY hKey, BYTE *sign, DWORD dwSigLen, BYTE *data, DWORD size, DWORD digest) {
+	HCRYPTHASH hHash;
+	DWORD hashtype;
+	DWORD hashsize;
+
+	switch (prov) {
+		case PROV_GOST_2012_256:
+			hashtype = CALG_GR3411_2012_256;
+			hashsize = 32;
+		break;
+		case PROV_GOST_2012_512:
+			hashtype = CALG_GR3411_2012_512;
+			hashsize = 64;
+		break;
+		default:
+			return -1;
+	}
+
+	if (digest && size != hashsize) {
+		return -1;
+	}
+
+	if (!CryptCreateHash(hProv, hashtype, 0, 0, &hHash)) {
+		PRINT_ERROR("verifyData: CryptCreateHash");
+		return -3;
+	}
+
+	if (digest) {
+		if (!CryptSetHashParam(hHash, HP_HASHVAL, data, 0)) {
+			PRINT_ERROR("verifyData: CryptSetHashParam");
+			CryptDestroyHash(hHash);
+			return -4;
+		}
+	} else {
+		if (!CryptHashData(hHash, data, size, 0)) {
+			PRINT_ERROR("verifyData: CryptHashData");
+			CryptDestroyHash(hHash);
+			return -4;
+		}
+	}
+
+	if (!CryptVerifySignature(hHash, sign, dwSigLen, hKey, NULL, 0)) {
+		SetCspError("verifyData: CryptVerifySignature", GetLastError());
+		CryptDestroyHash(hHash);
 		return 1;
 	}
 
+	CryptDestroyHash(hHash);
+
+	return 0;
+}
+
+extern int CreateContainer(BYTE prov, BYTE *container, BYTE *password, DWORD keys, DWORD exportable, BYTE *paramset, BYTE *hashset) {
+	HCRYPTPROV hProv;
+	DWORD flags = exportable ? CRYPT_EXPORTABLE : 0;
+
+	if (keys == 0) {
+		return -4;
+	}
+
+	if(!CryptAcquireContext(&hProv, container, NULL, prov, CRYPT_SILENT | CRYPT_NEWKEYSET)) {
+		PRINT_ERROR("CreateContainer: CryptAcquireContext");
+		if (CspErrorCode() == NTE_EXISTS) {
+			return 1;
+		}
+		return -1;
+	}
+
 	CryptReleaseContext(hProv, 0);
 
 	if(!CryptAcquireContext(&hProv, container, NULL, prov, CRYPT_NEWKEYSET)) {
@@ -21,13 +212,14 @@ extern int CreateContainer(BYTE prov, BYTE *container, BYTE *password) {
         return -2;
     }
 
-	if(!CryptGenKey(hProv, AT_SIGNATURE, CRYPT_EXPORTABLE, &hKey)) {
+	if(((keys & 0x1) && !genKey(hProv, AT_SIGNATURE, flags, paramset, hashset)) ||
+	   ((keys & 0x2) && !genKey(hProv, AT_KEYEXCHANGE, flags, paramset, hashset))) {
 		PRINT_ERROR("CreateContainer: CryptGenKey");
 		CryptReleaseContext(hProv, 0);
-        return -3;
+		CryptAcquireContext(&hProv, container, NULL, prov, CRYPT_SILENT | CRYPT_DELETEKEYSET);
+		return -3;
 	}
 
-	CryptDestroyKey(hKey);
 	CryptReleaseContext(hProv, 0);
 
 	return 0;
@@ -56,16 +248,11 @@ extern int OpenContainer(BYTE prov, HCRYPTPROV *hProv, HCRYPTKEY *hKey, BYTE *co
 
 extern int CheckContainer(BYTE prov, BYTE *container, BYTE *password) {
 	HCRYPTPROV hProv;
-	
-	if (!CryptAcquireContext(&hProv, container, NULL, prov, 0)) {
-		PRINT_ERROR("CheckContainer: CryptAcquireContext");
-		return -1;
-	}
+	int ret;
 
-	if (!CryptSetProvParam(hProv, PP_SIGNATURE_PIN, password, 0)) {
-		PRINT_ERROR("CheckContainer: CryptSetProvParam");
-		CryptReleaseContext(hProv, 0);
-		return -2;
+	ret = openContainer(prov, &hProv, container, password);
+	if (ret != 0) {
+		return ret;
 	}
 
 	CryptReleaseContext(hProv, 0);
@@ -75,19 +262,8 @@ extern int CheckContainer(BYTE prov, BYTE *container, BYTE *password) {
 
 extern BYTE *SignMessage(BYTE prov, BYTE *container, BYTE *password, BYTE *data, DWORD size, DWORD *dwSigLen, DWORD spec) {
 	HCRYPTPROV hProv;
-	HCRYPTHASH hHash;
-	DWORD hashtype;
 	BYTE *output;
 
-	switch (prov) {
-		case PROV_GOST_2012_256:
-			hashtype = CALG_GR3411_2012_256;
-		break;
-		case PROV_GOST_2012_512:
-			hashtype = CALG_GR3411_2012_512;
-		break;
-	}
-
 	if (!CryptAcquireContext(&hProv, container, NULL, prov, 0)) {
 		PRINT_ERROR("SignMessage: CryptAcquireContext");
 		return NULL;
@@ -99,85 +275,58 @@ extern BYTE *SignMessage(BYTE prov, BYTE *container, BYTE *password, BYTE *data,
 		return NULL;
 	}
 
-	if (!CryptCreateHash(hProv, hashtype, 0, 0, &hHash)) {
-		PRINT_ERROR("SignMessage: CryptCreateHash");
-		CryptReleaseContext(hProv, 0);
-		return NULL;
-	}
+	output = signData(hProv, prov, data, size, 0, dwSigLen, spec);
 
-    if (!CryptHashData(hHash, data, size, 0)) {
-		PRINT_ERROR("SignMessage: CryptHashData");
-		CryptDestroyHash(hHash);
-		CryptReleaseContext(hProv, 0);
-		return NULL;
-	}
+	CryptReleaseContext(hProv, 0);
 
-	if(!CryptSignHash(hHash, spec, NULL, 0, NULL, dwSigLen)) {
-		PRINT_ERROR("SignMessage: CryptSignHash (1)");
-		CryptDestroyHash(hHash);
-        CryptReleaseContext(hProv, 0);
+	return output;
+}
+
+extern BYTE *SignHash(BYTE prov, BYTE *container, BYTE *password, BYTE *hash, DWORD size, DWORD *dwSigLen, DWORD spec) {
+	HCRYPTPROV hProv;
+	BYTE *output;
+
+	if (!CryptAcquireContext(&hProv, container, NULL, prov, 0)) {
+		PRINT_ERROR("SignHash: CryptAcquireContext");
 		return NULL;
 	}
 
-	output = (BYTE*)malloc(sizeof(BYTE)*(*dwSigLen));
-
-	if(!CryptSignHash(hHash, spec, NULL, 0, output, dwSigLen)) {
-		PRINT_ERROR("SignMessage: CryptSignHash (2)");
-		free(output);
-		CryptDestroyHash(hHash);
-        CryptReleaseContext(hProv, 0);
+	if (!CryptSetProvParam(hProv, PP_SIGNATURE_PIN, password, 0)) {
+		PRINT_ERROR("SignHash: CryptSetProvParam");
+		CryptReleaseContext(hProv, 0);
 		return NULL;
 	}
 
-	CryptDestroyHash(hHash);
-    CryptReleaseContext(hProv, 0);
+	output = signData(hProv, prov, hash, size, 1, dwSigLen, spec);
+
+	CryptReleaseContext(hProv, 0);
 
 	return output;
 }
 
-extern int VerifySign(BYTE prov, HCRYPTKEY *hKey, BYTE *sign, DWORD dwSigLen, BYTE *data, DWORD size) {
-	HCRYPTPROV hProv;
-	HCRYPTHASH hHash;
-	DWORD hashtype;
+extern int OpenSession(BYTE prov, HCRYPTPROV *hProv, HCRYPTKEY *hKey, BYTE *container, BYTE *password, DWORD spec) {
+	int ret;
 
-	switch (prov) {
-		case PROV_GOST_2012_256:
-			hashtype = CALG_GR3411_2012_256;
-		break;
-		case PROV_GOST_2012_512:
-			hashtype = CALG_GR3411_2012_512;
-		break;
+	ret = openContainer(prov, hProv, container, password);
+	if (ret != 0) {
+		return ret;
 	}
 
-	if (!CryptAcquireContext(&hProv, NULL, NULL, prov, 0)) {
-		PRINT_ERROR("VerifySign: CryptAcquireContext");
+	if (!CryptGetUserKey(*hProv, spec, hKey)) {
+		PRINT_ERROR("OpenSession: CryptGetUserKey");
+		CryptReleaseContext(*hProv, 0);
 		return -2;
 	}
 
-	if (!CryptCreateHash(hProv, hashtype, 0, 0, &hHash)) {
-		PRINT_ERROR("VerifySign: CryptCreateHash");
-		CryptReleaseContext(hProv, 0);
-		return -3;
-	}
-
-    if (!CryptHashData(hHash, data, size, 0)) {
-		PRINT_ERROR("VerifySign: CryptHashData");
-		CryptDestroyHash(hHash);
-		CryptReleaseContext(hProv, 0);
-		return -4;
-	}
-
-	if(!CryptVerifySignature(hHash, sign, dwSigLen, *hKey, NULL, 0)) {
-		// PRINT_ERROR("VerifySign: CryptVerifySignature");
-		CryptDestroyHash(hHash);
-		CryptReleaseContext(hProv, 0);
-        return 1;
-    }
+	return 0;
+}
 
-	CryptDestroyHash(hHash);
-	CryptReleaseContext(hProv, 0);
+extern BYTE *SessionSign(HCRYPTPROV *hProv, BYTE prov, BYTE *data, DWORD size, DWORD digest, DWORD *dwSigLen, DWORD spec) {
+	return signData(*hProv, prov, data, size, digest, dwSigLen, spec);
+}
 
-	return 0;
+extern int VerifyPrepared(BYTE prov, HCRYPTPROV *hProv, HCRYPTKEY *hKey, BYTE *sign, DWORD dwSigLen, BYTE *data, DWORD size, DWORD digest) {
+	return verifyData(*hProv, prov, *hKey, sign, dwSigLen, data, size, digest);
 }
 
 extern int ImportPublicKey(BYTE prov, HCRYPTPROV *hProv, HCRYPTKEY *hKey, BYTE *pkbytes, DWORD keyBlobLen) {
@@ -213,3 +362,176 @@ extern BYTE *BytesPublicKey(HCRYPTKEY *hKey, DWORD *size) {
 
 	return pkbytes;
 }
+
+extern BYTE *EnumContainers(BYTE prov, DWORD *size) {
+	HCRYPTPROV hProv;
+	DWORD maxlen = 0;
+	DWORD namelen;
+	DWORD flags = CRYPT_FIRST | CRYPT_FQCN;
+	DWORD caplen = 0;
+	BYTE *name;
+	BYTE *output;
+	BYTE *tmp;
+
+	*size = 0;
+
+	if (!CryptAcquireContext(&hProv, NULL, NULL, prov, CRYPT_VERIFYCONTEXT)) {
+		PRINT_ERROR("EnumContainers: CryptAcquireContext");
+		return NULL;
+	}
+
+	if (!CryptGetProvParam(hProv, PP_ENUMCONTAINERS, NULL, &maxlen, flags)) {
+		if (GetLastError() == ERROR_NO_MORE_ITEMS) {
+			CryptReleaseContext(hProv, 0);
+			return (BYTE*)malloc(sizeof(BYTE));
+		}
+		PRINT_ERROR("EnumContainers: CryptGetProvParam (1)");
+		CryptReleaseContext(hProv, 0);
+		return NULL;
+	}
+
+	name = (BYTE*)malloc(sizeof(BYTE)*(maxlen+1));
+	output = (BYTE*)malloc(sizeof(BYTE));
+
+	for (;;) {
+		namelen = maxlen;
+		if (!CryptGetProvParam(hProv, PP_ENUMCONTAINERS, name, &namelen, flags)) {
+			if (GetLastError() == ERROR_NO_MORE_ITEMS) {
+				break;
+			}
+			PRINT_ERROR("EnumContainers: CryptGetProvParam (2)");
+			free(name);
+			free(output);
+			CryptReleaseContext(hProv, 0);
+			return NULL;
+		}
+		flags = CRYPT_NEXT | CRYPT_FQCN;
+
+		name[namelen] = 0;
+		namelen = strlen((char*)name) + 1;
+
+		if (*size + namelen > caplen) {
+			caplen = 2*(*size + namelen);
+			tmp = (BYTE*)realloc(output, sizeof(BYTE)*caplen);
+			if (tmp == NULL) {
+				free(name);
+				free(output);
+				CryptReleaseContext(hProv, 0);
+				return NULL;
+			}
+			output = tmp;
+		}
+
+		memcpy(output + *size, name, namelen);
+		*size += namelen;
+	}
+
+	free(name);
+	CryptReleaseContext(hProv, 0);
+
+	return output;
+}
+
+extern int ContainerKeys(BYTE prov, BYTE *container) {
+	HCRYPTPROV hProv;
+	HCRYPTKEY hKey;
+	int keys = 0;
+
+	if (!CryptAcquireContext(&hProv, container, NULL, prov, CRYPT_SILENT)) {
+		PRINT_ERROR("ContainerKeys: CryptAcquireContext");
+		return -1;
+	}
+
+	if (CryptGetUserKey(hProv, AT_SIGNATURE, &hKey)) {
+		keys |= 0x1;
+		CryptDestroyKey(hKey);
+	}
+
+	if (CryptGetUserKey(hProv, AT_KEYEXCHANGE, &hKey)) {
+		keys |= 0x2;
+		CryptDestroyKey(hKey);
+	}
+
+	CryptReleaseContext(hProv, 0);
+
+	return keys;
+}
+
+extern int DeleteContainer(BYTE prov, BYTE *container, BYTE *password) {
+	HCRYPTPROV hProv;
+	int ret;
+
+	ret = openContainer(prov, &hProv, container, password);
+	if (ret != 0) {
+		return ret;
+	}
+
+	CryptReleaseContext(hProv, 0);
+
+	if (!CryptAcquireContext(&hProv, container, NULL, prov, CRYPT_SILENT | CRYPT_DELETEKEYSET)) {
+		PRINT_ERROR("DeleteContainer: CryptAcquireContext");
+		return -2;
+	}
+
+	return 0;
+}
+
+extern int CopyContainer(BYTE prov, BYTE *src, BYTE *srcpass, BYTE *dst, BYTE *dstpass) {
+	HCRYPTPROV hSrc;
+	HCRYPTPROV hDst;
+	int ret;
+
+	ret = openContainer(prov, &hSrc, src, srcpass);
+	if (ret != 0) {
+		return ret;
+	}
+
+	if (!CryptAcquireContext(&hDst, dst, NULL, prov, CRYPT_SILENT | CRYPT_NEWKEYSET)) {
+		PRINT_ERROR("CopyContainer: CryptAcquireContext");
+		CryptReleaseContext(hSrc, 0);
+		if (CspErrorCode() == NTE_EXISTS) {
+			return 3;
+		}
+		return -2;
+	}
+
+	if (!CryptSetProvParam(hDst, PP_HCRYPTPROV, (BYTE*)&hSrc, 0)) {
+		PRINT_ERROR("CopyContainer: CryptSetProvParam (PP_HCRYPTPROV)");
+		ret = -3;
+	} else if (!CryptSetProvParam(hDst, PP_SIGNATURE_PIN, srcpass, 0)) {
+		PRINT_ERROR("CopyContainer: CryptSetProvParam (PP_SIGNATURE_PIN)");
+		ret = -4;
+	} else if (!changePin(hDst, dstpass)) {
+		PRINT_ERROR("CopyContainer: CryptSetProvParam (PP_CHANGE_PIN)");
+		ret = -5;
+	}
+
+	CryptReleaseContext(hDst, 0);
+	CryptReleaseContext(hSrc, 0);
+
+	if (ret < 0) {
+		CryptAcquireContext(&hDst, dst, NULL, prov, CRYPT_SILENT | CRYPT_DELETEKEYSET);
+	}
+
+	return ret;
+}
+
+extern int ChangePassword(BYTE prov, BYTE *container, BYTE *password, BYTE *newpassword) {
+	HCRYPTPROV hProv;
+	int ret;
+
+	ret = openContainer(prov, &hProv, container, password);
+	if (ret != 0) {
+		return ret;
+	}
+
+	if (!changePin(hProv, newpassword)) {
+		PRINT_ERROR("ChangePassword: CryptSetProvParam");
+		CryptReleaseContext(hProv, 0);
+		return -2;
+	}
+
+	CryptReleaseContext(hProv, 0);
+
+	return 0;
+}
@@ -2,18 +2,15 @@
 // https://docs.cntd.ru/document/1200095034
 package gost_r_34_10_2012
 
-/*
-#include "gost.h"
-*/
-import "C"
 import (
 	"bytes"
 	"encoding/hex"
 	"fmt"
 	"strings"
-	"unsafe"
 
 	ghash "github.com/towleeee/go-cryptopro/gost_r_34_11_2012"
+	"github.com/towleeee/go-cryptopro/internal/backend"
+	"github.com/towleeee/go-cryptopro/internal/keyblob"
 )
 
 func init() {
@@ -43,8 +40,8 @@ const (
 type KeySpec int
 
 const (
-	AT_KEYEXCHANGE KeySpec = C.AT_KEYEXCHANGE
-	AT_SIGNATURE   KeySpec = C.AT_SIGNATURE
+	AT_KEYEXCHANGE KeySpec = 1
+	AT_SIGNATURE   KeySpec = 2
 )
 
 const (
@@ -80,6 +77,25 @@ func (k ProvType) String() string {
 	}
 }
 
+// Hash function GOST R 34.11-2012 of the provider:
+// H256 for K256, H512 for K512.
+func (k ProvType) Hash() ghash.ProvType {
+	return ghash.ProvType(k)
+}
+
+// Size of the signature in bytes:
+// SignatureSize256 for K256, SignatureSize512 for K512.
+func (k ProvType) SignatureSize() int {
+	switch k {
+	case K256:
+		return SignatureSize256
+	case K512:
+		return SignatureSize512
+	default:
+		return -1
+	}
+}
+
 /*
  * PRIVATE KEY
  */
@@ -94,48 +110,46 @@ type PrivContainer struct {
 }
 
 // Creation of a container with a binding to a password
-// and generation of a private key.
+// and generation of the key pairs of the config
+// (the KeySpec of the config by default, see WithGenKeys).
 func GenPrivKey(cfg *Config) error {
+	if cfg.err != nil {
+		return cfg.err
+	}
 	log(fmt.Sprintf("GenPrivKey:{cont: %s}", cfg.container))
-	ret := C.CreateContainer(
-		C.uchar(cfg.prov),
-		hexDecode(cfg.container),
-		hexDecode(cfg.password),
-	)
-	if ret < 0 {
-		panic(fmt.Errorf("error code: %d", ret))
+	keys, err := cfg.genKeyFlags()
+	if err != nil {
+		return err
 	}
-	if ret > 0 {
-		return fmt.Errorf("key already exists")
+	paramSet, hashSet, err := cfg.genParams()
+	if err != nil {
+		return err
 	}
-	return nil
+	return provider.CreateContainer(
+		"gen priv key",
+		byte(cfg.prov),
+		cfg.fqcn(),
+		decodeName(cfg.password),
+		keys,
+		!cfg.nonExportable,
+		paramSet,
+		hashSet,
+	)
 }
 
 func (key PrivContainer) GenPrivKey(cfg *Config) error {
-	ret := C.CreateContainer(
-		C.uchar(cfg.prov),
-		hexDecode(cfg.container),
-		hexDecode(cfg.password),
-	)
-	if ret < 0 {
-		panic(fmt.Errorf("error code: %d", ret))
-	}
-	if ret > 0 {
-		return fmt.Errorf("key already exists")
-	}
-	return nil
+	return GenPrivKey(cfg)
 }
 
 // Getting the private key interface
 // from the container name and password.
 func NewPrivKey(cfg *Config) (PrivKey, error) {
-	ret := C.CheckContainer(
-		C.uchar(cfg.prov),
-		hexDecode(cfg.container),
-		hexDecode(cfg.password),
-	)
-	if ret < 0 {
-		return nil, fmt.Errorf("error: private key is nil")
+	if cfg.err != nil {
+		return nil, cfg.err
+	}
+	err := checkContainer(cfg.prov, decodeName(cfg.container), decodeName(cfg.password))
+	if err != nil {
+		return nil, err
 	}
 
 	privraw := bytes.Join(
@@ -149,14 +163,15 @@ func NewPrivKey(cfg *Config) (PrivKey, error) {
 
 	switch cfg.prov {
 	case K256:
-		// return PrivKey256(privraw), nil
 		return PrivContainer{
 			PrivKey: PrivKey256(privraw),
 			KeySpec: cfg.keySpec,
 		}, nil
-
 	case K512:
-		return PrivKey512(privraw), nil
+		return PrivContainer{
+			PrivKey: PrivKey512(privraw),
+			KeySpec: cfg.keySpec,
+		}, nil
 	default:
 		return nil, fmt.Errorf("error: key size not in (256, 512)")
 	}
@@ -174,30 +189,27 @@ func LoadPrivKey(pbytes []byte) (PrivKey, error) {
 		privlen = len(pbytes)
 	)
 
-	switch privlen {
-	case PrivKeySize256:
-		// case PrivKeySize512:
-		// pass
-	default:
+	if privlen == 0 {
 		return nil, fmt.Errorf("error: length of private key")
 	}
 
 	prov = ProvType(pbytes[0])
 	switch prov {
-	case K256, K512:
-		// pass
+	case K256:
+		if privlen != PrivKeySize256 {
+			return nil, fmt.Errorf("error: length of private key")
+		}
+	case K512:
+		if privlen != PrivKeySize512 {
+			return nil, fmt.Errorf("error: length of private key")
+		}
 	default:
 		return nil, fmt.Errorf("error: read prov type")
 	}
 
-	ret := C.CheckContainer(
-		C.uchar(prov),
-		toCstring(string(pbytes[1:ContainerLen+1])),
-		toCstring(string(pbytes[ContainerLen+1:])),
-	)
-
-	if ret < 0 {
-		return nil, fmt.Errorf("error: private key is nil")
+	err := checkContainer(prov, PrivKey256(pbytes).container(), PrivKey256(pbytes).password())
+	if err != nil {
+		return nil, err
 	}
 
 	switch prov {
@@ -210,6 +222,12 @@ func LoadPrivKey(pbytes []byte) (PrivKey, error) {
 	}
 }
 
+// Opening of the container by the password:
+// ErrBadKeyset if it does not exist, ErrWrongPassword if the password is wrong.
+func checkContainer(prov ProvType, container, password string) error {
+	return provider.CheckContainer("check container", byte(prov), container, password)
+}
+
 // Retrieving bytes (provider_type || container_name || container_password)
 // from the private key interface.
 func (key PrivKey512) Bytes() []byte {
@@ -230,8 +248,8 @@ func (key PrivKey512) String() string {
 func (key PrivKey256) String() string {
 	return fmt.Sprintf("Priv(%s){%s %s}",
 		key.Type(),
-		toGOstring(key.container()),
-		toGOstring(key.password()),
+		key.container(),
+		key.password(),
 	)
 }
 func (key PrivContainer) String() string {
@@ -239,40 +257,55 @@ func (key PrivContainer) String() string {
 }
 
 // Signing information using the private key interface.
-func (key PrivKey512) Sign(dbytes []byte, spec KeySpec) ([]byte, error) {
-	return PrivKey256(key).Sign(dbytes, spec)
+// The signature is in the format (FormatCryptoAPI if omitted).
+func (key PrivKey512) Sign(dbytes []byte, spec KeySpec, format ...SignatureFormat) ([]byte, error) {
+	return PrivKey256(key).Sign(dbytes, spec, format...)
 }
-func (key PrivContainer) Sign(dbytes []byte, spec KeySpec) ([]byte, error) {
-	return key.PrivKey.Sign(dbytes, spec)
+func (key PrivContainer) Sign(dbytes []byte, spec KeySpec, format ...SignatureFormat) ([]byte, error) {
+	return key.PrivKey.Sign(dbytes, spec, format...)
+}
+func (key PrivKey256) Sign(dbytes []byte, spec KeySpec, format ...SignatureFormat) ([]byte, error) {
+	return key.sign("sign", dbytes, false, spec, format)
 }
-func (key PrivKey256) Sign(dbytes []byte, spec KeySpec) ([]byte, error) {
-	var (
-		datlen = len(dbytes)
-		reslen C.uint
-		prov   = key.prov()
-	)
 
-	result := C.SignMessage(
-		C.uchar(prov),
+// Signing a ready hash value GOST R 34.11-2012
+// (32 bytes for K256, 64 bytes for K512)
+// without repeated hashing.
+func (key PrivKey512) SignDigest(digest []byte, spec KeySpec, format ...SignatureFormat) ([]byte, error) {
+	return PrivKey256(key).SignDigest(digest, spec, format...)
+}
+func (key PrivContainer) SignDigest(digest []byte, spec KeySpec, format ...SignatureFormat) ([]byte, error) {
+	return key.PrivKey.SignDigest(digest, spec, format...)
+}
+func (key PrivKey256) SignDigest(digest []byte, spec KeySpec, format ...SignatureFormat) ([]byte, error) {
+	if len(digest) != ghash.ProvType(key.prov()).Size() {
+		return nil, fmt.Errorf("error: length of digest")
+	}
+	return key.sign("sign", digest, true, spec, format)
+}
+
+// Signature of the data or of the hash value by the key pair of the container.
+func (key PrivKey256) sign(op string, data []byte, digest bool, spec KeySpec, format []SignatureFormat) ([]byte, error) {
+	if _, err := signatureFormat(format); err != nil {
+		return nil, err
+	}
+	sign, err := provider.Sign(
+		op,
+		byte(key.prov()),
 		key.container(),
 		key.password(),
-		toCbytes(dbytes),
-		C.uint(datlen),
-		&reslen,
-		C.uint(spec),
+		uint32(spec),
+		data,
+		digest,
 	)
-	if result == nil {
-		return nil, fmt.Errorf("error: sign is nil")
+	if err != nil {
+		return nil, err
 	}
-
-	resptr := unsafe.Pointer(result)
-	defer C.free(resptr)
-
-	return C.GoBytes(resptr, C.int(reslen)), nil
+	return encodeSignature(key.prov(), sign, format)
 }
 
 // Getting the public key interface
-// from the private key interface.
+// from the private key interface, nil on error (see PubKeyE).
 func (key PrivKey512) PubKey(spec KeySpec) PubKey {
 	return PrivKey256(key).PubKey(spec)
 }
@@ -280,54 +313,44 @@ func (key PrivContainer) PubKey(spec KeySpec) PubKey {
 	return key.PrivKey.PubKey(key.KeySpec)
 }
 func (key PrivKey256) PubKey(spec KeySpec) PubKey {
-	var (
-		hProv  C.HCRYPTPROV
-		hKey   C.HCRYPTKEY
-		publen C.uint
-		pbytes *C.uchar
-		prov   = key.prov()
-		// keyType change key type AT_SIGNATURE / AT_KEYEXCHANGE
+	pubkey, err := key.PubKeyE(spec)
+	if err != nil {
+		log(err.Error())
+		return nil
+	}
+	return pubkey
+}
 
-	)
+// Getting the public key interface
+// from the private key interface with the error of CSP.
+func (key PrivKey512) PubKeyE(spec KeySpec) (PubKey, error) {
+	return PrivKey256(key).PubKeyE(spec)
+}
+func (key PrivContainer) PubKeyE(spec KeySpec) (PubKey, error) {
+	return key.PrivKey.PubKeyE(key.KeySpec)
+}
+func (key PrivKey256) PubKeyE(spec KeySpec) (PubKey, error) {
 	log(fmt.Sprintf("key: %+v", key))
 
-	ret := C.OpenContainer(
-		C.uchar(prov),
-		&hProv,
-		&hKey,
+	pubraw, err := provider.PubKey(
+		"pub key",
+		byte(key.prov()),
 		key.container(),
 		key.password(),
-		C.uint(spec),
+		uint32(spec),
 	)
-	if ret < 0 {
-		panic(fmt.Errorf("error code: %d", ret))
-	}
-	defer func() {
-		C.CryptDestroyKey(hKey)
-		C.CryptReleaseContext(hProv, C.uint(0))
-	}()
-
-	pbytes = C.BytesPublicKey(&hKey, &publen)
-	if pbytes == nil {
-		panic(fmt.Errorf("error: public key is nil"))
+	if err != nil {
+		return nil, err
 	}
-	defer C.free(unsafe.Pointer(pbytes))
-
-	pubraw := C.GoBytes(unsafe.Pointer(pbytes), C.int(publen))
 	pubraw = bytes.Join(
 		[][]byte{
-			[]byte{byte(prov)},
+			[]byte{byte(key.prov())},
 			pubraw,
 		},
 		[]byte{},
 	)
 
-	pubkey, err := LoadPubKey(pubraw)
-	if err != nil {
-		panic(err)
-	}
-
-	return pubkey
+	return LoadPubKey(pubraw)
 }
 
 // Comparison of private keys by bytes
@@ -365,32 +388,34 @@ func (key PrivKey256) containerLen() int {
 	return len(key)
 }
 
-// Container name in bytes.
-func (key PrivKey256) container() *C.uchar {
-	return hexDecode(string(key[1 : ContainerLen+1]))
+// Container name as passed to the provider.
+func (key PrivKey256) container() string {
+	return decodeName(string(key[1 : ContainerLen+1]))
 }
 
-func hexDecode(data string) *C.uchar {
+// Container name or password as passed to CSP:
+// the hex string itself for NewConfig,
+// the decoded part before ":" for SimpleConfig.
+func decodeName(data string) string {
 	dst := make([]byte, hex.DecodedLen(len(data)))
 	_, err := hex.Decode(dst, []byte(data))
 	if err != nil {
-		fmt.Println(err)
-		return toCstring(data)
+		log(err.Error())
+		return data
 	}
 	log(fmt.Sprintf("{decode: %+v, s: %s}", dst, string(dst)))
 	s := strings.Split(string(dst), ":")
 	log(fmt.Sprintf("{split: %+v, len: %d}", s, len(s)))
 	if len(s) < 2 {
-		return toCstring(data)
+		return data
 	}
 	log(fmt.Sprintf("{split_return: %+v, len: %d}", s[0], len(s)))
-	return toCstring(s[0])
+	return s[0]
 }
 
-// Container password in bytes.
-func (key PrivKey256) password() *C.uchar {
-	// return toCstring(string(key[ContainerLen+1:]))
-	return hexDecode(string(key[ContainerLen+1:]))
+// Container password as passed to the provider.
+func (key PrivKey256) password() string {
+	return decodeName(string(key[ContainerLen+1:]))
 }
 
 /*
@@ -403,18 +428,14 @@ type PubKey256 []byte
 
 // Checking the correctness of the public key bytes.
 // Translating bytes into PubKey interface.
+// The length depends on the parameters of the key,
+// PubKeySize256 and PubKeySize512 are the sizes of the provider default.
 func LoadPubKey(pbytes []byte) (PubKey, error) {
 	var (
-		hProv  C.HCRYPTPROV
-		hKey   C.HCRYPTKEY
-		prov   ProvType
-		publen = len(pbytes)
+		prov ProvType
 	)
 
-	switch publen {
-	case PubKeySize256, PubKeySize512:
-		// pass
-	default:
+	if len(pbytes) < 2 {
 		return nil, fmt.Errorf("error: length of public key")
 	}
 
@@ -426,14 +447,9 @@ func LoadPubKey(pbytes []byte) (PubKey, error) {
 		return nil, fmt.Errorf("error: read prov type")
 	}
 
-	ret := C.ImportPublicKey(C.uchar(prov), &hProv, &hKey, toCbytes(pbytes[1:]), C.uint(publen-1))
-	if ret < 0 {
-		return nil, fmt.Errorf("error: read public key")
+	if err := provider.CheckPubKey("load pub key", byte(prov), pbytes[1:]); err != nil {
+		return nil, err
 	}
-	defer func() {
-		C.CryptDestroyKey(hKey)
-		C.CryptReleaseContext(hProv, C.uint(0))
-	}()
 
 	switch prov {
 	case K256:
@@ -471,45 +487,90 @@ func (key PubKey256) String() string {
 }
 
 // Signature confirmation using the original data.
-func (key PubKey512) VerifySignature(dbytes, sign []byte) bool {
-	return PubKey256(key).VerifySignature(dbytes, sign)
+// The signature is in the format (FormatCryptoAPI if omitted).
+func (key PubKey512) VerifySignature(dbytes, sign []byte, format ...SignatureFormat) bool {
+	return PubKey256(key).VerifySignature(dbytes, sign, format...)
+}
+func (key PubKey256) VerifySignature(dbytes, sign []byte, format ...SignatureFormat) bool {
+	return key.VerifySignatureE(dbytes, sign, format...) == nil
 }
-func (key PubKey256) VerifySignature(dbytes, sign []byte) bool {
-	var (
-		hProv C.HCRYPTPROV
-		hKey  C.HCRYPTKEY
-		prov  = key.prov()
-	)
 
-	ret := C.ImportPublicKey(C.uchar(prov), &hProv, &hKey, key.bytes(), key.len())
-	if ret < 0 {
-		panic(fmt.Errorf("error: code: %d", ret))
-	}
-	defer func() {
-		C.CryptDestroyKey(hKey)
-		C.CryptReleaseContext(hProv, C.uint(0))
-	}()
-
-	ret = C.VerifySign(
-		C.uchar(prov),
-		&hKey, toCbytes(sign),
-		C.uint(len(sign)),
-		toCbytes(dbytes),
-		C.uint(len(dbytes)),
-	)
-	if ret < 0 {
-		panic(fmt.Errorf("error code: %d", ret))
+// Signature confirmation using the original data,
+// ErrBadSignature if the signature is incorrect.
+func (key PubKey512) VerifySignatureE(dbytes, sign []byte, format ...SignatureFormat) error {
+	return PubKey256(key).VerifySignatureE(dbytes, sign, format...)
+}
+func (key PubKey256) VerifySignatureE(dbytes, sign []byte, format ...SignatureFormat) error {
+	return key.verify("verify signature", dbytes, sign, false, format)
+}
+
+// Signature confirmation using a ready hash value
+// GOST R 34.11-2012 (32 bytes for K256, 64 bytes for K512).
+func (key PubKey512) VerifyDigest(digest, sign []byte, format ...SignatureFormat) bool {
+	return PubKey256(key).VerifyDigest(digest, sign, format...)
+}
+func (key PubKey256) VerifyDigest(digest, sign []byte, format ...SignatureFormat) bool {
+	return key.VerifyDigestE(digest, sign, format...) == nil
+}
+
+// Signature confirmation using a ready hash value,
+// ErrBadSignature if the signature is incorrect.
+func (key PubKey512) VerifyDigestE(digest, sign []byte, format ...SignatureFormat) error {
+	return PubKey256(key).VerifyDigestE(digest, sign, format...)
+}
+func (key PubKey256) VerifyDigestE(digest, sign []byte, format ...SignatureFormat) error {
+	if len(digest) != ghash.ProvType(key.prov()).Size() {
+		return fmt.Errorf("error: length of digest")
+	}
+	return key.verify("verify digest", digest, sign, true, format)
+}
+
+// Import of the public key and the check of the signature
+// of the data or of the hash value.
+func (key PubKey256) verify(op string, data, sign []byte, digest bool, format []SignatureFormat) error {
+	sign, err := decodeSignature(key.prov(), sign, format)
+	if err != nil {
+		return err
 	}
+	h, err := importPubKey(key)()
+	if err != nil {
+		return err
+	}
+	defer h.Close()
+	return verifyHandle(op, h, data, sign, digest)
+}
 
-	return ret == 0
+// Import of the public key for verifyHandle.
+func importPubKey(key PubKey256) func() (handle, error) {
+	return func() (handle, error) {
+		return provider.ImportPubKey("import pub key", byte(key.prov()), key[1:])
+	}
 }
 
-// Comparison of public keys by addresses.
+// Check of the signature with the imported public key:
+// ErrBadSignature if the signature is incorrect.
+func verifyHandle(op string, h handle, data, sign []byte, digest bool) error {
+	return h.(backend.Verifier).Verify(op, data, sign, digest)
+}
+
+// Comparison of public keys by the provider type, the parameter set
+// and the point: the digest set is optional in the key blob
+// (omitted in certificates for tc26 256 and 512 bit keys).
 func (key PubKey512) Equals(cmp PubKey) bool {
 	return PubKey256(key).Equals(cmp)
 }
 func (key PubKey256) Equals(cmp PubKey) bool {
-	return bytes.Equal(key.Address(), cmp.Address())
+	if cmp == nil {
+		return false
+	}
+	k1, err1 := keyblob.Parse(key.Bytes())
+	k2, err2 := keyblob.Parse(cmp.Bytes())
+	if err1 != nil || err2 != nil {
+		return bytes.Equal(key.Bytes(), cmp.Bytes())
+	}
+	return k1.Prov == k2.Prov &&
+		k1.Params.ParamSet.Equal(k2.Params.ParamSet) &&
+		bytes.Equal(k1.Point, k2.Point)
 }
 
 // Retrieving a format string "ГОСТ Р 34.10-2012_???".
@@ -527,16 +588,6 @@ func (key PubKey256) prov() ProvType {
 	return ProvType(key[0])
 }
 
-// The main bytes of the public key.
-func (key PubKey256) bytes() *C.uchar {
-	return (*C.uchar)(&key[1])
-}
-
-// The length of main bytes of the public key.
-func (key PubKey256) len() C.uint {
-	return C.uint(len(key[1:]))
-}
-
 /*
  * BATCH VERIFIER
  */
@@ -561,7 +612,11 @@ func NewBatchVerifier() BatchVerifier {
 
 // Add a public key, data and a signature
 // for this data to the verifier object.
+// The length of the signature is checked by the key type.
 func (b *BatchVerifierX) Add(key PubKey, message, signature []byte) error {
+	if err := checkBatchItem(key, signature); err != nil {
+		return err
+	}
 	b.signs = append(b.signs, trySign{
 		pubkey:    key,
 		message:   message,
@@ -585,3 +640,22 @@ func (b *BatchVerifierX) Verify() (bool, []bool) {
 	}
 	return res, list
 }
+
+// Checking of the public key and the length of the signature.
+func checkBatchItem(key PubKey, signature []byte) error {
+	if key == nil {
+		return fmt.Errorf("error: public key is nil")
+	}
+	pbytes := key.Bytes()
+	if len(pbytes) == 0 {
+		return fmt.Errorf("error: length of public key")
+	}
+	size := ProvType(pbytes[0]).SignatureSize()
+	if size < 0 {
+		return fmt.Errorf("error: read prov type")
+	}
+	if len(signature) != size {
+		return fmt.Errorf("error: length of signature %d != %d", len(signature), size)
+	}
+	return nil
+}
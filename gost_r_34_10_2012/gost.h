@@ -4,15 +4,20 @@
 #include "../headers/common.h"
 
 // DESCRIPTION:
-// Create container wirh key by name and password;
+// Create container wirh keys by name and password;
 // INPUT:
-// prov      - type of crypto provider (80 or 81);
-// container - container name;
-// password  - password of container;
+// prov       - type of crypto provider (80 or 81);
+// container  - container name;
+// password   - password of container;
+// keys       - key pairs to generate: 0x1 (AT_SIGNATURE) | 0x2 (AT_KEYEXCHANGE);
+// exportable - keys are generated with CRYPT_EXPORTABLE if not 0;
+// paramset   - OID of the parameter set (KP_DHOID), NULL for default;
+// hashset    - OID of the digest parameters (KP_HASHOID), NULL for default;
 // OUTPUT:
 // int (CreateContainer) = 0 if success;
 // int (CreateContainer) = 1 if container exist;
-extern int CreateContainer(BYTE prov, BYTE *container, BYTE *password);
+// int (CreateContainer) < 0 result with error;
+extern int CreateContainer(BYTE prov, BYTE *container, BYTE *password, DWORD keys, DWORD exportable, BYTE *paramset, BYTE *hashset);
 
 // DESCRIPTION:
 // Obtaining a pointer to a public key by
@@ -37,6 +42,9 @@ extern int OpenContainer(BYTE prov, HCRYPTPROV *hProv, HCRYPTKEY *hKey, BYTE *co
 // password  - password of container;
 // OUTPUT:
 // int (CheckContainer) = 0 if success;
+// int (CheckContainer) = 1 if container not found;
+// int (CheckContainer) = 2 if password is wrong;
+// int (CheckContainer) < 0 result with error;
 extern int CheckContainer(BYTE prov, BYTE *container, BYTE *password);
 
 // DESCRIPTION:
@@ -58,19 +66,74 @@ extern int CheckContainer(BYTE prov, BYTE *container, BYTE *password);
 extern BYTE *SignMessage(BYTE prov, BYTE *container, BYTE *password, BYTE *data, DWORD size, DWORD *dwSigLen, DWORD spec);
 
 // DESCRIPTION:
-// Signature verification function based on source data; 
+// Function of signing a ready hash value with a private key;
+// GOST R 34.10-2012 (256, 512).
+// The private key is taken from the container using a password;
+// The hash value is loaded as is via CryptSetHashParam(HP_HASHVAL);
 // INPUT:
 // prov      - type of crypto provider (80 or 81);
-// hKey      - pointer to public key;
-// sign      - digital signature ;
-// dwSigLen  - size of signature in bytes;
-// data      - data subject to signature verification;
-// size      - the size of the data in bytes;
+// container - container name;
+// password  - password of container;
+// hash      - hash value GOST R 34.11-2012 (32 or 64 bytes);
+// size      - size of hash value;
+// dwSigLen  - pointer to the size of the signature in bytes;
+// spec      - key pair (AT_SIGNATURE or AT_KEYEXCHANGE);
+// OUTPUT:
+// dwSigLen  - size of signature;
+// BYTE *(SignHash) - pointer to digital signature ;
+// BYTE *(SignHash) != NULL if success;
+extern BYTE *SignHash(BYTE prov, BYTE *container, BYTE *password, BYTE *hash, DWORD size, DWORD *dwSigLen, DWORD spec);
+
+// DESCRIPTION:
+// Opening of the container for many signatures
+// (see SessionSign), the handles are closed by
+// CryptDestroyKey and CryptReleaseContext;
+// INPUT:
+// prov      - type of crypto provider (80 or 81);
+// hProv     - pointer to crypto provider;
+// hKey      - pointer to the key pair;
+// container - container name;
+// password  - password of container;
+// spec      - key pair (AT_SIGNATURE or AT_KEYEXCHANGE);
 // OUTPUT:
-// int (VerifySign) = 0 signature is correct (successful completion);
-// int (VerifySign) = 1 signature is incorrect (successful completion) ;
-// int (VerifySign) < 0 result with error;
-extern int VerifySign(BYTE prov, HCRYPTKEY *hKey, BYTE *sign, DWORD dwSigLen, BYTE *data, DWORD size);
+// hProv     - crypto provider with the password set;
+// hKey      - key pair of the container;
+// int (OpenSession) = 0 if success, 1 if container not found, 2 if wrong password;
+extern int OpenSession(BYTE prov, HCRYPTPROV *hProv, HCRYPTKEY *hKey, BYTE *container, BYTE *password, DWORD spec);
+
+// DESCRIPTION:
+// Signing of the data or of a ready hash value
+// with the crypto provider of OpenSession;
+// INPUT:
+// hProv     - pointer to crypto provider of OpenSession;
+// prov      - type of crypto provider (80 or 81);
+// data      - data or hash value GOST R 34.11-2012 (32 or 64 bytes);
+// size      - size of data;
+// digest    - 1 if data is a hash value;
+// dwSigLen  - pointer to the size of the signature in bytes;
+// spec      - key pair (AT_SIGNATURE or AT_KEYEXCHANGE);
+// OUTPUT:
+// dwSigLen  - size of signature;
+// BYTE *(SessionSign) != NULL if success;
+extern BYTE *SessionSign(HCRYPTPROV *hProv, BYTE prov, BYTE *data, DWORD size, DWORD digest, DWORD *dwSigLen, DWORD spec);
+
+// DESCRIPTION:
+// Function of signature verification with the public key
+// imported once by ImportPublicKey;
+// INPUT:
+// prov      - type of crypto provider (80 or 81);
+// hProv     - pointer to crypto provider of ImportPublicKey;
+// hKey      - pointer to the imported public key;
+// sign      - signature;
+// dwSigLen  - size of signature;
+// data      - signed data or hash value GOST R 34.11-2012 (32 or 64 bytes);
+// size      - size of data;
+// digest    - 1 if data is a hash value;
+// OUTPUT:
+// int (VerifyPrepared) = 0 signature is correct (successful completion);
+// int (VerifyPrepared) = 1 signature is incorrect (successful completion) ;
+// int (VerifyPrepared) < 0 result with error;
+extern int VerifyPrepared(BYTE prov, HCRYPTPROV *hProv, HCRYPTKEY *hKey, BYTE *sign, DWORD dwSigLen, BYTE *data, DWORD size, DWORD digest);
 
 // DESCRIPTION:
 // Obtaining a pointer to a public key by
@@ -98,4 +161,75 @@ extern int ImportPublicKey(BYTE prov, HCRYPTPROV *hProv, HCRYPTKEY *hKey, BYTE *
 // BYTE *(BytesPublicKey) != NULL if success;
 extern BYTE *BytesPublicKey(HCRYPTKEY *hKey, DWORD *size);
 
+// DESCRIPTION:
+// Enumeration of the containers available to the crypto provider
+// via CryptGetProvParam(PP_ENUMCONTAINERS) with CRYPT_FQCN;
+// INPUT:
+// prov      - type of crypto provider (80 or 81);
+// size      - pointer to the size of the list in bytes;
+// OUTPUT:
+// size      - size of the list;
+// BYTE *(EnumContainers) - pointer to the list of fully qualified
+// container names, each name is terminated by zero;
+// BYTE *(EnumContainers) != NULL if success;
+extern BYTE *EnumContainers(BYTE prov, DWORD *size);
+
+// DESCRIPTION:
+// Checking the key pairs stored in the container
+// without entering the password;
+// INPUT:
+// prov      - type of crypto provider (80 or 81);
+// container - container name (may be fully qualified);
+// OUTPUT:
+// int (ContainerKeys) = 0x1 | 0x2 (AT_SIGNATURE | AT_KEYEXCHANGE present);
+// int (ContainerKeys) < 0 result with error;
+extern int ContainerKeys(BYTE prov, BYTE *container);
+
+// DESCRIPTION:
+// Deleting the container with keys (CRYPT_DELETEKEYSET)
+// after checking the password;
+// INPUT:
+// prov      - type of crypto provider (80 or 81);
+// container - container name (may be fully qualified);
+// password  - password of container;
+// OUTPUT:
+// int (DeleteContainer) = 0 if success;
+// int (DeleteContainer) = 1 if container not found;
+// int (DeleteContainer) = 2 if password is wrong;
+// int (DeleteContainer) < 0 result with error;
+extern int DeleteContainer(BYTE prov, BYTE *container, BYTE *password);
+
+// DESCRIPTION:
+// Copying the container with all key pairs to a new container
+// (CryptSetProvParam(PP_HCRYPTPROV)), the copy is protected
+// by its own password (CryptSetProvParam(PP_CHANGE_PIN));
+// INPUT:
+// prov      - type of crypto provider (80 or 81);
+// src       - source container name (may be fully qualified);
+// srcpass   - password of source container;
+// dst       - new container name (may be fully qualified);
+// dstpass   - password of new container;
+// OUTPUT:
+// int (CopyContainer) = 0 if success;
+// int (CopyContainer) = 1 if source container not found;
+// int (CopyContainer) = 2 if password is wrong;
+// int (CopyContainer) = 3 if new container exists;
+// int (CopyContainer) < 0 result with error;
+extern int CopyContainer(BYTE prov, BYTE *src, BYTE *srcpass, BYTE *dst, BYTE *dstpass);
+
+// DESCRIPTION:
+// Changing the password of the container
+// (CryptSetProvParam(PP_CHANGE_PIN));
+// INPUT:
+// prov        - type of crypto provider (80 or 81);
+// container   - container name (may be fully qualified);
+// password    - password of container;
+// newpassword - new password of container;
+// OUTPUT:
+// int (ChangePassword) = 0 if success;
+// int (ChangePassword) = 1 if container not found;
+// int (ChangePassword) = 2 if password is wrong;
+// int (ChangePassword) < 0 result with error;
+extern int ChangePassword(BYTE prov, BYTE *container, BYTE *password, BYTE *newpassword);
+
 #endif /* GOST_R_34_10_2012_H */
@@ -3,6 +3,8 @@ package gost_r_34_10_2012
 
 import (
 	"testing"
+
+	ghash "github.com/towleeee/go-cryptopro/gost_r_34_11_2012"
 )
 
 const (
@@ -19,10 +21,18 @@ var (
 var (
 	PRIVATE_KEY PrivKey
 	PUBLIC_KEY  PubKey
+
+	PRIVATE_KEY_512 PrivKey
+	PUBLIC_KEY_512  PubKey
 )
 
 func init() {
-	cfg := NewConfig(K256, TEST_SUBJECT, TEST_PASSWORD)
+	PRIVATE_KEY, PUBLIC_KEY = testKeys(K256)
+	PRIVATE_KEY_512, PUBLIC_KEY_512 = testKeys(K512)
+}
+
+func testKeys(prov ProvType) (PrivKey, PubKey) {
+	cfg := NewConfig(prov, TEST_SUBJECT, TEST_PASSWORD)
 	err := GenPrivKey(cfg)
 	if err != nil {
 		println("test warning: key already exist?")
@@ -38,36 +48,166 @@ func init() {
 		panic("test failed: load priv key")
 	}
 
-	PRIVATE_KEY = priv
-	PUBLIC_KEY = priv.PubKey()
-
-	PUBLIC_KEY, err = LoadPubKey(PUBLIC_KEY.Bytes())
+	pub, err := LoadPubKey(priv.PubKey(AT_SIGNATURE).Bytes())
 	if err != nil {
 		panic("test failed: load pub key")
 	}
+
+	return priv, pub
+}
+
+func TestKeys512(t *testing.T) {
+	if _, ok := PRIVATE_KEY_512.(PrivKey512); !ok {
+		t.Errorf("test failed: load priv key 512")
+		return
+	}
+
+	if _, ok := PUBLIC_KEY_512.(PubKey512); !ok {
+		t.Errorf("test failed: load pub key 512")
+		return
+	}
+
+	if len(PUBLIC_KEY_512.Bytes()) != PubKeySize512 {
+		t.Errorf("test failed: length of pub key 512")
+		return
+	}
+
+	priv, err := NewPrivKey(NewConfig(K512, TEST_SUBJECT, TEST_PASSWORD))
+	if err != nil {
+		t.Errorf("test failed: new priv key 512")
+		return
+	}
+
+	container, ok := priv.(PrivContainer)
+	if !ok || container.KeySpec != AT_SIGNATURE {
+		t.Errorf("test failed: priv container 512")
+		return
+	}
+
+	if !priv.Equals(PRIVATE_KEY_512) || !priv.PubKey(AT_SIGNATURE).Equals(PUBLIC_KEY_512) {
+		t.Errorf("test failed: equals 512")
+		return
+	}
+
+	if priv.Equals(PRIVATE_KEY) || PUBLIC_KEY.Equals(PUBLIC_KEY_512) {
+		t.Errorf("test failed: equals 256 and 512")
+		return
+	}
+
+	pbytes := append([]byte{}, PRIVATE_KEY_512.Bytes()...)
+	_, err = LoadPrivKey(pbytes[:len(pbytes)-1])
+	if err == nil {
+		t.Errorf("test failed: load priv key with wrong length")
+		return
+	}
+
+	pbytes = append([]byte{}, PUBLIC_KEY_512.Bytes()...)
+	_, err = LoadPubKey(pbytes[:PubKeySize256])
+	if err == nil {
+		t.Errorf("test failed: load pub key with wrong length")
+		return
+	}
 }
 
 func TestVerifySign(t *testing.T) {
-	sign, err := PRIVATE_KEY.Sign(TEST_MESSAGE_1)
+	testVerifySign(t, PRIVATE_KEY, PUBLIC_KEY)
+}
+
+func TestVerifySign512(t *testing.T) {
+	testVerifySign(t, PRIVATE_KEY_512, PUBLIC_KEY_512)
+}
+
+func testVerifySign(t *testing.T, priv PrivKey, pub PubKey) {
+	sign, err := priv.Sign(TEST_MESSAGE_1, AT_SIGNATURE)
 	if err != nil {
 		t.Errorf("test failed: sign")
 		return
 	}
 
-	if !PUBLIC_KEY.VerifySignature(TEST_MESSAGE_1, sign) {
+	if len(sign) != ProvType(pub.Bytes()[0]).SignatureSize() {
+		t.Errorf("test failed: length of sign")
+		return
+	}
+
+	if !pub.VerifySignature(TEST_MESSAGE_1, sign) {
 		t.Errorf("test failed: verify (1)")
 		return
 	}
 
 	sign[7] ^= byte(0x1)
 
-	if PUBLIC_KEY.VerifySignature(TEST_MESSAGE_1, sign) {
+	if pub.VerifySignature(TEST_MESSAGE_1, sign) {
 		t.Errorf("test failed: verify (2)")
 		return
 	}
 }
 
+func TestSignDigest(t *testing.T) {
+	testSignDigest(t, PRIVATE_KEY, PUBLIC_KEY, ghash.H256, ghash.H512)
+}
+
+func TestSignDigest512(t *testing.T) {
+	testSignDigest(t, PRIVATE_KEY_512, PUBLIC_KEY_512, ghash.H512, ghash.H256)
+}
+
+func testSignDigest(t *testing.T, priv PrivKey, pub PubKey, hprov, wrong ghash.ProvType) {
+	digest := ghash.Sum(hprov, TEST_MESSAGE_1)
+
+	sign, err := priv.SignDigest(digest, AT_SIGNATURE)
+	if err != nil {
+		t.Errorf("test failed: sign digest")
+		return
+	}
+
+	if !pub.VerifyDigest(digest, sign) {
+		t.Errorf("test failed: verify digest (1)")
+		return
+	}
+
+	if !pub.VerifySignature(TEST_MESSAGE_1, sign) {
+		t.Errorf("test failed: verify message (1)")
+		return
+	}
+
+	sign, err = priv.Sign(TEST_MESSAGE_1, AT_SIGNATURE)
+	if err != nil {
+		t.Errorf("test failed: sign")
+		return
+	}
+
+	if !pub.VerifyDigest(digest, sign) {
+		t.Errorf("test failed: verify digest (2)")
+		return
+	}
+
+	sign[7] ^= byte(0x1)
+
+	if pub.VerifyDigest(digest, sign) {
+		t.Errorf("test failed: verify digest (3)")
+		return
+	}
+
+	_, err = priv.SignDigest(ghash.Sum(wrong, TEST_MESSAGE_1), AT_SIGNATURE)
+	if err == nil {
+		t.Errorf("test failed: sign digest with wrong length")
+		return
+	}
+
+	if pub.VerifyDigest(digest[1:], sign) {
+		t.Errorf("test failed: verify digest with wrong length")
+		return
+	}
+}
+
 func TestBatchVerifier(t *testing.T) {
+	testBatchVerifier(t, PRIVATE_KEY, PUBLIC_KEY)
+}
+
+func TestBatchVerifier512(t *testing.T) {
+	testBatchVerifier(t, PRIVATE_KEY_512, PUBLIC_KEY_512)
+}
+
+func testBatchVerifier(t *testing.T, priv PrivKey, pub PubKey) {
 	batchv := NewBatchVerifier()
 
 	msgs := [][]byte{
@@ -77,12 +217,12 @@ func TestBatchVerifier(t *testing.T) {
 	}
 
 	for _, v := range msgs {
-		sign, err := PRIVATE_KEY.Sign(v)
+		sign, err := priv.Sign(v, AT_SIGNATURE)
 		if err != nil {
 			t.Errorf("test failed: sign")
 			return
 		}
-		batchv.Add(PUBLIC_KEY, v, sign)
+		batchv.Add(pub, v, sign)
 	}
 
 	ok, oks := batchv.Verify()
@@ -101,7 +241,7 @@ func TestBatchVerifier(t *testing.T) {
 
 func BenchmarkVerifySign(b *testing.B) {
 	for i := 0; i < b.N; i++ {
-		sign, err := PRIVATE_KEY.Sign(TEST_MESSAGE_1)
+		sign, err := PRIVATE_KEY.Sign(TEST_MESSAGE_1, AT_SIGNATURE)
 		if err != nil {
 			b.Errorf("benchmark failed: sign")
 			break
@@ -112,3 +252,17 @@ func BenchmarkVerifySign(b *testing.B) {
 		}
 	}
 }
+
+func BenchmarkVerifySign512(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		sign, err := PRIVATE_KEY_512.Sign(TEST_MESSAGE_1, AT_SIGNATURE)
+		if err != nil {
+			b.Errorf("benchmark failed: sign")
+			break
+		}
+		if !PUBLIC_KEY_512.VerifySignature(TEST_MESSAGE_1, sign) {
+			b.Errorf("benchmark failed: verify")
+			break
+		}
+	}
+}
@@ -1,19 +1,12 @@
 package gost_r_34_10_2012
 
-/*
-#cgo LDFLAGS: -Wl,--allow-multiple-definition
-#cgo linux,amd64 CFLAGS: -I/opt/cprocsp/include/cpcsp -DUNIX -DLINUX -DSIZEOF_VOID_P=8
-#cgo linux,386 CFLAGS: -I/opt/cprocsp/include/cpcsp -DUNIX -DLINUX -DSIZEOF_VOID_P=4
-#cgo linux,amd64 LDFLAGS: -L/opt/cprocsp/lib/amd64/ -lcapi10 -lcapi20 -lrdrsup -lssp
-#cgo linux,386 LDFLAGS: -L/opt/cprocsp/lib/ia32/ -lcapi10 -lcapi20 -lrdrsup -lssp
-*/
-import "C"
 import (
 	"bytes"
+	"encoding/asn1"
 	"encoding/hex"
 	"fmt"
-	"unsafe"
 
+	"github.com/towleeee/go-cryptopro/csplog"
 	ghash "github.com/towleeee/go-cryptopro/gost_r_34_11_2012"
 )
 
@@ -22,7 +15,8 @@ import (
  */
 const keyHashSize = ghash.Size256
 
-// Debug enable debug log print
+// Debug enable debug messages,
+// the messages are passed to the logger of csplog.SetLogger.
 var Debug bool
 
 func init() {
@@ -31,7 +25,7 @@ func init() {
 
 func log(s string) {
 	if Debug {
-		fmt.Println(s)
+		csplog.Log(csplog.LevelDebug, s)
 	}
 }
 
@@ -40,8 +34,10 @@ type Address []byte
 type PrivKey interface {
 	Bytes() []byte
 	String() string
-	Sign(msg []byte, spec KeySpec) ([]byte, error)
+	Sign(msg []byte, spec KeySpec, format ...SignatureFormat) ([]byte, error)
+	SignDigest(digest []byte, spec KeySpec, format ...SignatureFormat) ([]byte, error)
 	PubKey(spec KeySpec) PubKey
+	PubKeyE(spec KeySpec) (PubKey, error)
 	Equals(PrivKey) bool
 	Type() string
 }
@@ -50,7 +46,12 @@ type PubKey interface {
 	Address() Address
 	Bytes() []byte
 	String() string
-	VerifySignature(msg []byte, sig []byte) bool
+	VerifySignature(msg []byte, sig []byte, format ...SignatureFormat) bool
+	VerifyDigest(digest []byte, sig []byte, format ...SignatureFormat) bool
+	VerifySignatureE(msg []byte, sig []byte, format ...SignatureFormat) error
+	VerifyDigestE(digest []byte, sig []byte, format ...SignatureFormat) error
+	Params() Params
+	Point() []byte
 	Equals(PubKey) bool
 	Type() string
 }
@@ -69,17 +70,33 @@ type Config struct {
 	container string
 	password  string
 	keySpec   KeySpec
+	reader    string
+	// Container name and kind of wrapping
+	// for the derivation of a new password.
+	subject string
+	soft    bool
+	// Key pairs generated by GenPrivKey (keySpec if empty)
+	// and the prohibition of the key export.
+	genKeys       []KeySpec
+	nonExportable bool
+	// Parameter set of generated keys (provider default if nil).
+	paramSet asn1.ObjectIdentifier
+	// Error of the wrapping returned by GenPrivKey, NewPrivKey
+	// and the functions of containers.
+	err error
 }
 
+// The error of the hashing of the name and the password
+// is returned by GenPrivKey and NewPrivKey.
 func NewConfig(prov ProvType, container, password string) *Config {
 	switch prov {
 	case K256, K512:
-		return (&Config{
+		return wrapConfig((&Config{
 			prov:      prov,
 			container: container,
 			password:  password,
 			keySpec:   AT_SIGNATURE,
-		}).wrap()
+		}).wrap())
 	default:
 		return nil
 	}
@@ -89,21 +106,82 @@ func NewConfig(prov ProvType, container, password string) *Config {
 func SimpleConfig(prov ProvType, container, password string, spec KeySpec) *Config {
 	switch prov {
 	case K256, K512:
-		return (&Config{
+		return wrapConfig((&Config{
 			prov:      prov,
 			container: container,
 			password:  password,
 			keySpec:   spec,
-		}).softWrap()
+		}).softWrap())
 	default:
 		return nil
 	}
 }
 
+// Config keeping the error of the wrapping until it is used.
+func wrapConfig(cfg *Config, err error) *Config {
+	if err != nil {
+		return &Config{
+			prov:    cfg.prov,
+			keySpec: cfg.keySpec,
+			subject: cfg.container,
+			err:     err,
+		}
+	}
+	return cfg
+}
+
+// Copy of the config with the key pair
+// used by the private key of NewPrivKey and generated by GenPrivKey.
+func (cfg *Config) WithKeySpec(spec KeySpec) *Config {
+	c := *cfg
+	c.keySpec = spec
+	return &c
+}
+
+// Copy of the config generating several key pairs
+// in one container, e.g. AT_SIGNATURE and AT_KEYEXCHANGE.
+func (cfg *Config) WithGenKeys(specs ...KeySpec) *Config {
+	c := *cfg
+	c.genKeys = append([]KeySpec{}, specs...)
+	return &c
+}
+
+// Copy of the config generating exportable (by default)
+// or non-exportable keys.
+func (cfg *Config) WithExportable(exportable bool) *Config {
+	c := *cfg
+	c.nonExportable = !exportable
+	return &c
+}
+
+// Key pairs generated by GenPrivKey in the form of ContainerKeys:
+// 0x1 - AT_SIGNATURE, 0x2 - AT_KEYEXCHANGE.
+func (cfg *Config) genKeyFlags() (uint32, error) {
+	specs := cfg.genKeys
+	if len(specs) == 0 {
+		specs = []KeySpec{cfg.keySpec}
+	}
+	var flags uint32
+	for _, v := range specs {
+		switch v {
+		case AT_SIGNATURE:
+			flags |= 0x1
+		case AT_KEYEXCHANGE:
+			flags |= 0x2
+		default:
+			return 0, fmt.Errorf("error: key spec %d", v)
+		}
+	}
+	return flags, nil
+}
+
 // salt добить до 32
-func salt(data string) string {
+func salt(data string) (string, error) {
 	buf := bytes.NewBufferString(data)
-	h := ghash.Sum(ghash.ProvType(K256), buf.Bytes())
+	h, err := ghash.SumE(ghash.ProvType(K256), buf.Bytes())
+	if err != nil {
+		return "", err
+	}
 	if len(data) < keyHashSize {
 		log(fmt.Sprintf("{data: %s, len: %d}",
 			buf.String(),
@@ -120,48 +198,71 @@ func salt(data string) string {
 	}
 	dst := make([]byte, hex.EncodedLen(keyHashSize))
 	_ = hex.Encode(dst, buf.Bytes())
-	return string(dst)
+	return string(dst), nil
 }
 
 // softWrap без хэша
-func (cfg *Config) softWrap() *Config {
+func (cfg *Config) softWrap() (*Config, error) {
+	container, err := salt(cfg.container)
+	if err != nil {
+		return cfg, err
+	}
+	password, err := salt(cfg.password)
+	if err != nil {
+		return cfg, err
+	}
 	c := &Config{
 		prov:      cfg.prov,
-		container: salt(cfg.container),
-		password:  salt(cfg.password),
+		container: container,
+		password:  password,
 		keySpec:   cfg.keySpec,
+		reader:    cfg.reader,
+		subject:   cfg.container,
+		soft:      true,
 	}
 	log(fmt.Sprintf("%+v", c))
-	return c
+	return c, nil
 }
 
-func (cfg *Config) wrap() *Config {
-	return &Config{
-		prov: cfg.prov,
-		container: hex.EncodeToString(ghash.SumHMAC(
-			ghash.H256,
-			[]byte(cfg.container),
-			[]byte{byte(cfg.prov)},
-		)),
-		password: hex.EncodeToString(ghash.SumHMAC(
-			ghash.H256,
-			[]byte(cfg.password),
-			[]byte(cfg.container),
-		)),
+func (cfg *Config) wrap() (*Config, error) {
+	container, err := ghash.SumHMACE(
+		ghash.H256,
+		[]byte(cfg.container),
+		[]byte{byte(cfg.prov)},
+	)
+	if err != nil {
+		return cfg, err
 	}
+	password, err := ghash.SumHMACE(
+		ghash.H256,
+		[]byte(cfg.password),
+		[]byte(cfg.container),
+	)
+	if err != nil {
+		return cfg, err
+	}
+	return &Config{
+		prov:      cfg.prov,
+		container: hex.EncodeToString(container),
+		password:  hex.EncodeToString(password),
+		keySpec:   cfg.keySpec,
+		reader:    cfg.reader,
+		subject:   cfg.container,
+	}, nil
 }
 
-func toGOstring(cstr *C.uchar) string {
-	return C.GoString((*C.char)(unsafe.Pointer(cstr)))
-}
-
-func toCstring(gostr string) *C.uchar {
-	return (*C.uchar)(&append([]byte(gostr), 0)[0])
-}
-
-func toCbytes(data []byte) *C.uchar {
-	if len(data) > 0 {
-		return (*C.uchar)(&data[0])
+// Config of the same container with the new password,
+// the password is wrapped in the same way as by NewConfig or SimpleConfig.
+func (cfg *Config) withPassword(password string) (*Config, error) {
+	c := &Config{
+		prov:      cfg.prov,
+		container: cfg.subject,
+		password:  password,
+		keySpec:   cfg.keySpec,
+		reader:    cfg.reader,
+	}
+	if cfg.soft {
+		return c.softWrap()
 	}
-	return nil
+	return c.wrap()
 }
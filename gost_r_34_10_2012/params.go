@@ -0,0 +1,91 @@
+package gost_r_34_10_2012
+
+import (
+	"encoding/asn1"
+	"fmt"
+
+	"github.com/towleeee/go-cryptopro/internal/keyblob"
+	"github.com/towleeee/go-cryptopro/internal/oids"
+)
+
+/*
+ * PARAMETER SETS
+ */
+
+// Parameter sets of GOST R 34.10-2012 keys (RFC 9215, R 1323565.1.024-2019).
+var (
+	ParamSetCryptoProA    = oids.CryptoProA
+	ParamSetCryptoProB    = oids.CryptoProB
+	ParamSetCryptoProC    = oids.CryptoProC
+	ParamSetCryptoProXchA = oids.CryptoProXchA
+	ParamSetCryptoProXchB = oids.CryptoProXchB
+
+	ParamSetTc26256A = oids.Tc26Gost256A
+	ParamSetTc26256B = oids.Tc26Gost256B
+	ParamSetTc26256C = oids.Tc26Gost256C
+	ParamSetTc26256D = oids.Tc26Gost256D
+
+	ParamSetTc26512A = oids.Tc26Gost512A
+	ParamSetTc26512B = oids.Tc26Gost512B
+	ParamSetTc26512C = oids.Tc26Gost512C
+)
+
+// Parameters of the public key:
+// the parameter set of the curve and the digest parameters
+// (always set in the key blob, only for CryptoPro 256 parameter
+// sets in certificates).
+type Params struct {
+	ParamSet  asn1.ObjectIdentifier
+	DigestSet asn1.ObjectIdentifier
+}
+
+// Copy of the config generating keys on the parameter set,
+// the size of the parameter set must be the same as of the prov.
+func (cfg *Config) WithParamSet(paramSet asn1.ObjectIdentifier) *Config {
+	c := *cfg
+	c.paramSet = paramSet
+	return &c
+}
+
+// OIDs of KP_DHOID and KP_HASHOID for CreateContainer,
+// empty for the provider default (the digest set of the key size).
+func (cfg *Config) genParams() (string, string, error) {
+	if cfg.paramSet == nil {
+		return "", "", nil
+	}
+
+	size := oids.ParamSetSize(cfg.paramSet)
+	switch {
+	case size == 256 && cfg.prov == K256:
+		// pass
+	case size == 512 && cfg.prov == K512:
+		return cfg.paramSet.String(), "", nil
+	default:
+		return "", "", fmt.Errorf("error: parameter set %s for prov %s", cfg.paramSet, cfg.prov)
+	}
+
+	for _, v := range []asn1.ObjectIdentifier{
+		oids.Tc26Gost256A, oids.Tc26Gost256B, oids.Tc26Gost256C, oids.Tc26Gost256D,
+	} {
+		if v.Equal(cfg.paramSet) {
+			return cfg.paramSet.String(), "", nil
+		}
+	}
+
+	return cfg.paramSet.String(), oids.GostR3411_12_256.String(), nil
+}
+
+// Parameters from the public key blob.
+func (key PubKey512) Params() Params {
+	return PubKey256(key).Params()
+}
+func (key PubKey256) Params() Params {
+	blob, err := keyblob.Parse(key.Bytes())
+	if err != nil {
+		return Params{}
+	}
+	return Params{
+		ParamSet:  blob.Params.ParamSet,
+		DigestSet: blob.Params.DigestSet,
+	}
+}
@@ -0,0 +1,78 @@
+// go test -v -bench=. -benchtime=100x
+package gost_r_34_10_2012
+
+import (
+	"encoding/asn1"
+	"testing"
+
+	"github.com/towleeee/go-cryptopro/internal/oids"
+)
+
+func TestParamSet(t *testing.T) {
+	tests := []struct {
+		prov      ProvType
+		paramSet  asn1.ObjectIdentifier
+		digestSet asn1.ObjectIdentifier
+	}{
+		{K256, ParamSetTc26256B, oids.GostR3411_12_256},
+		{K256, ParamSetCryptoProA, oids.GostR3411_12_256},
+		{K512, ParamSetTc26512C, oids.GostR3411_12_512},
+	}
+
+	for i, v := range tests {
+		cfg := NewConfig(v.prov, TEST_SUBJECT+"_params_"+v.paramSet.String(), TEST_PASSWORD).
+			WithParamSet(v.paramSet)
+		defer DeleteContainer(cfg)
+
+		err := GenPrivKey(cfg)
+		if err != nil {
+			t.Errorf("test failed: gen priv key (%d)", i)
+			return
+		}
+
+		priv, err := NewPrivKey(cfg)
+		if err != nil {
+			t.Errorf("test failed: new priv key (%d)", i)
+			return
+		}
+
+		pub := priv.PubKey(AT_SIGNATURE)
+		params := pub.Params()
+		if !params.ParamSet.Equal(v.paramSet) {
+			t.Errorf("test failed: param set (%d)", i)
+			return
+		}
+		if !params.DigestSet.Equal(v.digestSet) {
+			t.Errorf("test failed: digest set (%d)", i)
+			return
+		}
+
+		sign, err := priv.Sign(TEST_MESSAGE_1, AT_SIGNATURE)
+		if err != nil {
+			t.Errorf("test failed: sign (%d)", i)
+			return
+		}
+		if !pub.VerifySignature(TEST_MESSAGE_1, sign) {
+			t.Errorf("test failed: verify (%d)", i)
+			return
+		}
+	}
+
+	err := GenPrivKey(NewConfig(K256, TEST_SUBJECT+"_params", TEST_PASSWORD).WithParamSet(ParamSetTc26512A))
+	if err == nil {
+		t.Errorf("test failed: gen priv key with wrong param set")
+		return
+	}
+}
+
+func TestParams(t *testing.T) {
+	if len(PUBLIC_KEY.Params().ParamSet) == 0 {
+		t.Errorf("test failed: params 256")
+		return
+	}
+
+	if len(PUBLIC_KEY_512.Params().ParamSet) == 0 {
+		t.Errorf("test failed: params 512")
+		return
+	}
+}
@@ -0,0 +1,56 @@
+package gost_r_34_10_2012
+
+import (
+	"encoding/pem"
+	"fmt"
+
+	"github.com/towleeee/go-cryptopro/internal/keyblob"
+)
+
+const (
+	PemPublicKey = "PUBLIC KEY"
+)
+
+/*
+ * SUBJECT PUBLIC KEY INFO
+ */
+
+// DER encoding of the public key as SubjectPublicKeyInfo
+// (RFC 4491, RFC 9215), the form of OpenSSL and other libraries.
+func MarshalPKIX(pub PubKey) ([]byte, error) {
+	return keyblob.MarshalPKIX(pub.Bytes())
+}
+
+// Parsing of DER of SubjectPublicKeyInfo to PubKey256/PubKey512.
+// The key is checked by the import to CSP.
+func ParsePKIX(der []byte) (PubKey, error) {
+	pbytes, err := keyblob.UnmarshalPKIX(der, 0)
+	if err != nil {
+		return nil, err
+	}
+	return LoadPubKey(pbytes)
+}
+
+// PEM encoding of the public key (block "PUBLIC KEY").
+func MarshalPKIXPEM(pub PubKey) ([]byte, error) {
+	der, err := MarshalPKIX(pub)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{
+		Type:  PemPublicKey,
+		Bytes: der,
+	}), nil
+}
+
+// Parsing of PEM block "PUBLIC KEY".
+func ParsePKIXPEM(data []byte) (PubKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("error: pem block not found")
+	}
+	if block.Type != PemPublicKey {
+		return nil, fmt.Errorf("error: pem type %q", block.Type)
+	}
+	return ParsePKIX(block.Bytes)
+}
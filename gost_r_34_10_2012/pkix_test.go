@@ -0,0 +1,103 @@
+// go test -v -bench=. -benchtime=100x
+package gost_r_34_10_2012
+
+import (
+	"encoding/asn1"
+	"testing"
+)
+
+func TestPKIX(t *testing.T) {
+	for _, pub := range []PubKey{PUBLIC_KEY, PUBLIC_KEY_512} {
+		der, err := MarshalPKIX(pub)
+		if err != nil {
+			t.Errorf("test failed: marshal pkix: %v", err)
+			return
+		}
+
+		parsed, err := ParsePKIX(der)
+		if err != nil {
+			t.Errorf("test failed: parse pkix: %v", err)
+			return
+		}
+		if !parsed.Equals(pub) {
+			t.Errorf("test failed: parsed != original")
+			return
+		}
+
+		data, err := MarshalPKIXPEM(pub)
+		if err != nil {
+			t.Errorf("test failed: marshal pkix pem: %v", err)
+			return
+		}
+
+		parsed, err = ParsePKIXPEM(data)
+		if err != nil {
+			t.Errorf("test failed: parse pkix pem: %v", err)
+			return
+		}
+		if !parsed.Equals(pub) {
+			t.Errorf("test failed: parsed pem != original")
+			return
+		}
+
+		if _, err := ParsePKIX(der[:len(der)-1]); err == nil {
+			t.Errorf("test failed: parse truncated pkix")
+			return
+		}
+	}
+
+	if _, err := ParsePKIXPEM([]byte("-----BEGIN CERTIFICATE-----\n-----END CERTIFICATE-----\n")); err == nil {
+		t.Errorf("test failed: parse pem type")
+		return
+	}
+}
+
+// Keys without the digest set in the certificate:
+// tc26 256 (except CryptoPro) and all 512 bit parameter sets.
+func TestPKIXParamSets(t *testing.T) {
+	for _, v := range []struct {
+		prov     ProvType
+		paramSet asn1.ObjectIdentifier
+	}{
+		{K256, ParamSetCryptoProA},
+		{K256, ParamSetTc26256B},
+		{K256, ParamSetTc26256D},
+		{K512, ParamSetTc26512A},
+		{K512, ParamSetTc26512B},
+	} {
+		cfg := NewConfig(v.prov, TEST_SUBJECT+"_pkix_"+v.paramSet.String(), TEST_PASSWORD).
+			WithParamSet(v.paramSet)
+		if err := GenPrivKey(cfg); err != nil {
+			println("test warning: key already exist?")
+		}
+		priv, err := NewPrivKey(cfg)
+		if err != nil {
+			t.Errorf("test failed: %s: new priv key: %v", v.paramSet, err)
+			return
+		}
+		pub, err := priv.PubKeyE(AT_SIGNATURE)
+		if err != nil {
+			t.Errorf("test failed: %s: pub key: %v", v.paramSet, err)
+			return
+		}
+
+		der, err := MarshalPKIX(pub)
+		if err != nil {
+			t.Errorf("test failed: %s: marshal pkix: %v", v.paramSet, err)
+			return
+		}
+		parsed, err := ParsePKIX(der)
+		if err != nil {
+			t.Errorf("test failed: %s: parse pkix: %v", v.paramSet, err)
+			return
+		}
+		if !parsed.Equals(pub) || !pub.Equals(parsed) {
+			t.Errorf("test failed: %s: parsed != original", v.paramSet)
+			return
+		}
+		if !parsed.Params().ParamSet.Equal(v.paramSet) {
+			t.Errorf("test failed: %s: param set", v.paramSet)
+			return
+		}
+	}
+}
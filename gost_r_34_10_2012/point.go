@@ -0,0 +1,90 @@
+package gost_r_34_10_2012
+
+import (
+	"fmt"
+
+	"github.com/towleeee/go-cryptopro/internal/curves"
+	"github.com/towleeee/go-cryptopro/internal/keyblob"
+	"github.com/towleeee/go-cryptopro/internal/oids"
+)
+
+/*
+ * CURVE POINT
+ */
+
+// Public key 256 bit from the raw point: little-endian X || little-endian Y
+// (64 bytes) on the curve of the parameter set.
+// The point is checked to be on the curve and in the subgroup,
+// the digest set of 256 bit keys is GOST R 34.11-2012 if nil.
+func NewPubKey256(point []byte, params Params) (PubKey256, error) {
+	return newPubKey(K256, point, params)
+}
+
+// Public key 512 bit from the raw point: little-endian X || little-endian Y
+// (128 bytes) on the curve of the parameter set.
+// The digest set is GOST R 34.11-2012 512 if nil, as in the keys of CSP.
+func NewPubKey512(point []byte, params Params) (PubKey512, error) {
+	key, err := newPubKey(K512, point, params)
+	if err != nil {
+		return nil, err
+	}
+	return PubKey512(key), nil
+}
+
+func newPubKey(prov ProvType, point []byte, params Params) (PubKey256, error) {
+	size := oids.ParamSetSize(params.ParamSet)
+	if (prov == K256 && size != 256) || (prov == K512 && size != 512) {
+		return nil, fmt.Errorf("error: parameter set %s for prov %s", params.ParamSet, prov)
+	}
+
+	curve := curves.ByParamSet(params.ParamSet)
+	if curve == nil {
+		return nil, fmt.Errorf("error: parameter set %s", params.ParamSet)
+	}
+	if err := curve.CheckPoint(point); err != nil {
+		return nil, err
+	}
+
+	blobParams := keyblob.Params{
+		ParamSet:  params.ParamSet,
+		DigestSet: params.DigestSet,
+	}
+	if blobParams.DigestSet == nil {
+		blobParams.DigestSet = keyblob.DigestSet(byte(prov))
+	}
+
+	pbytes, err := (&keyblob.PublicKey{
+		Prov:   byte(prov),
+		Params: blobParams,
+		Point:  point,
+	}).Bytes()
+	if err != nil {
+		return nil, err
+	}
+	return PubKey256(pbytes), nil
+}
+
+// Raw point of the public key:
+// little-endian X || little-endian Y (64 or 128 bytes).
+func (key PubKey512) Point() []byte {
+	return PubKey256(key).Point()
+}
+func (key PubKey256) Point() []byte {
+	blob, err := keyblob.Parse(key.Bytes())
+	if err != nil {
+		return nil
+	}
+	return append([]byte{}, blob.Point...)
+}
+
+// Coordinates of the public key in little-endian (32 or 64 bytes each).
+func (key PubKey512) Coordinates() (x, y []byte) {
+	return PubKey256(key).Coordinates()
+}
+func (key PubKey256) Coordinates() (x, y []byte) {
+	point := key.Point()
+	if point == nil {
+		return nil, nil
+	}
+	return point[:len(point)/2], point[len(point)/2:]
+}
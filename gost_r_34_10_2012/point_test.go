@@ -0,0 +1,81 @@
+// go test -v -bench=. -benchtime=100x
+package gost_r_34_10_2012
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestPubKeyPoint(t *testing.T) {
+	point := PUBLIC_KEY.Point()
+	if len(point) != 64 {
+		t.Errorf("test failed: length of point")
+		return
+	}
+
+	x, y := PubKey256(PUBLIC_KEY.Bytes()).Coordinates()
+	if !bytes.Equal(append(x, y...), point) {
+		t.Errorf("test failed: coordinates")
+		return
+	}
+
+	pub, err := NewPubKey256(point, PUBLIC_KEY.Params())
+	if err != nil {
+		t.Errorf("test failed: new pub key: %v", err)
+		return
+	}
+	if !bytes.Equal(pub.Point(), point) || !pub.Params().ParamSet.Equal(PUBLIC_KEY.Params().ParamSet) {
+		t.Errorf("test failed: point or params of new pub key")
+		return
+	}
+
+	sign, err := PRIVATE_KEY.Sign(TEST_MESSAGE_1, AT_SIGNATURE)
+	if err != nil {
+		t.Errorf("test failed: sign")
+		return
+	}
+	if !pub.VerifySignature(TEST_MESSAGE_1, sign) {
+		t.Errorf("test failed: verify with new pub key")
+		return
+	}
+
+	wrong := append([]byte{}, point...)
+	wrong[0] ^= 0x1
+	if _, err := NewPubKey256(wrong, PUBLIC_KEY.Params()); err == nil {
+		t.Errorf("test failed: point is not on the curve")
+		return
+	}
+
+	if _, err := NewPubKey512(point, PUBLIC_KEY.Params()); err == nil {
+		t.Errorf("test failed: parameter set 256 for key 512")
+		return
+	}
+}
+
+func TestPubKeyPoint512(t *testing.T) {
+	point := PUBLIC_KEY_512.Point()
+	if len(point) != 128 {
+		t.Errorf("test failed: length of point")
+		return
+	}
+
+	pub, err := NewPubKey512(point, PUBLIC_KEY_512.Params())
+	if err != nil {
+		t.Errorf("test failed: new pub key: %v", err)
+		return
+	}
+	if !bytes.Equal(pub.Point(), point) {
+		t.Errorf("test failed: point of new pub key")
+		return
+	}
+
+	sign, err := PRIVATE_KEY_512.Sign(TEST_MESSAGE_1, AT_SIGNATURE)
+	if err != nil {
+		t.Errorf("test failed: sign")
+		return
+	}
+	if !pub.VerifySignature(TEST_MESSAGE_1, sign) {
+		t.Errorf("test failed: verify with new pub key")
+		return
+	}
+}
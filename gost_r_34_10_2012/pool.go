@@ -0,0 +1,98 @@
+package gost_r_34_10_2012
+
+import (
+	"fmt"
+	"sync"
+)
+
+/*
+ * HANDLE POOL
+ */
+
+// Provider and key handles used by one goroutine at a time:
+// backend.Session or backend.Verifier.
+type handle interface {
+	Close()
+}
+
+// Pool of at most size handles opened on demand.
+type handlePool struct {
+	mtx    sync.Mutex
+	closed bool
+	idle   chan handle
+	slots  chan struct{}
+	open   func() (handle, error)
+}
+
+func newHandlePool(size int, open func() (handle, error)) *handlePool {
+	return &handlePool{
+		idle:  make(chan handle, size),
+		slots: make(chan struct{}, size),
+		open:  open,
+	}
+}
+
+// Idle handle or a new one if the number of handles is less than size.
+func (p *handlePool) get() (handle, error) {
+	if p.isClosed() {
+		return nil, fmt.Errorf("error: handles are closed")
+	}
+	select {
+	case h := <-p.idle:
+		return h, nil
+	default:
+	}
+	select {
+	case h := <-p.idle:
+		return h, nil
+	case p.slots <- struct{}{}:
+		h, err := p.open()
+		if err != nil {
+			<-p.slots
+			return nil, err
+		}
+		if p.isClosed() {
+			p.put(h)
+			return nil, fmt.Errorf("error: handles are closed")
+		}
+		return h, nil
+	}
+}
+
+// Returning of the handle to the pool or closing it after close.
+func (p *handlePool) put(h handle) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	if p.closed {
+		h.Close()
+		<-p.slots
+		return
+	}
+	p.idle <- h
+}
+
+// Closing of the idle handles, the used handles are closed by put.
+// The result is false if the pool is already closed.
+func (p *handlePool) close() bool {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	if p.closed {
+		return false
+	}
+	p.closed = true
+	for {
+		select {
+		case h := <-p.idle:
+			h.Close()
+			<-p.slots
+		default:
+			return true
+		}
+	}
+}
+
+func (p *handlePool) isClosed() bool {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	return p.closed
+}
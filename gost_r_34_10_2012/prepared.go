@@ -0,0 +1,118 @@
+package gost_r_34_10_2012
+
+import (
+	"fmt"
+	"runtime"
+
+	ghash "github.com/towleeee/go-cryptopro/gost_r_34_11_2012"
+)
+
+var (
+	_ PubKey = &PreparedPubKey{}
+)
+
+/*
+ * PREPARED PUBLIC KEY
+ */
+
+// Public key imported once for many verifications.
+// The imported key handles are pooled: every handle is used
+// by one goroutine at a time, at most size handles are imported.
+// The key is safe for concurrent use and must be closed by Close,
+// the forgotten key is closed by the finalizer.
+// Other methods of PubKey are the methods of the source key.
+type PreparedPubKey struct {
+	PubKey
+	key  PubKey256
+	pool *handlePool
+}
+
+// Create PreparedPubKey from PubKey256 or PubKey512.
+// The number of key handles is runtime.GOMAXPROCS(0) if size <= 0.
+func NewPreparedPubKey(pub PubKey, size int) (*PreparedPubKey, error) {
+	var key PubKey256
+	switch x := pub.(type) {
+	case *PreparedPubKey:
+		return NewPreparedPubKey(x.PubKey, size)
+	case PubKey256:
+		key = x
+	case PubKey512:
+		key = PubKey256(x)
+	default:
+		return nil, fmt.Errorf("error: unsupported public key")
+	}
+	switch key.prov() {
+	case K256, K512:
+		// pass
+	default:
+		return nil, fmt.Errorf("error: read prov type")
+	}
+	if size <= 0 {
+		size = runtime.GOMAXPROCS(0)
+	}
+
+	p := &PreparedPubKey{
+		PubKey: pub,
+		key:    key,
+		pool:   newHandlePool(size, importPubKey(key)),
+	}
+
+	// The first import checks the key.
+	h, err := p.pool.get()
+	if err != nil {
+		return nil, err
+	}
+	p.pool.put(h)
+
+	runtime.SetFinalizer(p, (*PreparedPubKey).Close)
+	return p, nil
+}
+
+// Signature confirmation using the original data.
+// The signature is in the format (FormatCryptoAPI if omitted).
+func (p *PreparedPubKey) VerifySignature(dbytes, sign []byte, format ...SignatureFormat) bool {
+	return p.VerifySignatureE(dbytes, sign, format...) == nil
+}
+
+// Signature confirmation using the original data,
+// ErrBadSignature if the signature is incorrect.
+func (p *PreparedPubKey) VerifySignatureE(dbytes, sign []byte, format ...SignatureFormat) error {
+	return p.verify("verify signature", dbytes, sign, false, format)
+}
+
+// Signature confirmation using a ready hash value
+// GOST R 34.11-2012 (32 bytes for K256, 64 bytes for K512).
+func (p *PreparedPubKey) VerifyDigest(digest, sign []byte, format ...SignatureFormat) bool {
+	return p.VerifyDigestE(digest, sign, format...) == nil
+}
+
+// Signature confirmation using a ready hash value,
+// ErrBadSignature if the signature is incorrect.
+func (p *PreparedPubKey) VerifyDigestE(digest, sign []byte, format ...SignatureFormat) error {
+	if len(digest) != ghash.ProvType(p.key.prov()).Size() {
+		return fmt.Errorf("error: length of digest")
+	}
+	return p.verify("verify digest", digest, sign, true, format)
+}
+
+// Closing of the key handles.
+// Handles used by verification at the moment are closed after it.
+func (p *PreparedPubKey) Close() error {
+	if p.pool.close() {
+		runtime.SetFinalizer(p, nil)
+	}
+	return nil
+}
+
+func (p *PreparedPubKey) verify(op string, data, sign []byte, digest bool, format []SignatureFormat) error {
+	sign, err := decodeSignature(p.key.prov(), sign, format)
+	if err != nil {
+		return err
+	}
+	h, err := p.pool.get()
+	if err != nil {
+		return err
+	}
+	defer p.pool.put(h)
+	return verifyHandle(op, h, data, sign, digest)
+}
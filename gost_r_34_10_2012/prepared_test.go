@@ -0,0 +1,174 @@
+// go test -v -bench=. -benchtime=100x
+package gost_r_34_10_2012
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	ghash "github.com/towleeee/go-cryptopro/gost_r_34_11_2012"
+)
+
+func TestPreparedPubKey(t *testing.T) {
+	testPreparedPubKey(t, PRIVATE_KEY, PUBLIC_KEY, ghash.H256)
+}
+
+func TestPreparedPubKey512(t *testing.T) {
+	testPreparedPubKey(t, PRIVATE_KEY_512, PUBLIC_KEY_512, ghash.H512)
+}
+
+func testPreparedPubKey(t *testing.T, priv PrivKey, pub PubKey, hprov ghash.ProvType) {
+	prepared, err := NewPreparedPubKey(pub, 2)
+	if err != nil {
+		t.Errorf("test failed: new prepared pub key: %v", err)
+		return
+	}
+	defer prepared.Close()
+
+	if !prepared.Equals(pub) || !pub.Equals(prepared) {
+		t.Errorf("test failed: equals")
+		return
+	}
+
+	sign, err := priv.Sign(TEST_MESSAGE_1, AT_SIGNATURE)
+	if err != nil {
+		t.Errorf("test failed: sign")
+		return
+	}
+
+	if !prepared.VerifySignature(TEST_MESSAGE_1, sign) {
+		t.Errorf("test failed: verify (1)")
+		return
+	}
+
+	if !prepared.VerifyDigest(ghash.Sum(hprov, TEST_MESSAGE_1), sign) {
+		t.Errorf("test failed: verify digest")
+		return
+	}
+
+	err = prepared.VerifySignatureE(TEST_MESSAGE_2, sign)
+	if !errors.Is(err, ErrBadSignature) {
+		t.Errorf("test failed: verify (2): %v", err)
+		return
+	}
+
+	var (
+		wg  sync.WaitGroup
+		res = make(chan bool, 16)
+	)
+	for i := 0; i < cap(res); i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			msg := TEST_MESSAGE_1
+			if i%2 == 1 {
+				msg = TEST_MESSAGE_2
+			}
+			res <- prepared.VerifySignature(msg, sign) == (i%2 == 0)
+		}(i)
+	}
+	wg.Wait()
+	close(res)
+	for ok := range res {
+		if !ok {
+			t.Errorf("test failed: concurrent verify")
+			return
+		}
+	}
+
+	batch := NewBatchVerifier()
+	_ = batch.Add(prepared, TEST_MESSAGE_1, sign)
+	if ok, _ := batch.Verify(); !ok {
+		t.Errorf("test failed: batch verifier")
+		return
+	}
+
+	if err = prepared.Close(); err != nil {
+		t.Errorf("test failed: close: %v", err)
+		return
+	}
+	if prepared.VerifySignature(TEST_MESSAGE_1, sign) {
+		t.Errorf("test failed: verify after close")
+		return
+	}
+}
+
+func BenchmarkVerify(b *testing.B) {
+	sign, err := PRIVATE_KEY.Sign(TEST_MESSAGE_1, AT_SIGNATURE)
+	if err != nil {
+		b.Errorf("benchmark failed: sign")
+		return
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if !PUBLIC_KEY.VerifySignature(TEST_MESSAGE_1, sign) {
+			b.Errorf("benchmark failed: verify")
+			break
+		}
+	}
+}
+
+func BenchmarkPreparedVerify(b *testing.B) {
+	sign, err := PRIVATE_KEY.Sign(TEST_MESSAGE_1, AT_SIGNATURE)
+	if err != nil {
+		b.Errorf("benchmark failed: sign")
+		return
+	}
+	prepared, err := NewPreparedPubKey(PUBLIC_KEY, 1)
+	if err != nil {
+		b.Errorf("benchmark failed: new prepared pub key")
+		return
+	}
+	defer prepared.Close()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if !prepared.VerifySignature(TEST_MESSAGE_1, sign) {
+			b.Errorf("benchmark failed: verify")
+			break
+		}
+	}
+}
+
+func BenchmarkVerifyParallel(b *testing.B) {
+	sign, err := PRIVATE_KEY.Sign(TEST_MESSAGE_1, AT_SIGNATURE)
+	if err != nil {
+		b.Errorf("benchmark failed: sign")
+		return
+	}
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if !PUBLIC_KEY.VerifySignature(TEST_MESSAGE_1, sign) {
+				b.Errorf("benchmark failed: verify")
+				break
+			}
+		}
+	})
+}
+
+func BenchmarkPreparedVerifyParallel(b *testing.B) {
+	sign, err := PRIVATE_KEY.Sign(TEST_MESSAGE_1, AT_SIGNATURE)
+	if err != nil {
+		b.Errorf("benchmark failed: sign")
+		return
+	}
+	prepared, err := NewPreparedPubKey(PUBLIC_KEY, 0)
+	if err != nil {
+		b.Errorf("benchmark failed: new prepared pub key")
+		return
+	}
+	defer prepared.Close()
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if !prepared.VerifySignature(TEST_MESSAGE_1, sign) {
+				b.Errorf("benchmark failed: verify")
+				break
+			}
+		}
+	})
+}
@@ -0,0 +1,157 @@
+package gost_r_34_10_2012
+
+import (
+	"bytes"
+	"fmt"
+	"runtime"
+
+	ghash "github.com/towleeee/go-cryptopro/gost_r_34_11_2012"
+	"github.com/towleeee/go-cryptopro/internal/backend"
+)
+
+/*
+ * SIGNING SESSION
+ */
+
+// Container opened once for many signatures.
+// The provider handles are pooled: every handle is used
+// by one goroutine at a time, at most size handles are opened.
+// The session is safe for concurrent use and must be closed by Close,
+// the forgotten session is closed by the finalizer.
+type SigningSession struct {
+	priv PrivKey256
+	spec KeySpec
+	pub  PubKey
+	pool *handlePool
+}
+
+// Create SigningSession from PrivContainer, PrivKey256 or PrivKey512.
+// PrivContainer uses its own KeySpec, other keys use AT_SIGNATURE.
+// The number of provider handles is runtime.GOMAXPROCS(0) if size <= 0.
+func NewSigningSession(priv PrivKey, size int) (*SigningSession, error) {
+	if x, ok := priv.(PrivContainer); ok {
+		return NewSigningSessionSpec(x.PrivKey, x.KeySpec, size)
+	}
+	return NewSigningSessionSpec(priv, AT_SIGNATURE, size)
+}
+
+// Create SigningSession with an explicit key pair of the container.
+func NewSigningSessionSpec(priv PrivKey, spec KeySpec, size int) (*SigningSession, error) {
+	var key PrivKey256
+	switch x := priv.(type) {
+	case PrivContainer:
+		return NewSigningSessionSpec(x.PrivKey, spec, size)
+	case PrivKey256:
+		key = x
+	case PrivKey512:
+		key = PrivKey256(x)
+	default:
+		return nil, fmt.Errorf("error: unsupported private key")
+	}
+	switch key.prov() {
+	case K256, K512:
+		// pass
+	default:
+		return nil, fmt.Errorf("error: read prov type")
+	}
+	if size <= 0 {
+		size = runtime.GOMAXPROCS(0)
+	}
+
+	s := &SigningSession{
+		priv: key,
+		spec: spec,
+	}
+	// The pool does not refer to the session for the finalizer.
+	s.pool = newHandlePool(size, openSession(key, spec))
+
+	// The first handle checks the password
+	// and gives the public key.
+	h, err := s.pool.get()
+	if err != nil {
+		return nil, err
+	}
+	s.pub, err = s.pubKey(h)
+	s.pool.put(h)
+	if err != nil {
+		s.pool.close()
+		return nil, err
+	}
+
+	runtime.SetFinalizer(s, (*SigningSession).Close)
+	return s, nil
+}
+
+// Public key of the session key pair.
+func (s *SigningSession) PubKey() PubKey {
+	return s.pub
+}
+
+// Signing information, the same as PrivKey.Sign.
+func (s *SigningSession) Sign(dbytes []byte, format ...SignatureFormat) ([]byte, error) {
+	return s.sign("sign", dbytes, false, format)
+}
+
+// Signing a ready hash value GOST R 34.11-2012
+// (32 bytes for K256, 64 bytes for K512), the same as PrivKey.SignDigest.
+func (s *SigningSession) SignDigest(digest []byte, format ...SignatureFormat) ([]byte, error) {
+	if len(digest) != ghash.ProvType(s.priv.prov()).Size() {
+		return nil, fmt.Errorf("error: length of digest")
+	}
+	return s.sign("sign digest", digest, true, format)
+}
+
+// Closing of the provider handles.
+// Handles used by Sign at the moment are closed after the signing.
+func (s *SigningSession) Close() error {
+	if s.pool.close() {
+		runtime.SetFinalizer(s, nil)
+	}
+	return nil
+}
+
+func (s *SigningSession) sign(op string, data []byte, digest bool, format []SignatureFormat) ([]byte, error) {
+	if _, err := signatureFormat(format); err != nil {
+		return nil, err
+	}
+	h, err := s.pool.get()
+	if err != nil {
+		return nil, err
+	}
+	defer s.pool.put(h)
+
+	sign, err := h.(backend.Session).Sign(op, data, digest)
+	if err != nil {
+		return nil, err
+	}
+	return encodeSignature(s.priv.prov(), sign, format)
+}
+
+// Opening of the container with the password.
+func openSession(key PrivKey256, spec KeySpec) func() (handle, error) {
+	return func() (handle, error) {
+		return provider.OpenSession(
+			"open session",
+			byte(key.prov()),
+			key.container(),
+			key.password(),
+			uint32(spec),
+		)
+	}
+}
+
+func (s *SigningSession) pubKey(h handle) (PubKey, error) {
+	pbytes, err := h.(backend.Session).PubKey("pub key")
+	if err != nil {
+		return nil, err
+	}
+
+	pubraw := bytes.Join(
+		[][]byte{
+			[]byte{byte(s.priv.prov())},
+			pbytes,
+		},
+		[]byte{},
+	)
+	return LoadPubKey(pubraw)
+}
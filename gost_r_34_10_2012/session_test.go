@@ -0,0 +1,154 @@
+// go test -v -bench=. -benchtime=100x
+package gost_r_34_10_2012
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	ghash "github.com/towleeee/go-cryptopro/gost_r_34_11_2012"
+)
+
+func TestSigningSession(t *testing.T) {
+	testSigningSession(t, PRIVATE_KEY, PUBLIC_KEY, ghash.H256)
+}
+
+func TestSigningSession512(t *testing.T) {
+	testSigningSession(t, PRIVATE_KEY_512, PUBLIC_KEY_512, ghash.H512)
+}
+
+func testSigningSession(t *testing.T, priv PrivKey, pub PubKey, hprov ghash.ProvType) {
+	session, err := NewSigningSession(priv, 2)
+	if err != nil {
+		t.Errorf("test failed: new signing session: %v", err)
+		return
+	}
+	defer session.Close()
+
+	if !session.PubKey().Equals(pub) {
+		t.Errorf("test failed: pub key")
+		return
+	}
+
+	sign, err := session.Sign(TEST_MESSAGE_1)
+	if err != nil || !pub.VerifySignature(TEST_MESSAGE_1, sign) {
+		t.Errorf("test failed: sign: %v", err)
+		return
+	}
+
+	sign, err = session.SignDigest(ghash.Sum(hprov, TEST_MESSAGE_2))
+	if err != nil || !pub.VerifySignature(TEST_MESSAGE_2, sign) {
+		t.Errorf("test failed: sign digest: %v", err)
+		return
+	}
+
+	var (
+		wg   sync.WaitGroup
+		errs = make(chan error, 16)
+	)
+	for i := 0; i < cap(errs); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sign, err := session.Sign(TEST_MESSAGE_3)
+			if err == nil && !pub.VerifySignature(TEST_MESSAGE_3, sign) {
+				err = errors.New("verify")
+			}
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Errorf("test failed: concurrent sign: %v", err)
+			return
+		}
+	}
+
+	if err = session.Close(); err != nil {
+		t.Errorf("test failed: close: %v", err)
+		return
+	}
+	if _, err = session.Sign(TEST_MESSAGE_1); err == nil {
+		t.Errorf("test failed: sign after close")
+		return
+	}
+}
+
+func TestSigningSessionPassword(t *testing.T) {
+	priv, err := NewPrivKey(NewConfig(K256, TEST_SUBJECT, TEST_PASSWORD))
+	if err != nil {
+		t.Errorf("test failed: new priv key")
+		return
+	}
+	wrong := NewConfig(K256, TEST_SUBJECT, "wrong password")
+	key := append(PrivKey256{}, priv.Bytes()...)
+	copy(key[ContainerLen+1:], wrong.password)
+
+	_, err = NewSigningSession(key, 1)
+	if !errors.Is(err, ErrWrongPassword) {
+		t.Errorf("test failed: wrong password: %v", err)
+		return
+	}
+}
+
+func BenchmarkSign(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, err := PRIVATE_KEY.Sign(TEST_MESSAGE_1, AT_SIGNATURE)
+		if err != nil {
+			b.Errorf("benchmark failed: sign")
+			break
+		}
+	}
+}
+
+func BenchmarkSigningSession(b *testing.B) {
+	session, err := NewSigningSession(PRIVATE_KEY, 1)
+	if err != nil {
+		b.Errorf("benchmark failed: new signing session")
+		return
+	}
+	defer session.Close()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := session.Sign(TEST_MESSAGE_1)
+		if err != nil {
+			b.Errorf("benchmark failed: sign")
+			break
+		}
+	}
+}
+
+func BenchmarkSignParallel(b *testing.B) {
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_, err := PRIVATE_KEY.Sign(TEST_MESSAGE_1, AT_SIGNATURE)
+			if err != nil {
+				b.Errorf("benchmark failed: sign")
+				break
+			}
+		}
+	})
+}
+
+func BenchmarkSigningSessionParallel(b *testing.B) {
+	session, err := NewSigningSession(PRIVATE_KEY, 0)
+	if err != nil {
+		b.Errorf("benchmark failed: new signing session")
+		return
+	}
+	defer session.Close()
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_, err := session.Sign(TEST_MESSAGE_1)
+			if err != nil {
+				b.Errorf("benchmark failed: sign")
+				break
+			}
+		}
+	})
+}
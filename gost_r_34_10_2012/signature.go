@@ -0,0 +1,156 @@
+package gost_r_34_10_2012
+
+import (
+	"encoding/asn1"
+	"fmt"
+	"math/big"
+
+	"github.com/towleeee/go-cryptopro/internal/curves"
+)
+
+/*
+ * SIGNATURE FORMAT
+ */
+
+// Encoding of the signature (r, s) of GOST R 34.10-2012.
+type SignatureFormat int
+
+const (
+	// Signature of CryptoAPI: little-endian r || little-endian s,
+	// the reversed form of FormatRFC4491.
+	FormatCryptoAPI SignatureFormat = iota
+	// Signature of RFC 4491, RFC 9215 (X.509, CMS, OpenSSL, gogost):
+	// big-endian s || r.
+	FormatRFC4491
+	// DER of SEQUENCE { r INTEGER, s INTEGER }.
+	FormatASN1
+)
+
+type asn1Signature struct {
+	R, S *big.Int
+}
+
+func (f SignatureFormat) String() string {
+	switch f {
+	case FormatCryptoAPI:
+		return "CryptoAPI"
+	case FormatRFC4491:
+		return "RFC 4491"
+	case FormatASN1:
+		return "ASN.1"
+	default:
+		return "???"
+	}
+}
+
+// Conversion of the signature of the key type prov
+// from one format to another.
+func ConvertSignature(prov ProvType, sign []byte, from, to SignatureFormat) ([]byte, error) {
+	size := prov.SignatureSize()
+	if size < 0 {
+		return nil, fmt.Errorf("error: read prov type")
+	}
+
+	r, s, err := decodeRS(size, sign, from)
+	if err != nil {
+		return nil, err
+	}
+	return encodeRS(size, r, s, to)
+}
+
+// Values r and s of the signature.
+func decodeRS(size int, sign []byte, format SignatureFormat) (r, s *big.Int, err error) {
+	half := size / 2
+	switch format {
+	case FormatCryptoAPI:
+		if len(sign) != size {
+			return nil, nil, fmt.Errorf("error: length of signature")
+		}
+		r = curves.FromLE(sign[:half])
+		s = curves.FromLE(sign[half:])
+	case FormatRFC4491:
+		if len(sign) != size {
+			return nil, nil, fmt.Errorf("error: length of signature")
+		}
+		s = new(big.Int).SetBytes(sign[:half])
+		r = new(big.Int).SetBytes(sign[half:])
+	case FormatASN1:
+		var v asn1Signature
+		rest, err := asn1.Unmarshal(sign, &v)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error: parse signature: %w", err)
+		}
+		if len(rest) != 0 {
+			return nil, nil, fmt.Errorf("error: trailing data after signature")
+		}
+		if v.R.Sign() < 0 || v.S.Sign() < 0 || v.R.BitLen() > 8*half || v.S.BitLen() > 8*half {
+			return nil, nil, fmt.Errorf("error: signature values")
+		}
+		r, s = v.R, v.S
+	default:
+		return nil, nil, fmt.Errorf("error: signature format %d", format)
+	}
+	return r, s, nil
+}
+
+func encodeRS(size int, r, s *big.Int, format SignatureFormat) ([]byte, error) {
+	half := size / 2
+	switch format {
+	case FormatCryptoAPI:
+		sign := make([]byte, size)
+		curves.FillLE(r, sign[:half])
+		curves.FillLE(s, sign[half:])
+		return sign, nil
+	case FormatRFC4491:
+		sign := make([]byte, size)
+		s.FillBytes(sign[:half])
+		r.FillBytes(sign[half:])
+		return sign, nil
+	case FormatASN1:
+		return asn1.Marshal(asn1Signature{R: r, S: s})
+	default:
+		return nil, fmt.Errorf("error: signature format %d", format)
+	}
+}
+
+// Format of the optional argument of Sign and Verify,
+// FormatCryptoAPI if it is omitted.
+func signatureFormat(format []SignatureFormat) (SignatureFormat, error) {
+	switch len(format) {
+	case 0:
+		return FormatCryptoAPI, nil
+	case 1:
+		switch format[0] {
+		case FormatCryptoAPI, FormatRFC4491, FormatASN1:
+			return format[0], nil
+		default:
+			return 0, fmt.Errorf("error: signature format %d", format[0])
+		}
+	default:
+		return 0, fmt.Errorf("error: several signature formats")
+	}
+}
+
+// Signature of CryptoAPI in the chosen format.
+func encodeSignature(prov ProvType, sign []byte, format []SignatureFormat) ([]byte, error) {
+	f, err := signatureFormat(format)
+	if err != nil {
+		return nil, err
+	}
+	if f == FormatCryptoAPI {
+		return sign, nil
+	}
+	return ConvertSignature(prov, sign, FormatCryptoAPI, f)
+}
+
+// Signature in the chosen format to the form of CryptoAPI.
+func decodeSignature(prov ProvType, sign []byte, format []SignatureFormat) ([]byte, error) {
+	f, err := signatureFormat(format)
+	if err != nil {
+		return nil, err
+	}
+	if f == FormatCryptoAPI {
+		return sign, nil
+	}
+	return ConvertSignature(prov, sign, f, FormatCryptoAPI)
+}
@@ -0,0 +1,132 @@
+// go test -v -bench=. -benchtime=100x
+package gost_r_34_10_2012
+
+import (
+	"bytes"
+	"encoding/asn1"
+	"math/big"
+	"testing"
+
+	ghash "github.com/towleeee/go-cryptopro/gost_r_34_11_2012"
+	"github.com/towleeee/go-cryptopro/internal/curves"
+)
+
+func TestConvertSignature(t *testing.T) {
+	for _, prov := range []ProvType{K256, K512} {
+		size := prov.SignatureSize()
+
+		// r = 0x01..., s = 0x80...
+		sign := make([]byte, size)
+		for i := range sign {
+			sign[i] = byte(i + 1)
+		}
+		sign[size/2-1] = 0x01
+		sign[size-1] = 0x80
+
+		rfc, err := ConvertSignature(prov, sign, FormatCryptoAPI, FormatRFC4491)
+		if err != nil {
+			t.Errorf("test failed: convert to rfc 4491: %v", err)
+			return
+		}
+		for i := range rfc {
+			if rfc[i] != sign[size-1-i] {
+				t.Errorf("test failed: rfc 4491 != reversed signature")
+				return
+			}
+		}
+
+		der, err := ConvertSignature(prov, rfc, FormatRFC4491, FormatASN1)
+		if err != nil {
+			t.Errorf("test failed: convert to asn.1: %v", err)
+			return
+		}
+		var v asn1Signature
+		if _, err := asn1.Unmarshal(der, &v); err != nil {
+			t.Errorf("test failed: parse asn.1: %v", err)
+			return
+		}
+		if v.R.Cmp(curves.FromLE(sign[:size/2])) != 0 ||
+			v.S.Cmp(curves.FromLE(sign[size/2:])) != 0 {
+			t.Errorf("test failed: r or s of asn.1")
+			return
+		}
+
+		back, err := ConvertSignature(prov, der, FormatASN1, FormatCryptoAPI)
+		if err != nil {
+			t.Errorf("test failed: convert to cryptoapi: %v", err)
+			return
+		}
+		if !bytes.Equal(back, sign) {
+			t.Errorf("test failed: converted != original")
+			return
+		}
+
+		if _, err := ConvertSignature(prov, sign[1:], FormatCryptoAPI, FormatRFC4491); err == nil {
+			t.Errorf("test failed: convert short signature")
+			return
+		}
+		if _, err := ConvertSignature(prov, append(der, 0), FormatASN1, FormatRFC4491); err == nil {
+			t.Errorf("test failed: convert trailing data")
+			return
+		}
+	}
+
+	// The values of 512 bit signature do not fit 256 bit signature.
+	der, _ := asn1.Marshal(asn1Signature{R: new(big.Int).Lsh(big.NewInt(1), 300), S: big.NewInt(1)})
+	if _, err := ConvertSignature(K256, der, FormatASN1, FormatCryptoAPI); err == nil {
+		t.Errorf("test failed: convert big values")
+		return
+	}
+}
+
+func TestSignatureFormat(t *testing.T) {
+	for _, v := range []struct {
+		priv  PrivKey
+		pub   PubKey
+		hprov ghash.ProvType
+	}{
+		{PRIVATE_KEY, PUBLIC_KEY, ghash.H256},
+		{PRIVATE_KEY_512, PUBLIC_KEY_512, ghash.H512},
+	} {
+		for _, format := range []SignatureFormat{FormatCryptoAPI, FormatRFC4491, FormatASN1} {
+			sign, err := v.priv.Sign(TEST_MESSAGE_1, AT_SIGNATURE, format)
+			if err != nil {
+				t.Errorf("test failed: sign (%s): %v", format, err)
+				return
+			}
+			if err := v.pub.VerifySignatureE(TEST_MESSAGE_1, sign, format); err != nil {
+				t.Errorf("test failed: verify (%s): %v", format, err)
+				return
+			}
+			if v.pub.VerifySignature(TEST_MESSAGE_2, sign, format) {
+				t.Errorf("test failed: verify other message (%s)", format)
+				return
+			}
+
+			sign, err = v.priv.SignDigest(ghash.Sum(v.hprov, TEST_MESSAGE_1), AT_SIGNATURE, format)
+			if err != nil {
+				t.Errorf("test failed: sign digest (%s): %v", format, err)
+				return
+			}
+			if !v.pub.VerifyDigest(ghash.Sum(v.hprov, TEST_MESSAGE_1), sign, format) {
+				t.Errorf("test failed: verify digest (%s)", format)
+				return
+			}
+
+			native, err := ConvertSignature(ProvType(v.pub.Bytes()[0]), sign, format, FormatCryptoAPI)
+			if err != nil || !v.pub.VerifyDigest(ghash.Sum(v.hprov, TEST_MESSAGE_1), native) {
+				t.Errorf("test failed: verify converted (%s)", format)
+				return
+			}
+		}
+	}
+
+	if _, err := PRIVATE_KEY.Sign(TEST_MESSAGE_1, AT_SIGNATURE, FormatRFC4491, FormatASN1); err == nil {
+		t.Errorf("test failed: several formats")
+		return
+	}
+	if _, err := PRIVATE_KEY.Sign(TEST_MESSAGE_1, AT_SIGNATURE, SignatureFormat(10)); err == nil {
+		t.Errorf("test failed: unknown format")
+		return
+	}
+}
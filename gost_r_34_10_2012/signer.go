@@ -0,0 +1,83 @@
+package gost_r_34_10_2012
+
+import (
+	"crypto"
+	"fmt"
+	"io"
+)
+
+var (
+	_ crypto.Signer = &Signer{}
+)
+
+/*
+ * SIGNER
+ */
+
+// Adapter of the container key to the crypto.Signer interface.
+// The signature is returned in the CryptoAPI byte order,
+// the same as PrivKey.Sign: little-endian r || little-endian s
+// (the reversed big-endian s || r of RFC 4491).
+type Signer struct {
+	priv PrivKey256
+	spec KeySpec
+	pub  PubKey
+}
+
+// Create Signer from PrivContainer, PrivKey256 or PrivKey512.
+// PrivContainer uses its own KeySpec, other keys use AT_SIGNATURE.
+func NewSigner(priv PrivKey) (*Signer, error) {
+	if x, ok := priv.(PrivContainer); ok {
+		return NewSignerSpec(x.PrivKey, x.KeySpec)
+	}
+	return NewSignerSpec(priv, AT_SIGNATURE)
+}
+
+// Create Signer with an explicit key pair of the container.
+func NewSignerSpec(priv PrivKey, spec KeySpec) (*Signer, error) {
+	switch x := priv.(type) {
+	case PrivContainer:
+		return NewSignerSpec(x.PrivKey, spec)
+	case PrivKey256:
+		return newSigner(x, spec)
+	case PrivKey512:
+		return newSigner(PrivKey256(x), spec)
+	default:
+		return nil, fmt.Errorf("error: unsupported private key")
+	}
+}
+
+func newSigner(key PrivKey256, spec KeySpec) (*Signer, error) {
+	switch key.prov() {
+	case K256, K512:
+		// pass
+	default:
+		return nil, fmt.Errorf("error: read prov type")
+	}
+	pub, err := key.PubKeyE(spec)
+	if err != nil {
+		return nil, err
+	}
+	return &Signer{
+		priv: key,
+		spec: spec,
+		pub:  pub,
+	}, nil
+}
+
+// Public key of the container (PubKey256 or PubKey512).
+func (s *Signer) Public() crypto.PublicKey {
+	return s.pub
+}
+
+// Signing of the precomputed hash GOST R 34.11-2012
+// (32 bytes for K256, 64 bytes for K512).
+// Streebog is not registered in the crypto package,
+// so opts must be nil or opts.HashFunc() must be zero.
+// The rand argument is ignored: CSP uses its own generator.
+func (s *Signer) Sign(rand io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	if opts != nil && opts.HashFunc() != 0 {
+		return nil, fmt.Errorf("error: unsupported hash function %v", opts.HashFunc())
+	}
+	return s.priv.SignDigest(digest, s.spec)
+}
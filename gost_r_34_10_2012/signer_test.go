@@ -0,0 +1,75 @@
+// go test -v -bench=. -benchtime=100x
+package gost_r_34_10_2012
+
+import (
+	"crypto"
+	"testing"
+
+	ghash "github.com/towleeee/go-cryptopro/gost_r_34_11_2012"
+)
+
+func TestSigner(t *testing.T) {
+	testSigner(t, PRIVATE_KEY, PUBLIC_KEY, ghash.H256)
+}
+
+func TestSigner512(t *testing.T) {
+	testSigner(t, PRIVATE_KEY_512, PUBLIC_KEY_512, ghash.H512)
+}
+
+func testSigner(t *testing.T, priv PrivKey, pub PubKey, hprov ghash.ProvType) {
+	signer, err := NewSigner(priv)
+	if err != nil {
+		t.Errorf("test failed: new signer")
+		return
+	}
+
+	if !signer.Public().(PubKey).Equals(pub) {
+		t.Errorf("test failed: signer public key")
+		return
+	}
+
+	digest := ghash.Sum(hprov, TEST_MESSAGE_1)
+	sign, err := signer.Sign(nil, digest, nil)
+	if err != nil {
+		t.Errorf("test failed: signer sign")
+		return
+	}
+
+	if !pub.VerifySignature(TEST_MESSAGE_1, sign) {
+		t.Errorf("test failed: verify (1)")
+		return
+	}
+
+	if pub.VerifySignature(TEST_MESSAGE_2, sign) {
+		t.Errorf("test failed: verify (2)")
+		return
+	}
+
+	_, err = signer.Sign(nil, digest[1:], nil)
+	if err == nil {
+		t.Errorf("test failed: sign digest with wrong length")
+		return
+	}
+
+	_, err = signer.Sign(nil, digest, crypto.SHA256)
+	if err == nil {
+		t.Errorf("test failed: sign with unsupported hash")
+		return
+	}
+}
+
+func BenchmarkSigner(b *testing.B) {
+	signer, err := NewSigner(PRIVATE_KEY)
+	if err != nil {
+		b.Errorf("benchmark failed: new signer")
+		return
+	}
+	digest := ghash.Sum(ghash.H256, TEST_MESSAGE_1)
+	for i := 0; i < b.N; i++ {
+		_, err := signer.Sign(nil, digest, nil)
+		if err != nil {
+			b.Errorf("benchmark failed: sign")
+			break
+		}
+	}
+}
@@ -0,0 +1,81 @@
+// go test -v -bench=. -benchtime=100x
+package gost_r_34_10_2012
+
+import (
+	"errors"
+	"testing"
+
+	gverify "github.com/towleeee/go-cryptopro/gost_r_34_10_2012_verify"
+	ghash "github.com/towleeee/go-cryptopro/gost_r_34_11_2012"
+)
+
+// Signatures of CryptoPro CSP are checked by the software verifier.
+func TestSoftwareVerify(t *testing.T) {
+	testSoftwareVerify(t, PRIVATE_KEY, PUBLIC_KEY, ghash.H256)
+}
+
+func TestSoftwareVerify512(t *testing.T) {
+	testSoftwareVerify(t, PRIVATE_KEY_512, PUBLIC_KEY_512, ghash.H512)
+}
+
+func testSoftwareVerify(t *testing.T, priv PrivKey, pub PubKey, hprov ghash.ProvType) {
+	soft, err := gverify.LoadPubKey(pub.Bytes())
+	if err != nil {
+		t.Errorf("test failed: load pub key: %v", err)
+		return
+	}
+
+	for _, msg := range [][]byte{TEST_MESSAGE_1, TEST_MESSAGE_3} {
+		sign, err := priv.Sign(msg, AT_SIGNATURE)
+		if err != nil {
+			t.Errorf("test failed: sign")
+			return
+		}
+
+		if !soft.VerifySignature(msg, sign) {
+			t.Errorf("test failed: verify")
+			return
+		}
+
+		if !soft.VerifyDigest(ghash.Sum(hprov, msg), sign) {
+			t.Errorf("test failed: verify digest")
+			return
+		}
+
+		err = soft.VerifySignatureE(TEST_MESSAGE_2, sign)
+		if !errors.Is(err, ErrBadSignature) {
+			t.Errorf("test failed: verify other message: %v", err)
+			return
+		}
+	}
+
+	// Signature of the ready hash value of CryptoPro CSP.
+	sign, err := priv.SignDigest(ghash.Sum(hprov, TEST_MESSAGE_2), AT_SIGNATURE)
+	if err != nil {
+		t.Errorf("test failed: sign digest")
+		return
+	}
+	if !soft.VerifySignature(TEST_MESSAGE_2, sign) || !pub.VerifySignature(TEST_MESSAGE_2, sign) {
+		t.Errorf("test failed: verify signature of digest")
+		return
+	}
+}
+
+func BenchmarkSoftwareVerify(b *testing.B) {
+	soft, err := gverify.LoadPubKey(PUBLIC_KEY.Bytes())
+	if err != nil {
+		b.Errorf("benchmark failed: load pub key")
+		return
+	}
+	sign, err := PRIVATE_KEY.Sign(TEST_MESSAGE_1, AT_SIGNATURE)
+	if err != nil {
+		b.Errorf("benchmark failed: sign")
+		return
+	}
+	for i := 0; i < b.N; i++ {
+		if !soft.VerifySignature(TEST_MESSAGE_1, sign) {
+			b.Errorf("benchmark failed: verify")
+			break
+		}
+	}
+}
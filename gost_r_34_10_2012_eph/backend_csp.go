@@ -0,0 +1,135 @@
+//go:build cgo && !purego
+// +build cgo,!purego
+
+package gost_r_34_10_2012_eph
+
+/*
+#cgo linux,amd64 CFLAGS: -I/opt/cprocsp/include/cpcsp -DUNIX -DLINUX -DSIZEOF_VOID_P=8
+#cgo linux,386 CFLAGS: -I/opt/cprocsp/include/cpcsp -DUNIX -DLINUX -DSIZEOF_VOID_P=4
+#cgo linux,amd64 LDFLAGS: -L/opt/cprocsp/lib/amd64/ -lcapi10 -lcapi20 -lrdrsup -lssp
+#cgo linux,386 LDFLAGS: -L/opt/cprocsp/lib/ia32/ -lcapi10 -lcapi20 -lrdrsup -lssp
+#cgo windows CFLAGS: -I/opt/cprocsp/include/cpcsp
+#cgo windows LDFLAGS: -lcrypt32 -lpthread
+
+#include "gost.h"
+*/
+import "C"
+import (
+	"unsafe"
+
+	"github.com/towleeee/go-cryptopro/internal/backend"
+	"github.com/towleeee/go-cryptopro/internal/csperr"
+)
+
+/*
+ * CRYPTOPRO CSP
+ */
+
+var provider backend.EphKeys = cspEphKeys{}
+
+type cspEphKeys struct{}
+
+func (cspEphKeys) GenPrivKey(op string, prov byte) ([]byte, error) {
+	var reslen C.uint
+
+	defer csperr.Lock()()
+	result := C.GeneratePrivateKey(C.uchar(prov), &reslen)
+	if result == nil {
+		return nil, csperr.Last(op, csperr.KindCall, -1)
+	}
+	defer C.free(unsafe.Pointer(result))
+
+	return C.GoBytes(unsafe.Pointer(result), C.int(reslen)), nil
+}
+
+func (cspEphKeys) CheckPrivKey(op string, prov byte, priv []byte) error {
+	var (
+		hProv C.HCRYPTPROV
+		hKey  C.HCRYPTKEY
+	)
+
+	defer csperr.Lock()()
+	ret := C.ImportPrivateKey(C.uchar(prov), &hProv, &hKey, toCbytes(priv), C.uint(len(priv)))
+	if ret < 0 {
+		return csperr.Last(op, csperr.KindImport, int(ret))
+	}
+	C.CryptDestroyKey(hKey)
+	C.CryptReleaseContext(hProv, C.uint(0))
+	return nil
+}
+
+func (cspEphKeys) PubKey(op string, prov byte, priv []byte) ([]byte, error) {
+	var (
+		hProv  C.HCRYPTPROV
+		hKey   C.HCRYPTKEY
+		publen C.uint
+	)
+
+	defer csperr.Lock()()
+	ret := C.ImportPrivateKey(C.uchar(prov), &hProv, &hKey, toCbytes(priv), C.uint(len(priv)))
+	if ret < 0 {
+		return nil, csperr.Last(op, csperr.KindImport, int(ret))
+	}
+	defer func() {
+		C.CryptDestroyKey(hKey)
+		C.CryptReleaseContext(hProv, C.uint(0))
+	}()
+
+	pbytes := C.BytesPublicKey(&hKey, &publen)
+	if pbytes == nil {
+		return nil, csperr.Last(op, csperr.KindCall, -1)
+	}
+	defer C.free(unsafe.Pointer(pbytes))
+
+	return C.GoBytes(unsafe.Pointer(pbytes), C.int(publen)), nil
+}
+
+func (cspEphKeys) CheckPubKey(op string, prov byte, pub []byte) error {
+	var (
+		hProv C.HCRYPTPROV
+		hKey  C.HCRYPTKEY
+	)
+
+	defer csperr.Lock()()
+	ret := C.ImportPublicKey(C.uchar(prov), &hProv, &hKey, toCbytes(pub), C.uint(len(pub)))
+	if ret < 0 {
+		return csperr.Last(op, csperr.KindImport, int(ret))
+	}
+	C.CryptDestroyKey(hKey)
+	C.CryptReleaseContext(hProv, C.uint(0))
+	return nil
+}
+
+func (cspEphKeys) SharedKey(op string, prov byte, priv, pub []byte) ([]byte, error) {
+	var (
+		hProv  C.HCRYPTPROV
+		hKey   C.HCRYPTKEY
+		reslen C.uint
+	)
+
+	defer csperr.Lock()()
+	ret := C.ImportPrivateKey(C.uchar(prov), &hProv, &hKey, toCbytes(priv), C.uint(len(priv)))
+	if ret < 0 {
+		return nil, csperr.Last(op, csperr.KindImport, int(ret))
+	}
+	defer func() {
+		C.CryptDestroyKey(hKey)
+		C.CryptReleaseContext(hProv, C.uint(0))
+	}()
+
+	result := C.SharedSessionKey(&hProv, &hKey, toCbytes(pub), C.uint(len(pub)), &reslen)
+	if result == nil {
+		return nil, csperr.Last(op, csperr.KindCall, -1)
+	}
+	resptr := unsafe.Pointer(result)
+	defer C.free(resptr)
+
+	return C.GoBytes(resptr, C.int(reslen)), nil
+}
+
+func toCbytes(data []byte) *C.uchar {
+	if len(data) > 0 {
+		return (*C.uchar)(&data[0])
+	}
+	return nil
+}
@@ -0,0 +1,12 @@
+//go:build purego
+// +build purego
+
+package gost_r_34_10_2012_eph
+
+import (
+	"github.com/towleeee/go-cryptopro/internal/backend"
+	"github.com/towleeee/go-cryptopro/internal/backend/soft"
+)
+
+// Software backend without CryptoPro CSP (VKO GOST R 34.10-2012 in pure Go).
+var provider backend.EphKeys = soft.EphKeys{}
@@ -0,0 +1,65 @@
+// go test -v -bench=. -benchtime=100x
+package gost_r_34_10_2012_eph
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/towleeee/go-cryptopro/internal/backend/csptest"
+	"github.com/towleeee/go-cryptopro/internal/csperr"
+)
+
+func TestFakeKeys(t *testing.T) {
+	p := csptest.Install(t, &provider)
+
+	p.Fail("CryptGenKey", csperr.NTE_FAIL)
+	var cerr *Error
+	if _, err := NewPrivKey(K256); !errors.As(err, &cerr) || cerr.Func != "GeneratePrivateKey: CryptGenKey" {
+		t.Errorf("test failed: new priv key: %v", err)
+		return
+	}
+
+	priv1, err := NewPrivKey(K256)
+	if err != nil {
+		t.Errorf("test failed: %s", err)
+		return
+	}
+	priv2, err := NewPrivKey(K256)
+	if err != nil {
+		t.Errorf("test failed: %s", err)
+		return
+	}
+
+	p.Fail("ImportPrivateKey: CryptImportKey", csperr.NTE_BAD_DATA)
+	if _, err := LoadPrivKey(priv1.Bytes()); !errors.Is(err, ErrBadKey) {
+		t.Errorf("test failed: load priv key: %v", err)
+		return
+	}
+
+	p.Fail("BytesPublicKey: CryptExportKey (1)", csperr.NTE_BAD_KEY)
+	if priv1.PubKey() != nil {
+		t.Errorf("test failed: pub key")
+		return
+	}
+
+	pub2, err := priv2.PubKeyE()
+	if err != nil {
+		t.Errorf("test failed: %s", err)
+		return
+	}
+	p.Fail("ImportPublicKey: CryptImportKey", csperr.NTE_BAD_PUBLIC_KEY)
+	if _, err := LoadPubKey(pub2.Bytes()); !errors.Is(err, ErrBadKey) {
+		t.Errorf("test failed: load pub key: %v", err)
+		return
+	}
+
+	p.Fail("SharedSessionKey: CryptDeriveKey", csperr.NTE_FAIL)
+	if _, err := priv1.SecretE(pub2); !errors.As(err, &cerr) || cerr.Code != -1 {
+		t.Errorf("test failed: secret: %v", err)
+		return
+	}
+	if priv1.Secret(pub2) == nil || p.Pending() != 0 {
+		t.Errorf("test failed: secret after the failure")
+		return
+	}
+}
@@ -4,7 +4,9 @@ func LoadPrivKey(pbytes []byte) (PrivKey, error) {}
 func (key PrivKey) Bytes() []byte {}
 func (key PrivKey) String() string {}
 func (key PrivKey) Secret(pub PubKey) []byte {}
+func (key PrivKey) SecretE(pub PubKey) ([]byte, error) {}
 func (key PrivKey) PubKey() PubKey {}
+func (key PrivKey) PubKeyE() (PubKey, error) {}
 func (key PrivKey) Equals(cmp PrivKey) bool {}
 func (key PrivKey) Type() string {}
 
@@ -14,6 +16,11 @@ func (key PubKey) Bytes() []byte {}
 func (key PubKey) String() string {}
 func (key PubKey) Equals(cmp PubKey) bool {}
 func (key PubKey) Type() string {}
+
+func MarshalPKIX(pub PubKey) ([]byte, error) {}
+func ParsePKIX(der []byte) (PubKey, error) {}
+func MarshalPKIXPEM(pub PubKey) ([]byte, error) {}
+func ParsePKIXPEM(data []byte) (PubKey, error) {}
 */
 package gost_r_34_10_2012_eph
 
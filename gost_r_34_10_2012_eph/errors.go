@@ -0,0 +1,16 @@
+package gost_r_34_10_2012_eph
+
+import (
+	"github.com/towleeee/go-cryptopro/internal/csperr"
+)
+
+/*
+ * ERRORS
+ */
+
+// Error of the CSP call with the return code of the C function,
+// the failing CSP function and the value of GetLastError().
+type Error = csperr.Error
+
+// Key or key blob is incorrect.
+var ErrBadKey = csperr.ErrBadKey
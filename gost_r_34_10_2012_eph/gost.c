@@ -1,3 +1,6 @@
+//go:build cgo && !purego
+// +build cgo,!purego
+
 #include "gost.h"
 
 extern BYTE *GeneratePrivateKey(BYTE prov, DWORD *size) {
@@ -91,27 +94,6 @@ extern BYTE *BytesPrivateKey(BYTE prov, HCRYPTPROV *hProv, HCRYPTKEY *hKey, DWOR
 	return pkbytes;
 }
 
-extern BYTE *BytesPublicKey(HCRYPTKEY *hKey, DWORD *size) {
-	BYTE *pkbytes;
-
-	if(!CryptExportKey(*hKey, 0, PUBLICKEYBLOB, 0, NULL, size)) {
-		PRINT_ERROR("BytesPublicKey: CryptExportKey (1)");
-		CryptDestroyKey(*hKey);
-        return NULL;
-    }
-
-	pkbytes = (BYTE*)malloc(sizeof(BYTE)*(*size));
-
-	if(!CryptExportKey(*hKey, 0, PUBLICKEYBLOB, 0, pkbytes, size)) {
-		PRINT_ERROR("BytesPublicKey: CryptExportKey (2)");
-		free(pkbytes);
-		CryptDestroyKey(*hKey);
-        return NULL;
-    }
-
-	return pkbytes;
-}
-
 extern BYTE *BytesSessionKey(HCRYPTKEY *hSessionKey, HCRYPTKEY *hPubKey, DWORD *size) {
 	BYTE *pkbytes;
 
@@ -181,21 +163,6 @@ extern int ImportPrivateKey(BYTE prov, HCRYPTPROV *hProv, HCRYPTKEY *hKey, BYTE
 	return 0;
 }
 
-extern int ImportPublicKey(BYTE prov, HCRYPTPROV *hProv, HCRYPTKEY *hKey, BYTE *pkbytes, DWORD keyBlobLen) {
-	if (!CryptAcquireContext(hProv, NULL, NULL, prov, 0)) {
-		PRINT_ERROR("ImportPublicKey: CryptAcquireContext");
-		return -1;
-	}
-
-	if (!CryptImportKey(*hProv, pkbytes, keyBlobLen, 0, 0, hKey)) {
-		PRINT_ERROR("ImportPublicKey: CryptImportKey");
-		CryptReleaseContext(*hProv, 0);
-		return -2;
-	}
-
-	return 0;
-}
-
 extern BYTE *SharedSessionKey(HCRYPTPROV *hProv, HCRYPTKEY *hKey, BYTE *pkbytes, DWORD keyBlobLen, DWORD *size) {
 	const int IVSIZ = 16;
 
@@ -2,15 +2,9 @@
 // https://docs.cntd.ru/document/1200095034
 package gost_r_34_10_2012_eph
 
-/*
-#include "gost.h"
-*/
-import "C"
-
 import (
 	"bytes"
 	"fmt"
-	"unsafe"
 
 	gkeys "github.com/towleeee/go-cryptopro/gost_r_34_10_2012"
 	ghash "github.com/towleeee/go-cryptopro/gost_r_34_11_2012"
@@ -51,17 +45,17 @@ type PrivKey512 PrivKey256
 type PrivKey256 []byte
 
 func NewPrivKey(prov ProvType) (PrivKey, error) {
-	var (
-		reslen C.uint
-	)
-
-	result := C.GeneratePrivateKey(C.uchar(prov), &reslen)
-	if result == nil {
-		return nil, fmt.Errorf("error: new private key")
+	switch prov {
+	case K256, K512:
+		// pass
+	default:
+		return nil, fmt.Errorf("error: undefined provider type")
 	}
-	defer C.free(unsafe.Pointer(result))
 
-	privraw := C.GoBytes(unsafe.Pointer(result), C.int(reslen))
+	privraw, err := provider.GenPrivKey("new priv key", byte(prov))
+	if err != nil {
+		return nil, err
+	}
 	privraw = bytes.Join(
 		[][]byte{
 			[]byte{byte(prov)},
@@ -82,8 +76,6 @@ func NewPrivKey(prov ProvType) (PrivKey, error) {
 
 func LoadPrivKey(pbytes []byte) (PrivKey, error) {
 	var (
-		hProv   C.HCRYPTPROV
-		hKey    C.HCRYPTKEY
 		prov    ProvType
 		privlen = len(pbytes)
 	)
@@ -103,14 +95,9 @@ func LoadPrivKey(pbytes []byte) (PrivKey, error) {
 		return nil, fmt.Errorf("error: read prov type")
 	}
 
-	ret := C.ImportPrivateKey(C.uchar(prov), &hProv, &hKey, toCbytes(pbytes[1:]), C.uint(privlen-1))
-	if ret < 0 {
-		return nil, fmt.Errorf("error: private key is nil")
+	if err := provider.CheckPrivKey("load priv key", byte(prov), pbytes[1:]); err != nil {
+		return nil, err
 	}
-	defer func() {
-		C.CryptDestroyKey(hKey)
-		C.CryptReleaseContext(hProv, C.uint(0))
-	}()
 
 	switch prov {
 	case K256:
@@ -136,65 +123,65 @@ func (key PrivKey256) String() string {
 	return fmt.Sprintf("Priv(%s){%X}", key.Type(), key.Bytes())
 }
 
+// Shared secret of the key pair, nil on error (see SecretE).
 func (key PrivKey512) Secret(pub PubKey) []byte {
 	return PrivKey256(key).Secret(pub)
 }
 func (key PrivKey256) Secret(pub PubKey) []byte {
+	secret, err := key.SecretE(pub)
+	if err != nil {
+		return nil
+	}
+	return secret
+}
+
+// Shared secret of the key pair with the error of CSP.
+func (key PrivKey512) SecretE(pub PubKey) ([]byte, error) {
+	return PrivKey256(key).SecretE(pub)
+}
+func (key PrivKey256) SecretE(pub PubKey) ([]byte, error) {
 	var (
-		hProv  C.HCRYPTPROV
-		hKey   C.HCRYPTKEY
-		reslen C.uint
-		prov   = key.prov()
+		pubkey PubKey256
 	)
 
-	ret := C.ImportPrivateKey(C.uchar(prov), &hProv, &hKey, key.bytes(), key.len())
-	if ret < 0 {
-		panic(fmt.Errorf("error code: %d", ret))
+	switch x := pub.(type) {
+	case PubKey256:
+		pubkey = x
+	case PubKey512:
+		pubkey = PubKey256(x)
+	default:
+		return nil, fmt.Errorf("error: unsupported public key")
 	}
-	defer func() {
-		C.CryptDestroyKey(hKey)
-		C.CryptReleaseContext(hProv, C.uint(0))
-	}()
 
-	result := C.SharedSessionKey(&hProv, &hKey, pub.(PubKey256).bytes(), pub.(PubKey256).len(), &reslen)
-	if result == nil {
-		return nil
+	material, err := provider.SharedKey("secret", byte(key.prov()), key[1:], pubkey[1:])
+	if err != nil {
+		return nil, err
 	}
 
-	resptr := unsafe.Pointer(result)
-	defer C.free(resptr)
-
-	return ghash.Sum(ghash.H256, C.GoBytes(resptr, C.int(reslen)))
+	return ghash.SumE(ghash.H256, material)
 }
 
+// Public key of the key pair, nil on error (see PubKeyE).
 func (key PrivKey512) PubKey() PubKey {
 	return PrivKey256(key).PubKey()
 }
 func (key PrivKey256) PubKey() PubKey {
-	var (
-		hProv  C.HCRYPTPROV
-		hKey   C.HCRYPTKEY
-		publen C.uint
-		pbytes *C.uchar
-		prov   = key.prov()
-	)
-
-	ret := C.ImportPrivateKey(C.uchar(prov), &hProv, &hKey, key.bytes(), key.len())
-	if ret < 0 {
-		panic(fmt.Errorf("error code: %d", ret))
-	}
-	defer func() {
-		C.CryptDestroyKey(hKey)
-		C.CryptReleaseContext(hProv, C.uint(0))
-	}()
-
-	pbytes = C.BytesPublicKey(&hKey, &publen)
-	if pbytes == nil {
-		panic(fmt.Errorf("error: public key is nil"))
+	pubkey, err := key.PubKeyE()
+	if err != nil {
+		return nil
 	}
-	defer C.free(unsafe.Pointer(pbytes))
+	return pubkey
+}
 
-	pubraw := C.GoBytes(unsafe.Pointer(pbytes), C.int(publen))
+// Public key of the key pair with the error of CSP.
+func (key PrivKey512) PubKeyE() (PubKey, error) {
+	return PrivKey256(key).PubKeyE()
+}
+func (key PrivKey256) PubKeyE() (PubKey, error) {
+	pubraw, err := provider.PubKey("pub key", byte(key.prov()), key[1:])
+	if err != nil {
+		return nil, err
+	}
 	pubraw = bytes.Join(
 		[][]byte{
 			[]byte{byte(key.prov())},
@@ -203,12 +190,7 @@ func (key PrivKey256) PubKey() PubKey {
 		[]byte{},
 	)
 
-	pubkey, err := LoadPubKey(pubraw)
-	if err != nil {
-		panic(err)
-	}
-
-	return pubkey
+	return LoadPubKey(pubraw)
 }
 
 func (key PrivKey512) Equals(cmp PrivKey) bool {
@@ -229,14 +211,6 @@ func (key PrivKey256) prov() ProvType {
 	return ProvType(key[0])
 }
 
-func (key PrivKey256) bytes() *C.uchar {
-	return toCbytes(key[1:])
-}
-
-func (key PrivKey256) len() C.uint {
-	return C.uint(len(key[1:]))
-}
-
 /*
  * PUBLIC KEY
  */
@@ -246,8 +220,6 @@ type PubKey256 []byte
 
 func LoadPubKey(pbytes []byte) (PubKey, error) {
 	var (
-		hProv  C.HCRYPTPROV
-		hKey   C.HCRYPTKEY
 		prov   ProvType
 		publen = len(pbytes)
 	)
@@ -267,14 +239,9 @@ func LoadPubKey(pbytes []byte) (PubKey, error) {
 		return nil, fmt.Errorf("error: read prov type")
 	}
 
-	ret := C.ImportPublicKey(C.uchar(prov), &hProv, &hKey, toCbytes(pbytes[1:]), C.uint(publen-1))
-	if ret < 0 {
-		return nil, fmt.Errorf("error: public key is nil")
+	if err := provider.CheckPubKey("load pub key", byte(prov), pbytes[1:]); err != nil {
+		return nil, err
 	}
-	defer func() {
-		C.CryptDestroyKey(hKey)
-		C.CryptReleaseContext(hProv, C.uint(0))
-	}()
 
 	return PubKey256(pbytes), nil
 }
@@ -317,11 +284,3 @@ func (key PubKey256) Type() string {
 func (key PubKey256) prov() ProvType {
 	return ProvType(key[0])
 }
-
-func (key PubKey256) bytes() *C.uchar {
-	return toCbytes(key[1:])
-}
-
-func (key PubKey256) len() C.uint {
-	return C.uint(len(key[1:]))
-}
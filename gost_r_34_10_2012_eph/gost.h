@@ -37,6 +37,7 @@ extern BYTE *BytesPrivateKey(BYTE prov, HCRYPTPROV *hProv, HCRYPTKEY *hKey, DWOR
 // size - size of the public key; 
 // BYTE *(BytesPublicKey) - pointer to public key bytes;
 // BYTE *(BytesPublicKey) != NULL if success;
+// Defined in gost_r_34_10_2012/gost.c (the package is imported);
 extern BYTE *BytesPublicKey(HCRYPTKEY *hKey, DWORD *size);
 
 // DESCRIPTION:
@@ -77,6 +78,7 @@ extern int ImportPrivateKey(BYTE prov, HCRYPTPROV *hProv, HCRYPTKEY *hKey, BYTE
 // OUTPUT:
 // hKey      - initialized pointer to a public key;
 // int (ImportPublicKey) = 0 if success;
+// Defined in gost_r_34_10_2012/gost.c (the package is imported);
 extern int ImportPublicKey(BYTE prov, HCRYPTPROV *hProv, HCRYPTKEY *hKey, BYTE *pkbytes, DWORD keyBlobLen);
 
 // DESCRIPTION:
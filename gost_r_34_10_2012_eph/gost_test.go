@@ -3,6 +3,7 @@ package gost_r_34_10_2012_eph
 
 import (
 	"bytes"
+	"errors"
 	"testing"
 )
 
@@ -82,6 +83,96 @@ func TestSecret(t *testing.T) {
 	}
 }
 
+func TestSecretE(t *testing.T) {
+	priv1, err := NewPrivKey(K256)
+	if err != nil {
+		t.Errorf("test failed: new priv key (1)")
+		return
+	}
+	priv2, err := NewPrivKey(K256)
+	if err != nil {
+		t.Errorf("test failed: new priv key (2)")
+		return
+	}
+
+	pub1, err := priv1.PubKeyE()
+	if err != nil {
+		t.Errorf("test failed: pub key (1): %v", err)
+		return
+	}
+	pub2, err := priv2.PubKeyE()
+	if err != nil {
+		t.Errorf("test failed: pub key (2): %v", err)
+		return
+	}
+
+	xchkey1, err := priv1.SecretE(pub2)
+	if err != nil {
+		t.Errorf("test failed: secret (1): %v", err)
+		return
+	}
+	xchkey2, err := priv2.SecretE(pub1)
+	if err != nil {
+		t.Errorf("test failed: secret (2): %v", err)
+		return
+	}
+	if !bytes.Equal(xchkey1, xchkey2) {
+		t.Errorf("test failed: secret not equal")
+		return
+	}
+
+	// Type of the key blob is broken.
+	broken := append([]byte{}, priv1.Bytes()...)
+	broken[1] = 0
+	_, err = LoadPrivKey(broken)
+	if !errors.Is(err, ErrBadKey) {
+		t.Errorf("test failed: broken priv key: %v", err)
+		return
+	}
+}
+
+func TestPKIX(t *testing.T) {
+	for _, prov := range []ProvType{K256, K512} {
+		priv1, err := NewPrivKey(prov)
+		if err != nil {
+			t.Errorf("test failed: new priv key (1)")
+			return
+		}
+		priv2, err := NewPrivKey(prov)
+		if err != nil {
+			t.Errorf("test failed: new priv key (2)")
+			return
+		}
+
+		data, err := MarshalPKIXPEM(priv2.PubKey())
+		if err != nil {
+			t.Errorf("test failed: marshal pkix pem: %v", err)
+			return
+		}
+
+		pub2, err := ParsePKIXPEM(data)
+		if err != nil {
+			t.Errorf("test failed: parse pkix pem: %v", err)
+			return
+		}
+
+		der, err := MarshalPKIX(pub2)
+		if err != nil {
+			t.Errorf("test failed: marshal pkix: %v", err)
+			return
+		}
+		if _, err := ParsePKIX(der); err != nil {
+			t.Errorf("test failed: parse pkix: %v", err)
+			return
+		}
+
+		if !bytes.Equal(priv1.Secret(pub2), priv2.Secret(priv1.PubKey())) {
+			t.Errorf("test failed: secret not equal")
+			return
+		}
+	}
+}
+
 func BenchmarkGenerateKey(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		_, err := NewPrivKey(K256)
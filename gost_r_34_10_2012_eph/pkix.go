@@ -0,0 +1,67 @@
+package gost_r_34_10_2012_eph
+
+import (
+	"encoding/pem"
+	"fmt"
+
+	gkeys "github.com/towleeee/go-cryptopro/gost_r_34_10_2012"
+	"github.com/towleeee/go-cryptopro/internal/keyblob"
+)
+
+const (
+	PemPublicKey = gkeys.PemPublicKey
+)
+
+/*
+ * SUBJECT PUBLIC KEY INFO
+ */
+
+// DER encoding of the public key as SubjectPublicKeyInfo
+// (RFC 4491, RFC 9215), the form of OpenSSL and other libraries.
+func MarshalPKIX(pub PubKey) ([]byte, error) {
+	return keyblob.MarshalPKIX(pub.Bytes())
+}
+
+// Parsing of DER of SubjectPublicKeyInfo to the ephemeral public key.
+// The key blob gets the algorithm of the ephemeral keys (CALG_DH_*_EPHEM),
+// the key is checked by the import to CSP.
+func ParsePKIX(der []byte) (PubKey, error) {
+	pbytes, err := keyblob.UnmarshalPKIX(der, 0)
+	if err != nil {
+		return nil, err
+	}
+	key, err := keyblob.Parse(pbytes)
+	if err != nil {
+		return nil, err
+	}
+	key.AlgID = keyblob.EphemAlgID(key.Prov)
+	pbytes, err = key.Bytes()
+	if err != nil {
+		return nil, err
+	}
+	return LoadPubKey(pbytes)
+}
+
+// PEM encoding of the public key (block "PUBLIC KEY").
+func MarshalPKIXPEM(pub PubKey) ([]byte, error) {
+	der, err := MarshalPKIX(pub)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{
+		Type:  PemPublicKey,
+		Bytes: der,
+	}), nil
+}
+
+// Parsing of PEM block "PUBLIC KEY".
+func ParsePKIXPEM(data []byte) (PubKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("error: pem block not found")
+	}
+	if block.Type != PemPublicKey {
+		return nil, fmt.Errorf("error: pem type %q", block.Type)
+	}
+	return ParsePKIX(block.Bytes)
+}
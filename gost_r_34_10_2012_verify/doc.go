@@ -0,0 +1,36 @@
+/*
+func LoadPubKey(pbytes []byte) (*PubKey, error) {}
+func (key *PubKey) Bytes() []byte {}
+func (key *PubKey) Prov() ProvType {}
+func (key *PubKey) Equals(cmp *PubKey) bool {}
+func (key *PubKey) VerifySignature(dbytes, sign []byte) bool {}
+func (key *PubKey) VerifySignatureE(dbytes, sign []byte) error {}
+func (key *PubKey) VerifyDigest(digest, sign []byte) bool {}
+func (key *PubKey) VerifyDigestE(digest, sign []byte) error {}
+*/
+package gost_r_34_10_2012_verify
+
+/*
+package main
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	gverify "github.com/towleeee/go-cryptopro/gost_r_34_10_2012_verify"
+)
+
+func main() {
+	// pub.Bytes() and priv.Sign(msg) of gost_r_34_10_2012.
+	pbytes, _ := hex.DecodeString("5006200000492e00004d41473100020000301306072a85030202230106082a850307010102022f6197366b7cd9fb002ec3b7b8ab066fed6a514617a01c6a3ea5124b6acde80fbdb3004940753e0bb350e3f08f9a778dc87b14836a7d7ecf0ec53e49ccdce28e")
+	sign, _ := hex.DecodeString("d8c136c454da21069a7a7064b8fc6c7034b688a7edd0f0bef65d0cbb83ec851ab55b3ae46b6b344a989880d2563f93a47183bb434c65362590b68f03ef6c8ae2")
+
+	pub, err := gverify.LoadPubKey(pbytes)
+	if err != nil {
+		panic(err)
+	}
+
+	msg := []byte("hello, world!")
+	fmt.Printf("Success: %t;\n", pub.VerifySignature(msg, sign))
+}
+*/
@@ -0,0 +1,135 @@
+// ГОСТ Р 34.10-2012, проверка подписи без КриптоПро CSP и cgo
+// https://docs.cntd.ru/document/1200095034
+package gost_r_34_10_2012_verify
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+
+	"github.com/towleeee/go-cryptopro/internal/csperr"
+	"github.com/towleeee/go-cryptopro/internal/curves"
+	"github.com/towleeee/go-cryptopro/internal/gost3410"
+	"github.com/towleeee/go-cryptopro/internal/keyblob"
+	"github.com/towleeee/go-cryptopro/internal/streebog"
+)
+
+type ProvType byte
+
+const (
+	K256 ProvType = ProvType(keyblob.K256)
+	K512 ProvType = ProvType(keyblob.K512)
+)
+
+var (
+	// Signature is incorrect, the same error as
+	// ErrBadSignature of gost_r_34_10_2012.
+	ErrBadSignature = csperr.ErrBadSignature
+	// Key blob is incorrect.
+	ErrBadKey = csperr.ErrBadKey
+)
+
+/*
+ * PUBLIC KEY
+ */
+
+// Public key of gost_r_34_10_2012 (PubKey256, PubKey512)
+// checked by the software implementation.
+type PubKey struct {
+	raw   []byte
+	prov  ProvType
+	curve *curves.Curve
+	x, y  *big.Int
+}
+
+// Loading of the bytes of PubKey256/PubKey512: {prov || PUBLICKEYBLOB}.
+// The point is checked to be on the curve and in the subgroup.
+func LoadPubKey(pbytes []byte) (*PubKey, error) {
+	blob, err := keyblob.Parse(pbytes)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBadKey, err)
+	}
+
+	curve := curves.ByParamSet(blob.Params.ParamSet)
+	if curve == nil || 2*curve.Size != keyblob.PointSize(blob.Prov) {
+		return nil, fmt.Errorf("%w: parameter set %s", ErrBadKey, blob.Params.ParamSet)
+	}
+	if err := curve.CheckPoint(blob.Point); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBadKey, err)
+	}
+
+	x, y, err := curve.Unmarshal(blob.Point)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBadKey, err)
+	}
+
+	return &PubKey{
+		raw:   append([]byte{}, pbytes...),
+		prov:  ProvType(blob.Prov),
+		curve: curve,
+		x:     x,
+		y:     y,
+	}, nil
+}
+
+// Bytes of the key blob.
+func (key *PubKey) Bytes() []byte {
+	return key.raw
+}
+
+// K256 or K512.
+func (key *PubKey) Prov() ProvType {
+	return key.prov
+}
+
+// Keys with the same blob.
+func (key *PubKey) Equals(cmp *PubKey) bool {
+	return bytes.Equal(key.raw, cmp.raw)
+}
+
+// Signature confirmation using the original data,
+// the signature of CryptoAPI as of PubKey256.VerifySignature.
+func (key *PubKey) VerifySignature(dbytes, sign []byte) bool {
+	return key.VerifySignatureE(dbytes, sign) == nil
+}
+
+// Signature confirmation using the original data,
+// ErrBadSignature if the signature is incorrect.
+func (key *PubKey) VerifySignatureE(dbytes, sign []byte) error {
+	return key.verify(streebog.Sum(key.digestSize(), dbytes), sign)
+}
+
+// Signature confirmation using a ready hash value
+// GOST R 34.11-2012 (32 bytes for K256, 64 bytes for K512).
+func (key *PubKey) VerifyDigest(digest, sign []byte) bool {
+	return key.VerifyDigestE(digest, sign) == nil
+}
+
+// Signature confirmation using a ready hash value,
+// ErrBadSignature if the signature is incorrect.
+func (key *PubKey) VerifyDigestE(digest, sign []byte) error {
+	if len(digest) != key.digestSize() {
+		return fmt.Errorf("error: length of digest")
+	}
+	return key.verify(digest, sign)
+}
+
+// Signature of CryptoAPI: little-endian r || little-endian s.
+func (key *PubKey) verify(digest, sign []byte) error {
+	size := key.curve.Size
+	if len(sign) != 2*size {
+		return fmt.Errorf("error: length of signature")
+	}
+	r, s := gost3410.UnmarshalSignature(sign)
+	if !gost3410.Verify(key.curve, key.x, key.y, digest, r, s) {
+		return ErrBadSignature
+	}
+	return nil
+}
+
+func (key *PubKey) digestSize() int {
+	if key.prov == K512 {
+		return streebog.Size512
+	}
+	return streebog.Size256
+}
@@ -0,0 +1,160 @@
+// go test -v -bench=. -benchtime=100x
+package gost_r_34_10_2012_verify
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/towleeee/go-cryptopro/internal/curves"
+	"github.com/towleeee/go-cryptopro/internal/gost3410"
+	"github.com/towleeee/go-cryptopro/internal/keyblob"
+	"github.com/towleeee/go-cryptopro/internal/oids"
+	"github.com/towleeee/go-cryptopro/internal/streebog"
+)
+
+var (
+	TEST_MESSAGE_1 = []byte("hello, world!")
+	TEST_MESSAGE_2 = []byte("hello, world?")
+
+	// PubKey256 of CryptoPro CSP (paramset CryptoPro A) and the signature
+	// of TEST_MESSAGE_1 made by CryptoPro CSP.
+	TEST_PUBKEY_256, _ = hex.DecodeString("5006200000492e00004d41473100020000301306072a85030202230106082a850307010102022f6197366b7cd9fb002ec3b7b8ab066fed6a514617a01c6a3ea5124b6acde80fbdb3004940753e0bb350e3f08f9a778dc87b14836a7d7ecf0ec53e49ccdce28e")
+	TEST_SIGN_256, _   = hex.DecodeString("d8c136c454da21069a7a7064b8fc6c7034b688a7edd0f0bef65d0cbb83ec851ab55b3ae46b6b344a989880d2563f93a47183bb434c65362590b68f03ef6c8ae2")
+)
+
+// Private key d and the blob of the public key d * G.
+func testKey(prov byte, curve *curves.Curve, params keyblob.Params) (*big.Int, []byte) {
+	d, err := rand.Int(rand.Reader, curve.Q)
+	if err != nil || d.Sign() == 0 {
+		panic("gen private key")
+	}
+	pbytes, err := (&keyblob.PublicKey{
+		Prov:   prov,
+		Params: params,
+		Point:  curve.Marshal(curve.ScalarBaseMult(d)),
+	}).Bytes()
+	if err != nil {
+		panic(err)
+	}
+	return d, pbytes
+}
+
+func testSign(curve *curves.Curve, d *big.Int, digest []byte) []byte {
+	r, s, err := gost3410.Sign(curve, d, digest, rand.Reader)
+	if err != nil {
+		panic(err)
+	}
+	return gost3410.MarshalSignature(curve, r, s)
+}
+
+func TestLoadPubKey(t *testing.T) {
+	pub, err := LoadPubKey(TEST_PUBKEY_256)
+	if err != nil {
+		t.Errorf("test failed: load pub key: %v", err)
+		return
+	}
+	if pub.Prov() != K256 {
+		t.Errorf("test failed: prov")
+		return
+	}
+
+	if !pub.VerifySignature(TEST_MESSAGE_1, TEST_SIGN_256) {
+		t.Errorf("test failed: verify signature of CryptoPro CSP")
+		return
+	}
+	if pub.VerifySignature(TEST_MESSAGE_2, TEST_SIGN_256) {
+		t.Errorf("test failed: verify other message")
+		return
+	}
+
+	// The point is not on the curve.
+	broken := append([]byte{}, TEST_PUBKEY_256...)
+	broken[len(broken)-1] ^= 0x01
+	if _, err := LoadPubKey(broken); !errors.Is(err, ErrBadKey) {
+		t.Errorf("test failed: load broken pub key: %v", err)
+		return
+	}
+
+	if _, err := LoadPubKey(TEST_PUBKEY_256[:len(TEST_PUBKEY_256)-1]); !errors.Is(err, ErrBadKey) {
+		t.Errorf("test failed: load short pub key: %v", err)
+		return
+	}
+}
+
+func TestVerify(t *testing.T) {
+	d, pbytes := testKey(keyblob.K256, curves.Tc26Gost256A, keyblob.Params{
+		ParamSet:  oids.Tc26Gost256A,
+		DigestSet: oids.GostR3411_12_256,
+	})
+	testVerify(t, curves.Tc26Gost256A, d, pbytes, streebog.Size256)
+}
+
+func TestVerify512(t *testing.T) {
+	d, pbytes := testKey(keyblob.K512, curves.Tc26Gost512A, keyblob.Params{
+		ParamSet: oids.Tc26Gost512A,
+	})
+	testVerify(t, curves.Tc26Gost512A, d, pbytes, streebog.Size512)
+}
+
+func testVerify(t *testing.T, curve *curves.Curve, d *big.Int, pbytes []byte, size int) {
+	pub, err := LoadPubKey(pbytes)
+	if err != nil {
+		t.Errorf("test failed: load pub key: %v", err)
+		return
+	}
+
+	digest := streebog.Sum(size, TEST_MESSAGE_1)
+	sign := testSign(curve, d, digest)
+
+	if !pub.VerifySignature(TEST_MESSAGE_1, sign) {
+		t.Errorf("test failed: verify (1)")
+		return
+	}
+	if !pub.VerifyDigest(digest, sign) {
+		t.Errorf("test failed: verify digest")
+		return
+	}
+
+	if err := pub.VerifySignatureE(TEST_MESSAGE_2, sign); !errors.Is(err, ErrBadSignature) {
+		t.Errorf("test failed: verify (2): %v", err)
+		return
+	}
+
+	broken := append([]byte{}, sign...)
+	broken[0] ^= 0x01
+	if err := pub.VerifySignatureE(TEST_MESSAGE_1, broken); !errors.Is(err, ErrBadSignature) {
+		t.Errorf("test failed: verify broken signature: %v", err)
+		return
+	}
+
+	if err := pub.VerifySignatureE(TEST_MESSAGE_1, sign[1:]); err == nil || errors.Is(err, ErrBadSignature) {
+		t.Errorf("test failed: verify short signature: %v", err)
+		return
+	}
+	if err := pub.VerifyDigestE(digest[1:], sign); err == nil {
+		t.Errorf("test failed: verify short digest")
+		return
+	}
+}
+
+func BenchmarkVerify(b *testing.B) {
+	d, pbytes := testKey(keyblob.K256, curves.Tc26Gost256A, keyblob.Params{
+		ParamSet:  oids.Tc26Gost256A,
+		DigestSet: oids.GostR3411_12_256,
+	})
+	pub, err := LoadPubKey(pbytes)
+	if err != nil {
+		b.Errorf("benchmark failed: load pub key")
+		return
+	}
+	sign := testSign(curves.Tc26Gost256A, d, streebog.Sum(streebog.Size256, TEST_MESSAGE_1))
+	for i := 0; i < b.N; i++ {
+		if !pub.VerifySignature(TEST_MESSAGE_1, sign) {
+			b.Errorf("benchmark failed: verify")
+			break
+		}
+	}
+}
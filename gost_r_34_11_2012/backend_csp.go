@@ -0,0 +1,130 @@
+//go:build cgo && !purego
+// +build cgo,!purego
+
+package gost_r_34_11_2012
+
+/*
+#cgo linux,amd64 CFLAGS: -I/opt/cprocsp/include/cpcsp -DUNIX -DLINUX -DSIZEOF_VOID_P=8
+#cgo linux,386 CFLAGS: -I/opt/cprocsp/include/cpcsp -DUNIX -DLINUX -DSIZEOF_VOID_P=4
+#cgo linux,amd64 LDFLAGS: -L/opt/cprocsp/lib/amd64/ -lcapi10 -lcapi20 -lrdrsup -lssp
+#cgo linux,386 LDFLAGS: -L/opt/cprocsp/lib/ia32/ -lcapi10 -lcapi20 -lrdrsup -lssp
+#cgo windows CFLAGS: -I/opt/cprocsp/include/cpcsp
+#cgo windows LDFLAGS: -lcrypt32 -lpthread
+
+#include "gost.h"
+*/
+import "C"
+import (
+	"github.com/towleeee/go-cryptopro/internal/backend"
+	"github.com/towleeee/go-cryptopro/internal/csperr"
+)
+
+/*
+ * CRYPTOPRO CSP
+ */
+
+// Size of the buffer for the state of the hash (HP_HASHSTATEBLOB).
+const stateSize = 512
+
+var provider backend.Hash = cspHash{}
+
+type cspHash struct{}
+
+func (cspHash) NewHash(op string, prov byte) ([]byte, error) {
+	var (
+		hh C.HCRYPTHASH
+		hp C.HCRYPTPROV
+	)
+
+	defer csperr.Lock()()
+	ret := C.NewHash(C.uchar(prov), &hp, &hh)
+	if ret < 0 {
+		return nil, csperr.Last(op, csperr.KindCall, int(ret))
+	}
+	defer C.CloseHash(&hh, &hp)
+
+	return readState(op, &hh, &hp)
+}
+
+func (cspHash) WriteHash(op string, prov byte, state, data []byte) ([]byte, error) {
+	var (
+		hh C.HCRYPTHASH
+		hp C.HCRYPTPROV
+	)
+
+	defer csperr.Lock()()
+	if err := loadState(op, prov, &hh, &hp, state, data); err != nil {
+		return nil, err
+	}
+	defer C.CloseHash(&hh, &hp)
+
+	return readState(op, &hh, &hp)
+}
+
+func (cspHash) SumHash(op string, prov byte, state, data []byte) ([]byte, error) {
+	var (
+		hh C.HCRYPTHASH
+		hp C.HCRYPTPROV
+
+		output = make([]byte, ProvType(prov).Size())
+	)
+
+	defer csperr.Lock()()
+	if err := loadState(op, prov, &hh, &hp, state, data); err != nil {
+		return nil, err
+	}
+	defer C.CloseHash(&hh, &hp)
+
+	ret := C.ReadHash(&hh, &hp, toCbytes(output), C.uint(len(output)))
+	if ret < 0 {
+		return nil, csperr.Last(op, csperr.KindCall, int(ret))
+	}
+
+	return output, nil
+}
+
+// Hash object with the state and the data written,
+// closed by the caller with C.CloseHash on success.
+func loadState(op string, prov byte, hh *C.HCRYPTHASH, hp *C.HCRYPTPROV, state, data []byte) error {
+	ret := C.NewHash(C.uchar(prov), hp, hh)
+	if ret < 0 {
+		return csperr.Last(op, csperr.KindCall, int(ret))
+	}
+
+	ret = C.WriteStateHash(hh, hp, toCbytes(state), C.uint(len(state)))
+	if ret < 0 {
+		err := csperr.Last(op, csperr.KindCall, int(ret))
+		C.CloseHash(hh, hp)
+		return err
+	}
+
+	ret = C.WriteHash(hh, hp, toCbytes(data), C.uint(len(data)))
+	if ret < 0 {
+		err := csperr.Last(op, csperr.KindCall, int(ret))
+		C.CloseHash(hh, hp)
+		return err
+	}
+
+	return nil
+}
+
+func readState(op string, hh *C.HCRYPTHASH, hp *C.HCRYPTPROV) ([]byte, error) {
+	var (
+		length C.uint
+		state  = make([]byte, stateSize)
+	)
+
+	ret := C.ReadStateHash(hh, hp, toCbytes(state), &length)
+	if ret < 0 {
+		return nil, csperr.Last(op, csperr.KindCall, int(ret))
+	}
+
+	return state[:length], nil
+}
+
+func toCbytes(data []byte) *C.uchar {
+	if len(data) > 0 {
+		return (*C.uchar)(&data[0])
+	}
+	return nil
+}
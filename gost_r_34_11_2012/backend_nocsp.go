@@ -0,0 +1,13 @@
+//go:build !cgo && !purego
+// +build !cgo,!purego
+
+package gost_r_34_11_2012
+
+import (
+	"github.com/towleeee/go-cryptopro/internal/backend"
+	"github.com/towleeee/go-cryptopro/internal/backend/nocsp"
+)
+
+// Build without cgo: the operations fail with the error of
+// the unavailable CSP, the software backend needs the tag purego.
+var provider backend.Hash = nocsp.Hash{}
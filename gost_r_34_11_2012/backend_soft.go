@@ -0,0 +1,12 @@
+//go:build purego
+// +build purego
+
+package gost_r_34_11_2012
+
+import (
+	"github.com/towleeee/go-cryptopro/internal/backend"
+	"github.com/towleeee/go-cryptopro/internal/backend/soft"
+)
+
+// Software backend without CryptoPro CSP (streebog in pure Go).
+var provider backend.Hash = soft.Hash{}
@@ -0,0 +1,61 @@
+// go test -v -bench=. -benchtime=100x
+package gost_r_34_11_2012
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/towleeee/go-cryptopro/internal/backend/csptest"
+	"github.com/towleeee/go-cryptopro/internal/csperr"
+)
+
+func TestFakeHash(t *testing.T) {
+	p := csptest.Install(t, &provider)
+	msg := []byte("hello, world!")
+
+	p.Fail("NewHash: CryptCreateHash", csperr.NTE_FAIL)
+	var cerr *Error
+	if _, err := NewE(H256); !errors.As(err, &cerr) || cerr.Code != -2 {
+		t.Errorf("test failed: new hash: %v", err)
+		return
+	}
+
+	hasher, err := NewE(H256)
+	if err != nil {
+		t.Errorf("test failed: %s", err)
+		return
+	}
+	p.Fail("WriteHash: CryptHashData", csperr.NTE_BAD_LEN)
+	if _, err := hasher.Write(msg); !errors.As(err, &cerr) || cerr.Func != "WriteHash: CryptHashData" {
+		t.Errorf("test failed: write: %v", err)
+		return
+	}
+
+	// The hash is unusable after the failure until Reset.
+	if _, err := hasher.Write(msg); err == nil || hasher.Sum(nil) != nil {
+		t.Errorf("test failed: hash is usable after the failure")
+		return
+	}
+	hasher.Reset()
+	if _, err := hasher.Write(msg); err != nil {
+		t.Errorf("test failed: %s", err)
+		return
+	}
+	if !bytes.Equal(hasher.Sum(nil), Sum(H256, msg)) {
+		t.Errorf("test failed: sum after reset")
+		return
+	}
+
+	p.Fail("ReadHash: CryptGetHashParam", csperr.NTE_FAIL)
+	if _, err := SumE(H512, msg); !errors.As(err, &cerr) || cerr.LastError != csperr.NTE_FAIL {
+		t.Errorf("test failed: sum: %v", err)
+		return
+	}
+
+	p.Fail("ReadHash: CryptGetHashParam", csperr.NTE_FAIL)
+	if _, err := SumHMACE(H256, msg, msg); !errors.As(err, &cerr) || cerr.LastError != csperr.NTE_FAIL {
+		t.Errorf("test failed: hmac: %v", err)
+		return
+	}
+}
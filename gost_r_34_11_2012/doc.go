@@ -1,5 +1,6 @@
 /*
 func New(prov ProvType) Hash {}
+func NewE(prov ProvType) (Hash, error) {}
 func (hasher *Hash) Write(p []byte) (n int, err error) {}
 func (hasher *Hash) Sum(p []byte) []byte {}
 func (hasher *Hash) Reset() {}
@@ -8,8 +9,10 @@ func (hasher *Hash) BlockSize() int {}
 func (hasher *Hash) Type() string {}
 
 func Sum(prov ProvType, data []byte) []byte {}
+func SumE(prov ProvType, data []byte) ([]byte, error) {}
 func NewHMAC(prov ProvType, key []byte) Hash {}
 func SumHMAC(prov ProvType, key, data []byte) []byte {}
+func SumHMACE(prov ProvType, key, data []byte) ([]byte, error) {}
 */
 package gost_r_34_11_2012
 
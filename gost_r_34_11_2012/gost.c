@@ -1,3 +1,6 @@
+//go:build cgo && !purego
+// +build cgo,!purego
+
 #include "gost.h"
 
 #define HASHSIZE  32
@@ -32,8 +35,6 @@ extern int NewHash(BYTE prov, HCRYPTPROV *hProv, HCRYPTHASH *hHash) {
 extern int WriteHash(HCRYPTHASH *hHash, HCRYPTPROV *hProv, BYTE *data, DWORD size) {
     if (!CryptHashData(*hHash, data, size, 0)) {
         PRINT_ERROR("WriteHash: CryptHashData");
-        CryptDestroyHash(*hHash);
-        CryptReleaseContext(*hProv, 0);
         return -1;
     }
 
@@ -43,8 +44,6 @@ extern int WriteHash(HCRYPTHASH *hHash, HCRYPTPROV *hProv, BYTE *data, DWORD siz
 extern int ReadHash(HCRYPTHASH *hHash, HCRYPTPROV *hProv, BYTE *rgbHash, DWORD cbHash) {
     if (!CryptGetHashParam(*hHash, HP_HASHVAL, rgbHash, &cbHash, 0)) {
         PRINT_ERROR("ReadHash: CryptGetHashParam");
-        CryptDestroyHash(*hHash);
-        CryptReleaseContext(*hProv, 0);
         return -1;
     }
 
@@ -56,8 +55,6 @@ extern int WriteStateHash(HCRYPTHASH *hHash, HCRYPTPROV *hProv, BYTE *rgbHash, D
 
     if (!CryptSetHashParam(*hHash, HP_HASHSTATEBLOB, (BYTE *)&data, 0)) {
         PRINT_ERROR("WriteStateHash: CryptSetHashParam");
-        CryptDestroyHash(*hHash);
-        CryptReleaseContext(*hProv, 0);
         return -1;
     }
 
@@ -67,15 +64,11 @@ extern int WriteStateHash(HCRYPTHASH *hHash, HCRYPTPROV *hProv, BYTE *rgbHash, D
 extern int ReadStateHash(HCRYPTHASH *hHash, HCRYPTPROV *hProv, BYTE *rgbHash, DWORD *cbHash) {
     if (!CryptGetHashParam(*hHash, HP_HASHSTATEBLOB, NULL, cbHash, 0)) {
         PRINT_ERROR("ReadStateHash: CryptGetHashParam (1)");
-        CryptDestroyHash(*hHash);
-        CryptReleaseContext(*hProv, 0);
         return -1;
     }
 
     if (!CryptGetHashParam(*hHash, HP_HASHSTATEBLOB, rgbHash, cbHash, 0)) {
         PRINT_ERROR("ReadStateHash: CryptGetHashParam (2)");
-        CryptDestroyHash(*hHash);
-        CryptReleaseContext(*hProv, 0);
         return -2;
     }
 
@@ -2,10 +2,6 @@
 // https://docs.cntd.ru/document/1200095035
 package gost_r_34_11_2012
 
-/*
-#include "gost.h"
-*/
-import "C"
 import (
 	"crypto/hmac"
 	"fmt"
@@ -63,120 +59,93 @@ func (h ProvType) Size() int {
 type Hash512 Hash256
 type Hash256 struct {
 	prov   ProvType
-	length C.uint
 	states []byte
+	// First error of CSP, the hash becomes unusable.
+	err error
 }
 
 // Create Hash object.
+// The error of CSP is returned by Write,
+// Sum returns nil in this case (see NewE).
 func New(prov ProvType) Hash {
-	var (
-		hh C.HCRYPTHASH
-		hp C.HCRYPTPROV
-
-		states = make([]byte, 512)
-		length C.uint
-	)
-
-	ret := C.NewHash(C.uchar(prov), &hp, &hh)
-	if ret < 0 {
-		panic(fmt.Errorf("error code: %d", ret))
-	}
-	defer C.CloseHash(&hh, &hp)
-
-	ret = C.ReadStateHash(&hh, &hp, toCbytes(states), &length)
-	if ret < 0 {
-		panic(fmt.Errorf("error code: %d", ret))
+	switch prov {
+	case H256, H512:
+		hasher, _ := newHash(prov)
+		return hasher.hash()
+	default:
+		return nil
 	}
+}
 
+// Create Hash object with the error of CSP.
+func NewE(prov ProvType) (Hash, error) {
 	switch prov {
-	case H256:
-		return &Hash256{
-			prov:   prov,
-			length: length,
-			states: states,
-		}
-	case H512:
-		return &Hash512{
-			prov:   prov,
-			length: length,
-			states: states,
+	case H256, H512:
+		hasher, err := newHash(prov)
+		if err != nil {
+			return nil, err
 		}
+		return hasher.hash(), nil
 	default:
-		return nil
+		return nil, fmt.Errorf("error: read prov type")
 	}
 }
 
+// The error is saved in the returned object.
+func newHash(prov ProvType) (*Hash256, error) {
+	hasher := &Hash256{
+		prov: prov,
+	}
+	hasher.states, hasher.err = provider.NewHash("new hash", byte(prov))
+	return hasher, hasher.err
+}
+
+// Hash256 or Hash512 by the provider type.
+func (hasher *Hash256) hash() Hash {
+	if hasher.prov == H512 {
+		return (*Hash512)(hasher)
+	}
+	return hasher
+}
+
 // Writing a piece of information to the Hash object.
 func (hasher *Hash512) Write(p []byte) (n int, err error) {
 	return (*Hash256)(hasher).Write(p)
 }
 func (hasher *Hash256) Write(p []byte) (n int, err error) {
-	var (
-		hh C.HCRYPTHASH
-		hp C.HCRYPTPROV
-
-		datlen = len(p)
-	)
-
-	ret := C.NewHash(C.uchar(hasher.prov), &hp, &hh)
-	if ret < 0 {
-		panic(fmt.Errorf("error code: %d", ret))
-	}
-	defer C.CloseHash(&hh, &hp)
-
-	ret = C.WriteStateHash(&hh, &hp, toCbytes(hasher.states), hasher.length)
-	if ret < 0 {
-		panic(fmt.Errorf("error code: %d", ret))
-	}
-
-	ret = C.WriteHash(&hh, &hp, toCbytes(p), C.uint(datlen))
-	if ret < 0 {
-		panic(fmt.Errorf("error code: %d", ret))
+	if hasher.err != nil {
+		return 0, hasher.err
 	}
 
-	ret = C.ReadStateHash(&hh, &hp, toCbytes(hasher.states), &hasher.length)
-	if ret < 0 {
-		panic(fmt.Errorf("error code: %d", ret))
+	states, err := provider.WriteHash("write hash", byte(hasher.prov), hasher.states, p)
+	if err != nil {
+		hasher.err = err
+		return 0, hasher.err
 	}
+	hasher.states = states
 
-	return datlen, nil
+	return len(p), nil
 }
 
 // If the interface function takes a non-zero argument,
 // then there is a redirection to the Sum function.
+// Nil is returned if the hashing failed.
 func (hasher *Hash512) Sum(p []byte) []byte {
 	return (*Hash256)(hasher).Sum(p)
 }
 func (hasher *Hash256) Sum(p []byte) []byte {
-	var (
-		hh C.HCRYPTHASH
-		hp C.HCRYPTPROV
-
-		output = make([]byte, hasher.Size())
-	)
-
-	ret := C.NewHash(C.uchar(hasher.prov), &hp, &hh)
-	if ret < 0 {
-		panic(fmt.Errorf("error code: %d", ret))
-	}
-	defer C.CloseHash(&hh, &hp)
-
-	ret = C.WriteStateHash(&hh, &hp, toCbytes(hasher.states), hasher.length)
-	if ret < 0 {
-		panic(fmt.Errorf("error code: %d", ret))
-	}
-
-	ret = C.WriteHash(&hh, &hp, toCbytes(p), C.uint(len(p)))
-	if ret < 0 {
-		panic(fmt.Errorf("error code: %d", ret))
+	output, err := hasher.sum(p)
+	if err != nil {
+		return nil
 	}
+	return output
+}
 
-	ret = C.ReadHash(&hh, &hp, toCbytes(output), C.uint(hasher.Size()))
-	if ret < 0 {
-		panic(fmt.Errorf("error code: %d", ret))
+func (hasher *Hash256) sum(p []byte) ([]byte, error) {
+	if hasher.err != nil {
+		return nil, hasher.err
 	}
-
-	return output
+	return provider.SumHash("sum hash", byte(hasher.prov), hasher.states, p)
 }
 
 // Clear data in Hash object.
@@ -218,6 +187,21 @@ func Sum(prov ProvType, data []byte) []byte {
 	return hasher.Sum(nil)
 }
 
+// Computing a hash(256 or 512) at a time with the error of CSP.
+func SumE(prov ProvType, data []byte) ([]byte, error) {
+	switch prov {
+	case H256, H512:
+		// pass
+	default:
+		return nil, fmt.Errorf("error: read prov type")
+	}
+	hasher, err := newHash(prov)
+	if err != nil {
+		return nil, err
+	}
+	return hasher.sum(data)
+}
+
 // Create Hash(HMAC) object.
 func NewHMAC(prov ProvType, key []byte) Hash {
 	return hmac.New(newHasher(prov), key)
@@ -230,6 +214,32 @@ func SumHMAC(prov ProvType, key, data []byte) []byte {
 	return hasher.Sum(nil)
 }
 
+// Computing a hmac(256 or 512) at a time with the error of CSP
+// (RFC 2104: H(K ^ opad || H(K ^ ipad || data))).
+func SumHMACE(prov ProvType, key, data []byte) ([]byte, error) {
+	if len(key) > BlockSize {
+		hkey, err := SumE(prov, key)
+		if err != nil {
+			return nil, err
+		}
+		key = hkey
+	}
+	ipad := make([]byte, BlockSize)
+	opad := make([]byte, BlockSize)
+	copy(ipad, key)
+	copy(opad, key)
+	for i := range ipad {
+		ipad[i] ^= 0x36
+		opad[i] ^= 0x5c
+	}
+
+	inner, err := SumE(prov, append(ipad, data...))
+	if err != nil {
+		return nil, err
+	}
+	return SumE(prov, append(opad, inner...))
+}
+
 func newHasher(prov ProvType) func() hash.Hash {
 	h := func() hash.Hash {
 		return New(prov)
@@ -71,7 +71,8 @@ extern int ReadStateHash(HCRYPTHASH *hHash, HCRYPTPROV *hProv, BYTE *rgbHash, DW
 // Function for clearing the HCRYPTHASH object and cryptographic provider
 // after the end of all actions. Can be performed
 // only after the NewHash function, when an object of type
-// HCRYPTHASH has been initialized; 
+// HCRYPTHASH has been initialized
+// (also after a failure of the functions above); 
 // INPUT:
 // hHash - pointer to HCRYPTHASH object;
 // hProv - pointer to crypto provider;
@@ -2,6 +2,7 @@
 package gost_r_34_11_2012
 
 import (
+	"bytes"
 	"encoding/hex"
 	"testing"
 )
@@ -91,6 +92,58 @@ func TestHash512(t *testing.T) {
 	}
 }
 
+func TestHashE(t *testing.T) {
+	hasher, err := NewE(H256)
+	if err != nil {
+		t.Errorf("test failed: %v", err)
+		return
+	}
+	if _, err = hasher.Write(TEST_MESSAGE_3); err != nil {
+		t.Errorf("test failed: %v", err)
+		return
+	}
+	if hex.EncodeToString(hasher.Sum(nil)) != HASH_RESULT_256 {
+		t.Errorf("test failed: hash != HASH_RESULT")
+		return
+	}
+
+	res, err := SumE(H512, TEST_MESSAGE_3)
+	if err != nil || hex.EncodeToString(res) != HASH_RESULT_512 {
+		t.Errorf("test failed: sum != HASH_RESULT: %v", err)
+		return
+	}
+
+	if _, err = NewE(ProvType(0)); err == nil {
+		t.Errorf("test failed: undefined prov type")
+		return
+	}
+	if _, err = SumE(ProvType(0), TEST_MESSAGE_3); err == nil {
+		t.Errorf("test failed: undefined prov type")
+		return
+	}
+}
+
+func TestHMACE(t *testing.T) {
+	for _, prov := range []ProvType{H256, H512} {
+		for _, key := range [][]byte{TEST_MESSAGE_1, bytes.Repeat(TEST_MESSAGE_2, BlockSize)} {
+			mac, err := SumHMACE(prov, key, TEST_MESSAGE_3)
+			if err != nil {
+				t.Errorf("test failed: %v", err)
+				return
+			}
+			if !bytes.Equal(mac, SumHMAC(prov, key, TEST_MESSAGE_3)) {
+				t.Errorf("test failed: hmac %s != SumHMAC", prov)
+				return
+			}
+		}
+	}
+
+	if _, err := SumHMACE(ProvType(0), TEST_MESSAGE_1, TEST_MESSAGE_3); err == nil {
+		t.Errorf("test failed: undefined prov type")
+		return
+	}
+}
+
 func BenchmarkHasher256(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		hasher := New(H256)
@@ -0,0 +1,56 @@
+//go:build cgo && !purego
+// +build cgo,!purego
+
+package gost_r_34_12_2015
+
+/*
+#cgo linux,amd64 CFLAGS: -I/opt/cprocsp/include/cpcsp -DUNIX -DLINUX -DSIZEOF_VOID_P=8
+#cgo linux,386 CFLAGS: -I/opt/cprocsp/include/cpcsp -DUNIX -DLINUX -DSIZEOF_VOID_P=4
+#cgo linux,amd64 LDFLAGS: -L/opt/cprocsp/lib/amd64/ -lcapi10 -lcapi20 -lrdrsup -lssp
+#cgo linux,386 LDFLAGS: -L/opt/cprocsp/lib/ia32/ -lcapi10 -lcapi20 -lrdrsup -lssp
+#cgo windows CFLAGS: -I/opt/cprocsp/include/cpcsp
+#cgo windows LDFLAGS: -lcrypt32 -lpthread
+
+#include "gost.h"
+*/
+import "C"
+import (
+	"unsafe"
+
+	"github.com/towleeee/go-cryptopro/internal/backend"
+	"github.com/towleeee/go-cryptopro/internal/csperr"
+)
+
+/*
+ * CRYPTOPRO CSP
+ */
+
+var provider backend.Cipher = cspCipher{}
+
+type cspCipher struct{}
+
+func (cspCipher) Encrypt(op string, data, key, iv []byte) ([]byte, error) {
+	var (
+		datlen = len(data)
+		cpdata = make([]byte, datlen)
+	)
+	copy(cpdata, data)
+	var (
+		datptr = toCbytes(cpdata)
+		vecptr = toCbytes(iv)
+		keyptr = toCbytes(key)
+	)
+	defer csperr.Lock()()
+	reslen := C.Encrypt(datptr, (C.uint)(datlen), keyptr, (C.uint)(len(key)), vecptr)
+	if reslen < 0 {
+		return nil, csperr.Last(op, csperr.KindCall, int(reslen))
+	}
+	return C.GoBytes(unsafe.Pointer(datptr), reslen), nil
+}
+
+func toCbytes(data []byte) *C.uchar {
+	if len(data) > 0 {
+		return (*C.uchar)(&data[0])
+	}
+	return nil
+}
@@ -0,0 +1,12 @@
+//go:build purego
+// +build purego
+
+package gost_r_34_12_2015
+
+import (
+	"github.com/towleeee/go-cryptopro/internal/backend"
+	"github.com/towleeee/go-cryptopro/internal/backend/soft"
+)
+
+// Software backend without CryptoPro CSP (Kuznyechik in pure Go).
+var provider backend.Cipher = soft.Cipher{}
@@ -0,0 +1,47 @@
+// go test -v -bench=. -benchtime=100x
+package gost_r_34_12_2015
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/towleeee/go-cryptopro/internal/backend/csptest"
+	"github.com/towleeee/go-cryptopro/internal/csperr"
+)
+
+func TestFakeCipher(t *testing.T) {
+	p := csptest.Install(t, &provider)
+
+	key := bytes.Repeat([]byte{1}, KeySize)
+	nonce := bytes.Repeat([]byte{2}, NonceSize)
+	msg := []byte("hello, world!")
+
+	cphr, err := New(key)
+	if err != nil {
+		t.Errorf("test failed: %s", err)
+		return
+	}
+
+	p.Fail("CryptEncrypt", csperr.NTE_BAD_DATA)
+	if cphr.Seal(nil, nonce, msg, nil) != nil {
+		t.Errorf("test failed: seal")
+		return
+	}
+
+	encrypted := cphr.Seal(nil, nonce, msg, nil)
+	if encrypted == nil {
+		t.Errorf("test failed: seal after the failure")
+		return
+	}
+
+	p.Fail("Encrypt: CryptDeriveKey", csperr.NTE_BAD_KEY)
+	if _, err := cphr.Open(nil, nonce, encrypted, nil); err == nil {
+		t.Errorf("test failed: open")
+		return
+	}
+	decrypted, err := cphr.Open(nil, nonce, encrypted, nil)
+	if err != nil || !bytes.Equal(decrypted, msg) {
+		t.Errorf("test failed: open after the failure: %v", err)
+		return
+	}
+}
@@ -1,3 +1,6 @@
+//go:build cgo && !purego
+// +build cgo,!purego
+
 #include "gost.h"
 
 // Размер блока.
@@ -54,6 +57,7 @@ extern int Encrypt(BYTE *data, DWORD dsize, BYTE *key, DWORD ksize, BYTE *iv) {
 
 	len = dsize;
 	if (!CryptEncrypt(hKey, 0, 1, 0, data, &len, dsize)) {
+		PRINT_ERROR("Encrypt: CryptEncrypt");
 		CryptDestroyKey(hKey);
 		CryptDestroyHash(hHash);
 		CryptReleaseContext(hProv, 0);
@@ -2,15 +2,10 @@
 // https://docs.cntd.ru/document/1200121983
 package gost_r_34_12_2015
 
-/*
-#include "gost.h"
-*/
-import "C"
 import (
 	"bytes"
 	"crypto/cipher"
 	"fmt"
-	"unsafe"
 
 	ghash "github.com/towleeee/go-cryptopro/gost_r_34_11_2012"
 )
@@ -49,6 +44,7 @@ func New(key []byte) (cipher.AEAD, error) {
 }
 
 // Encrypt with authentication information.
+// Nil is returned if the nonce size is wrong or CSP fails.
 func (cphr *Cipher) Seal(dst, nonce, plaintext, addData []byte) []byte {
 	if len(nonce) != cphr.NonceSize() {
 		return nil
@@ -62,10 +58,14 @@ func (cphr *Cipher) Seal(dst, nonce, plaintext, addData []byte) []byte {
 		[]byte{},
 	)
 	mac := ghash.SumHMAC(ghash.H256, mbytes, cphr.key[:])
+	encrypted, err := encrypt(plaintext, cphr.key[:], nonce)
+	if err != nil || mac == nil {
+		return nil
+	}
 	ciphertext := bytes.Join(
 		[][]byte{
 			mac,
-			encrypt(plaintext, cphr.key[:], nonce),
+			encrypted,
 		},
 		[]byte{},
 	)
@@ -82,9 +82,9 @@ func (cphr *Cipher) Open(dst, nonce, ciphertext, addData []byte) ([]byte, error)
 		return nil, fmt.Errorf("error: len cipher < overhead")
 	}
 	mac := ciphertext[:ghash.Size256]
-	plaintext := encrypt(ciphertext[ghash.Size256:], cphr.key[:], nonce)
-	if plaintext == nil {
-		return nil, fmt.Errorf("error: decrypt")
+	plaintext, err := encrypt(ciphertext[ghash.Size256:], cphr.key[:], nonce)
+	if err != nil {
+		return nil, err
 	}
 	mbytes := bytes.Join(
 		[][]byte{
@@ -110,20 +110,6 @@ func (cphr *Cipher) Overhead() int {
 	return Overhead
 }
 
-func encrypt(data, key, iv []byte) []byte {
-	var (
-		datlen = len(data)
-		cpdata = make([]byte, datlen)
-	)
-	copy(cpdata, data)
-	var (
-		datptr = toCbytes(cpdata)
-		vecptr = toCbytes(iv)
-		keyptr = toCbytes(key)
-	)
-	reslen := C.Encrypt(datptr, (C.uint)(datlen), keyptr, (C.uint)(len(key)), vecptr)
-	if reslen < 0 {
-		panic(fmt.Errorf("error code: %v", reslen))
-	}
-	return C.GoBytes(unsafe.Pointer(datptr), reslen)
+func encrypt(data, key, iv []byte) ([]byte, error) {
+	return provider.Encrypt("encrypt", data, key, iv)
 }
@@ -1,15 +1,40 @@
+//go:build cgo && !purego
+// +build cgo,!purego
+
 package gost_r_iso_28640_2012
 
 /*
-#cgo LDFLAGS: -Wl,--allow-multiple-definition
 #cgo linux,amd64 CFLAGS: -I/opt/cprocsp/include/cpcsp -DUNIX -DLINUX -DSIZEOF_VOID_P=8
 #cgo linux,386 CFLAGS: -I/opt/cprocsp/include/cpcsp -DUNIX -DLINUX -DSIZEOF_VOID_P=4
 #cgo linux,amd64 LDFLAGS: -L/opt/cprocsp/lib/amd64/ -lcapi10 -lcapi20 -lrdrsup -lssp
 #cgo linux,386 LDFLAGS: -L/opt/cprocsp/lib/ia32/ -lcapi10 -lcapi20 -lrdrsup -lssp
 #cgo windows CFLAGS: -I/opt/cprocsp/include/cpcsp
 #cgo windows LDFLAGS: -lcrypt32 -lpthread
+
+#include "gost.h"
 */
 import "C"
+import (
+	"github.com/towleeee/go-cryptopro/internal/backend"
+	"github.com/towleeee/go-cryptopro/internal/csperr"
+)
+
+/*
+ * CRYPTOPRO CSP
+ */
+
+var provider backend.Rand = cspRand{}
+
+type cspRand struct{}
+
+func (cspRand) Rand(op string, output []byte) error {
+	defer csperr.Lock()()
+	ret := C.Rand(toCbytes(output), C.uint(len(output)))
+	if ret < 0 {
+		return csperr.Last(op, csperr.KindCall, int(ret))
+	}
+	return nil
+}
 
 func toCbytes(data []byte) *C.uchar {
 	if len(data) > 0 {
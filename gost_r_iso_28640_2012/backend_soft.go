@@ -0,0 +1,12 @@
+//go:build purego
+// +build purego
+
+package gost_r_iso_28640_2012
+
+import (
+	"github.com/towleeee/go-cryptopro/internal/backend"
+	"github.com/towleeee/go-cryptopro/internal/backend/soft"
+)
+
+// Software backend without CryptoPro CSP (crypto/rand).
+var provider backend.Rand = soft.Rand{}
@@ -0,0 +1,38 @@
+// go test -v -bench=. -benchtime=100x
+package gost_r_iso_28640_2012
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/towleeee/go-cryptopro/internal/backend/csptest"
+	"github.com/towleeee/go-cryptopro/internal/csperr"
+)
+
+func TestFakeRand(t *testing.T) {
+	p := csptest.Install(t, &provider)
+
+	p.Fail("CryptAcquireContext", csperr.NTE_BAD_KEYSET)
+	var cerr *Error
+	if _, err := RandE(READSIZE); !errors.As(err, &cerr) || cerr.Code != -1 {
+		t.Errorf("test failed: acquire context: %v", err)
+		return
+	}
+
+	p.Fail("CryptGenRandom", csperr.NTE_FAIL)
+	if Rand(READSIZE) != nil {
+		t.Errorf("test failed: rand")
+		return
+	}
+
+	p.Fail("CryptGenRandom", csperr.NTE_FAIL)
+	if n, err := Read(make([]byte, READSIZE)); err == nil || n != 0 {
+		t.Errorf("test failed: read")
+		return
+	}
+
+	if len(Rand(READSIZE)) != READSIZE || p.Calls("CryptGenRandom") != 3 {
+		t.Errorf("test failed: rand after the failure")
+		return
+	}
+}
@@ -2,6 +2,7 @@
 func Read(p []byte) (int, error) {}
 func (r Reader) Read(p []byte) (int, error) {}
 func Rand(size int) []byte {}
+func RandE(size int) ([]byte, error) {}
 */
 package gost_r_iso_28640_2012
 
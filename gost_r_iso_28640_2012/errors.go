@@ -0,0 +1,13 @@
+package gost_r_iso_28640_2012
+
+import (
+	"github.com/towleeee/go-cryptopro/internal/csperr"
+)
+
+/*
+ * ERRORS
+ */
+
+// Error of the CSP call with the return code of the C function,
+// the failing CSP function and the value of GetLastError().
+type Error = csperr.Error
@@ -1,3 +1,6 @@
+//go:build cgo && !purego
+// +build cgo,!purego
+
 #include "gost.h"
 
 extern int Rand(BYTE *output, DWORD size) {
@@ -10,6 +13,7 @@ extern int Rand(BYTE *output, DWORD size) {
 
 	if (!CryptGenRandom(hCryptProv, size, output)) {
 		PRINT_ERROR("Rand: CryptGenRandom");
+		CryptReleaseContext(hCryptProv, 0);
 		return -2;
 	}
 
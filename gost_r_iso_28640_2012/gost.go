@@ -2,10 +2,6 @@
 // https://docs.cntd.ru/document/1200096454
 package gost_r_iso_28640_2012
 
-/*
-#include "gost.h"
-*/
-import "C"
 import (
 	"fmt"
 	"io"
@@ -30,11 +26,11 @@ func Read(p []byte) (int, error) {
 // Interface function for the Reader object.
 func (r reader) Read(p []byte) (int, error) {
 	var (
-		n   = len(p)
-		res = Rand(n)
+		n = len(p)
 	)
-	if res == nil {
-		return 0, fmt.Errorf("rand is nil")
+	res, err := RandE(n)
+	if err != nil {
+		return 0, err
 	}
 	copy(p, res)
 	return n, nil
@@ -42,16 +38,25 @@ func (r reader) Read(p []byte) (int, error) {
 
 // The CryptGenRandom function is used based on
 // cryptographic provider PROV_GOST_2012_256.
+// Nil is returned on error (see RandE).
 func Rand(size int) []byte {
+	output, err := RandE(size)
+	if err != nil {
+		return nil
+	}
+	return output
+}
+
+// Rand with the error of CSP.
+func RandE(size int) ([]byte, error) {
+	if size < 0 {
+		return nil, fmt.Errorf("error: size < 0")
+	}
 	var (
 		output = make([]byte, size)
 	)
-	if size < 0 {
-		return nil
+	if err := provider.Rand("rand", output); err != nil {
+		return nil, err
 	}
-	ret := C.Rand(toCbytes(output), C.uint(size))
-	if ret < 0 {
-		panic(fmt.Errorf("error code: %d", ret))
-	}
-	return output
+	return output, nil
 }
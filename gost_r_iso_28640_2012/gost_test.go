@@ -27,6 +27,22 @@ func TestRand(t *testing.T) {
 	}
 }
 
+func TestRandE(t *testing.T) {
+	res, err := RandE(READSIZE)
+	if err != nil {
+		t.Errorf("test failed: %v", err)
+		return
+	}
+	if len(res) != READSIZE {
+		t.Errorf("test failed: len(res) != READSIZE")
+		return
+	}
+	if _, err = RandE(-1); err == nil {
+		t.Errorf("test failed: negative size")
+		return
+	}
+}
+
 func BenchmarkRand(b *testing.B) {
 	var buffer = make([]byte, READSIZE)
 	for i := 0; i < b.N; i++ {
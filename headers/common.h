@@ -1,13 +1,22 @@
 #ifndef COMMON_H_INCLUDED
 #define COMMON_H_INCLUDED
 
-#define DEBUG
+// Errors are logged on the Go side (csplog.SetLogger),
+// CGO_CFLAGS=-DDEBUG prints them to stderr as well.
 #ifdef DEBUG
-	#define PRINT_ERROR(err) printf("E[%d][%s]\n", GetLastError(), (err))
+	#define DEBUG_ERROR(code, err) fprintf(stderr, "E[%d][%s]\n", (code), (err))
 #else
-	#define PRINT_ERROR(err)
+	#define DEBUG_ERROR(code, err)
 #endif
 
+// The failing function and GetLastError() are saved
+// for the Go side (internal/csperr/csperr.c).
+#define PRINT_ERROR(err) do { \
+		DWORD __code = GetLastError(); \
+		DEBUG_ERROR(__code, (err)); \
+		SetCspError((err), __code); \
+	} while (0)
+
 #include <stdio.h>
 #include <stdlib.h>
 
@@ -31,6 +40,19 @@ typedef struct _CMSG_CTRL_DECRYPT_PARA_OVERRIDE {
 #endif
 #include <../headers/WinCryptEx.h>
 
+// DESCRIPTION:
+// The first CSP failure in the current thread since ResetCspError,
+// defined once in internal/csperr/csperr.c;
+// INPUT:
+// func - name of the failing function;
+// code - value of GetLastError();
+extern __thread const char *cspErrorFunc;
+extern __thread DWORD cspErrorCode;
+extern void SetCspError(const char *func, DWORD code);
+extern void ResetCspError(void);
+extern const char *CspErrorFunc(void);
+extern DWORD CspErrorCode(void);
+
 #define MY_ENC_TYPE (X509_ASN_ENCODING | PKCS_7_ASN_ENCODING)
 #define ENCRYPT_OID szOID_CP_GOST_28147
 #endif
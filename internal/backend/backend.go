@@ -0,0 +1,100 @@
+// Operations of the crypto provider used by the public packages.
+// CryptoPro CSP (cgo) is the default implementation, the software
+// implementation of internal/backend/soft is used with the build tag
+// purego only. Without cgo and purego the operations fail
+// (internal/backend/nocsp).
+//
+// The argument op is the operation of the Go package for the error
+// (csperr.Error), key blobs are passed without the first byte prov.
+package backend
+
+// Random numbers (gost_r_iso_28640_2012).
+type Rand interface {
+	// Filling of the output with random bytes.
+	Rand(op string, output []byte) error
+}
+
+// GOST R 34.12-2015 (Kuznyechik) in the mode OFB (gost_r_34_12_2015),
+// the key is the hash GOST R 34.11-2012 256 of the key argument.
+type Cipher interface {
+	// Encryption or decryption of the data with the 16 bytes iv.
+	Encrypt(op string, data, key, iv []byte) ([]byte, error)
+}
+
+// GOST R 34.11-2012 with the state saved between the calls
+// (gost_r_34_11_2012), prov is 80 (256 bit) or 81 (512 bit).
+type Hash interface {
+	// Initial state of the hash.
+	NewHash(op string, prov byte) ([]byte, error)
+	// State after the writing of the data.
+	WriteHash(op string, prov byte, state, data []byte) ([]byte, error)
+	// Hash value of the state and the data, the state is not changed.
+	SumHash(op string, prov byte, state, data []byte) ([]byte, error)
+}
+
+// Ephemeral exchange keys (gost_r_34_10_2012_eph).
+type EphKeys interface {
+	// Private key blob of a new key.
+	GenPrivKey(op string, prov byte) ([]byte, error)
+	// Check of the private key blob.
+	CheckPrivKey(op string, prov byte, priv []byte) error
+	// PUBLICKEYBLOB of the private key.
+	PubKey(op string, prov byte, priv []byte) ([]byte, error)
+	// Check of the PUBLICKEYBLOB.
+	CheckPubKey(op string, prov byte, pub []byte) error
+	// Key material of the private key and the public key of the other
+	// side, the same for both sides.
+	SharedKey(op string, prov byte, priv, pub []byte) ([]byte, error)
+}
+
+// Keys of containers (gost_r_34_10_2012).
+// Errors of the container functions wrap the sentinels
+// of csperr: ErrBadKeyset, ErrWrongPassword, ErrContainerExists.
+type Keys interface {
+	// Creation of the container with the key pairs keys
+	// (0x1 - AT_SIGNATURE, 0x2 - AT_KEYEXCHANGE) on the parameter set
+	// (provider default if empty) with the digest set (none if empty).
+	CreateContainer(op string, prov byte, container, password string, keys uint32, exportable bool, paramSet, digestSet string) error
+	// Opening of the container by the password.
+	CheckContainer(op string, prov byte, container, password string) error
+	// Deleting of the container, the password is checked.
+	DeleteContainer(op string, prov byte, container, password string) error
+	// Copying of the container with all keys to dst with the password of dst.
+	CopyContainer(op string, prov byte, src, srcPassword, dst, dstPassword string) error
+	// Changing of the password of the container.
+	ChangePassword(op string, prov byte, container, password, newPassword string) error
+	// Fully qualified names of the containers "\\.\READER\NAME".
+	EnumContainers(op string, prov byte) ([]string, error)
+	// Key pairs of the container (0x1 - AT_SIGNATURE, 0x2 - AT_KEYEXCHANGE).
+	ContainerKeys(op string, prov byte, container string) (uint32, error)
+
+	// Signature of CryptoAPI of the data or of the hash value.
+	Sign(op string, prov byte, container, password string, spec uint32, data []byte, digest bool) ([]byte, error)
+	// PUBLICKEYBLOB of the key pair of the container.
+	PubKey(op string, prov byte, container, password string, spec uint32) ([]byte, error)
+	// Check of the PUBLICKEYBLOB.
+	CheckPubKey(op string, prov byte, pub []byte) error
+
+	// Container opened for many signatures,
+	// the error wraps the sentinels of the container functions.
+	OpenSession(op string, prov byte, container, password string, spec uint32) (Session, error)
+	// Public key imported for many verifications.
+	ImportPubKey(op string, prov byte, pub []byte) (Verifier, error)
+}
+
+// Opened container, used by one goroutine at a time.
+type Session interface {
+	// PUBLICKEYBLOB of the key pair.
+	PubKey(op string) ([]byte, error)
+	// Signature of CryptoAPI of the data or of the hash value.
+	Sign(op string, data []byte, digest bool) ([]byte, error)
+	Close()
+}
+
+// Imported public key, used by one goroutine at a time.
+type Verifier interface {
+	// Check of the signature of CryptoAPI of the data or of the hash value:
+	// the error wraps csperr.ErrBadSignature if the signature is incorrect.
+	Verify(op string, data, sign []byte, digest bool) error
+	Close()
+}
@@ -0,0 +1,350 @@
+// Test double of CryptoPro CSP for the unit tests of the packages
+// without the CSP installation and the hardware: the containers with
+// the passwords (PIN) of the store of the software backend
+// (soft.Containers) kept in the memory of the test, key blobs, hashing,
+// signing and random numbers of the software backend.
+//
+// The fake tests the Go side only: the mapping of the errors of the C
+// functions to csperr.Error, the sentinels and the handling of the
+// failures by the packages. The C code (gost.c) is never run. The steps
+// of the operations are written by hand after the calls of the CryptoAPI
+// functions in gost.c and the return codes of the C functions; only the
+// names of the steps are checked against the sources (TestStepNames),
+// the order and the return codes must be kept in sync on changes of
+// gost.c. A failure can be injected into any step:
+//
+//	p := csptest.Install(t, &provider)
+//	p.Fail("CryptAcquireContext", csperr.NTE_BAD_KEYSET)
+//
+// The next call of CryptAcquireContext fails with the return code of the
+// C function and the error of the cgo backend: csperr.Error with the name
+// of the function, the value of GetLastError() and the sentinel.
+// The failures are checked before the operation of the software backend.
+// The errors of the state of the containers and of the keys (not found,
+// wrong password, bad signature) are the errors of the failing CryptoAPI
+// function of the steps as well.
+package csptest
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/towleeee/go-cryptopro/internal/backend"
+	"github.com/towleeee/go-cryptopro/internal/backend/soft"
+	"github.com/towleeee/go-cryptopro/internal/csperr"
+)
+
+var (
+	_ backend.Rand     = &Rand{}
+	_ backend.Cipher   = &Cipher{}
+	_ backend.Hash     = &Hash{}
+	_ backend.EphKeys  = &EphKeys{}
+	_ backend.Keys     = &Keys{}
+	_ backend.Session  = &session{}
+	_ backend.Verifier = &verifier{}
+)
+
+// Fake provider: the state of the containers and the injected failures.
+type Provider struct {
+	mtx   sync.Mutex
+	fails []failure
+	calls map[string]int
+	store *soft.Containers
+}
+
+type failure struct {
+	fn        string
+	lastError uint32
+}
+
+// CryptoAPI call of the C function: the name of PRINT_ERROR
+// and the return code on the failure.
+type step struct {
+	fn   string
+	code int
+
+	// Return code for the last error lastError only
+	// (openContainer: 1 for NTE_BAD_KEYSET).
+	lastError uint32
+	lastCode  int
+
+	// Name of the error if it is set by the caller of the failed
+	// function (CreateContainer after genKey).
+	as string
+}
+
+func New() *Provider {
+	return &Provider{
+		calls: make(map[string]int),
+		store: soft.NewContainers(),
+	}
+}
+
+// Replacement of the provider of the package (a pointer to the variable
+// of backend.Keys, EphKeys, Hash, Cipher or Rand) by the fake until the
+// end of the test.
+func Install(t testing.TB, provider interface{}) *Provider {
+	t.Helper()
+
+	p := New()
+	switch v := provider.(type) {
+	case *backend.Keys:
+		saved := *v
+		*v = p.Keys()
+		t.Cleanup(func() { *v = saved })
+	case *backend.EphKeys:
+		saved := *v
+		*v = p.EphKeys()
+		t.Cleanup(func() { *v = saved })
+	case *backend.Hash:
+		saved := *v
+		*v = p.Hash()
+		t.Cleanup(func() { *v = saved })
+	case *backend.Cipher:
+		saved := *v
+		*v = p.Cipher()
+		t.Cleanup(func() { *v = saved })
+	case *backend.Rand:
+		saved := *v
+		*v = p.Rand()
+		t.Cleanup(func() { *v = saved })
+	default:
+		t.Fatalf("test failed: provider of type %T", provider)
+	}
+	return p
+}
+
+// Failure of the next call of the function fn with the value of
+// GetLastError() lastError. The function is the CryptoAPI function
+// ("CryptAcquireContext") or the name of the error of the C function
+// ("SignHash: CryptAcquireContext", "signData: CryptSignHash (2)").
+// The failures of the same function happen in the order of Fail.
+func (p *Provider) Fail(fn string, lastError uint32) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	p.fails = append(p.fails, failure{fn: fn, lastError: lastError})
+}
+
+// Number of the injected failures which have not happened yet.
+func (p *Provider) Pending() int {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	return len(p.fails)
+}
+
+// Number of the calls of the function fn (the same names as Fail)
+// made by the operations up to the failed call.
+func (p *Provider) Calls(fn string) int {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	return p.calls[fn]
+}
+
+// Removal of the injected failures and the counters of the calls,
+// the containers are kept.
+func (p *Provider) Reset() {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	p.fails = nil
+	p.calls = make(map[string]int)
+}
+
+// Calls of the steps of the operation op of the C function of the kind,
+// the error of the first step with the injected failure.
+func (p *Provider) call(op string, kind csperr.Kind, steps ...step) error {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	for _, s := range steps {
+		p.calls[s.fn]++
+		p.calls[apiName(s.fn)]++
+
+		for i, f := range p.fails {
+			if f.fn != s.fn && f.fn != apiName(s.fn) {
+				continue
+			}
+			p.fails = append(p.fails[:i], p.fails[i+1:]...)
+			return s.error(op, kind, f.lastError)
+		}
+	}
+	return nil
+}
+
+func (s step) error(op string, kind csperr.Kind, lastError uint32) error {
+	code := s.code
+	if s.lastError != 0 && s.lastError == lastError {
+		code = s.lastCode
+	}
+	fn := s.fn
+	if s.as != "" {
+		fn = s.as
+	}
+
+	err := csperr.New(op, code, fn, lastError, csperr.CodeSentinel(kind, code))
+	csperr.Log(err)
+	return err
+}
+
+// Error of the state of the containers or of the keys (the sentinels of
+// csperr, soft.ErrNoKey) as the error of the first step of the CryptoAPI
+// function failing in CSP, other errors are returned as is.
+func (p *Provider) stateError(op string, kind csperr.Kind, err error, steps []step) error {
+	fns, lastError := stateFailure(err)
+	for _, fn := range fns {
+		for _, s := range steps {
+			if apiName(s.fn) == fn && (s.lastError == 0 || s.lastError == lastError) {
+				return s.error(op, kind, lastError)
+			}
+		}
+	}
+	return err
+}
+
+// CryptoAPI functions and the value of GetLastError() of CSP
+// for the error of the state.
+func stateFailure(err error) ([]string, uint32) {
+	switch {
+	case errors.Is(err, csperr.ErrBadKeyset):
+		return []string{"CryptAcquireContext"}, csperr.NTE_BAD_KEYSET
+	case errors.Is(err, csperr.ErrContainerExists):
+		return []string{"CryptAcquireContext"}, csperr.NTE_EXISTS
+	case errors.Is(err, csperr.ErrWrongPassword):
+		return []string{"CryptSetProvParam"}, csperr.SCARD_W_WRONG_CHV
+	case errors.Is(err, soft.ErrNoKey):
+		return []string{"CryptGetUserKey", "CryptSignHash"}, csperr.NTE_NO_KEY
+	case errors.Is(err, csperr.ErrBadKey):
+		return []string{"CryptImportKey"}, csperr.NTE_BAD_PUBLIC_KEY
+	case errors.Is(err, csperr.ErrBadSignature):
+		return []string{"CryptVerifySignature"}, csperr.NTE_BAD_SIGNATURE
+	default:
+		return nil, 0
+	}
+}
+
+// CryptoAPI function of the name of the error:
+// "signData: CryptSignHash (2)" - "CryptSignHash".
+func apiName(fn string) string {
+	if i := strings.Index(fn, ": "); i >= 0 {
+		fn = fn[i+2:]
+	}
+	if i := strings.Index(fn, " ("); i >= 0 {
+		fn = fn[:i]
+	}
+	return fn
+}
+
+/*
+ * RAND
+ */
+
+type Rand struct {
+	p *Provider
+}
+
+func (p *Provider) Rand() *Rand {
+	return &Rand{p: p}
+}
+
+func (r *Rand) Rand(op string, output []byte) error {
+	err := r.p.call(op, csperr.KindCall,
+		step{fn: "Rand: CryptAcquireContext", code: -1},
+		step{fn: "Rand: CryptGenRandom", code: -2},
+	)
+	if err != nil {
+		return err
+	}
+	return soft.Rand{}.Rand(op, output)
+}
+
+/*
+ * CIPHER
+ */
+
+type Cipher struct {
+	p *Provider
+}
+
+func (p *Provider) Cipher() *Cipher {
+	return &Cipher{p: p}
+}
+
+func (c *Cipher) Encrypt(op string, data, key, iv []byte) ([]byte, error) {
+	err := c.p.call(op, csperr.KindCall,
+		step{fn: "Encrypt: CryptAcquireContext", code: -1},
+		step{fn: "Encrypt: CryptCreateHash", code: -2},
+		step{fn: "Encrypt: CryptHashData", code: -3},
+		step{fn: "Encrypt: CryptDeriveKey", code: -4},
+		step{fn: "Encrypt: CryptSetKeyParam (1)", code: -5},
+		step{fn: "Encrypt: CryptSetKeyParam (2)", code: -6},
+		step{fn: "Encrypt: CryptEncrypt", code: -7},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return soft.Cipher{}.Encrypt(op, data, key, iv)
+}
+
+/*
+ * HASH
+ */
+
+type Hash struct {
+	p *Provider
+}
+
+func (p *Provider) Hash() *Hash {
+	return &Hash{p: p}
+}
+
+func (h *Hash) NewHash(op string, prov byte) ([]byte, error) {
+	err := h.p.call(op, csperr.KindCall, append(newHashSteps(), readStateSteps()...)...)
+	if err != nil {
+		return nil, err
+	}
+	return soft.Hash{}.NewHash(op, prov)
+}
+
+func (h *Hash) WriteHash(op string, prov byte, state, data []byte) ([]byte, error) {
+	err := h.p.call(op, csperr.KindCall, append(loadStateSteps(), readStateSteps()...)...)
+	if err != nil {
+		return nil, err
+	}
+	return soft.Hash{}.WriteHash(op, prov, state, data)
+}
+
+func (h *Hash) SumHash(op string, prov byte, state, data []byte) ([]byte, error) {
+	err := h.p.call(op, csperr.KindCall, append(loadStateSteps(),
+		step{fn: "ReadHash: CryptGetHashParam", code: -1},
+	)...)
+	if err != nil {
+		return nil, err
+	}
+	return soft.Hash{}.SumHash(op, prov, state, data)
+}
+
+func newHashSteps() []step {
+	return []step{
+		{fn: "NewHash: CryptAcquireContext", code: -1},
+		{fn: "NewHash: CryptCreateHash", code: -2},
+	}
+}
+
+func loadStateSteps() []step {
+	return append(newHashSteps(),
+		step{fn: "WriteStateHash: CryptSetHashParam", code: -1},
+		step{fn: "WriteHash: CryptHashData", code: -1},
+	)
+}
+
+func readStateSteps() []step {
+	return []step{
+		{fn: "ReadStateHash: CryptGetHashParam (1)", code: -1},
+		{fn: "ReadStateHash: CryptGetHashParam (2)", code: -2},
+	}
+}
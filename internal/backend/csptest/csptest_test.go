@@ -0,0 +1,328 @@
+// go test -v -bench=. -benchtime=100x
+package csptest
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/towleeee/go-cryptopro/internal/backend"
+	"github.com/towleeee/go-cryptopro/internal/backend/soft"
+	"github.com/towleeee/go-cryptopro/internal/csperr"
+	"github.com/towleeee/go-cryptopro/internal/keyblob"
+)
+
+func TestFail(t *testing.T) {
+	p := New()
+	keys := p.Keys()
+
+	if err := keys.CreateContainer("gen", keyblob.K256, "user", "pass", 0x1, true, "", ""); err != nil {
+		t.Errorf("test failed: %s", err)
+		return
+	}
+
+	tests := []struct {
+		fn        string
+		lastError uint32
+		code      int
+		errFn     string
+		sentinel  error
+	}{
+		{"CryptAcquireContext", csperr.NTE_BAD_KEYSET, 1, "openContainer: CryptAcquireContext", csperr.ErrBadKeyset},
+		{"CryptAcquireContext", csperr.NTE_FAIL, -1, "openContainer: CryptAcquireContext", nil},
+		{"CryptSetProvParam", csperr.SCARD_W_WRONG_CHV, 2, "openContainer: CryptSetProvParam", csperr.ErrWrongPassword},
+		{"openContainer: CryptSetProvParam", csperr.NTE_FAIL, 2, "openContainer: CryptSetProvParam", csperr.ErrWrongPassword},
+	}
+	for _, v := range tests {
+		p.Fail(v.fn, v.lastError)
+
+		err := keys.CheckContainer("check", keyblob.K256, "user", "pass")
+		var cerr *csperr.Error
+		if !errors.As(err, &cerr) {
+			t.Errorf("test failed: %s: %v", v.fn, err)
+			return
+		}
+		if cerr.Code != v.code || cerr.Func != v.errFn || cerr.LastError != v.lastError {
+			t.Errorf("test failed: %s: %v", v.fn, err)
+			return
+		}
+		if v.sentinel != nil && !errors.Is(err, v.sentinel) {
+			t.Errorf("test failed: %s: sentinel: %v", v.fn, err)
+			return
+		}
+		if p.Pending() != 0 {
+			t.Errorf("test failed: %s: pending failure", v.fn)
+			return
+		}
+	}
+
+	if err := keys.CheckContainer("check", keyblob.K256, "user", "pass"); err != nil {
+		t.Errorf("test failed: failure is not removed: %s", err)
+		return
+	}
+}
+
+func TestInstall(t *testing.T) {
+	var provider backend.Rand = soft.Rand{}
+
+	t.Run("fake", func(t *testing.T) {
+		p := Install(t, &provider)
+		p.Fail("CryptGenRandom", csperr.NTE_FAIL)
+		if err := provider.Rand("rand", make([]byte, 8)); err == nil {
+			t.Errorf("test failed: provider is not replaced")
+		}
+	})
+
+	if _, ok := provider.(soft.Rand); !ok {
+		t.Errorf("test failed: provider is not restored")
+		return
+	}
+}
+
+func TestFailOrder(t *testing.T) {
+	p := New()
+	keys := p.Keys()
+	msg := []byte("hello, world!")
+
+	if err := keys.CreateContainer("gen", keyblob.K256, "user", "pass", 0x1, true, "", ""); err != nil {
+		t.Errorf("test failed: %s", err)
+		return
+	}
+
+	p.Fail("CryptSignHash", csperr.NTE_BAD_KEY)
+	p.Fail("signData: CryptSignHash (2)", csperr.NTE_FAIL)
+
+	_, err := keys.Sign("sign", keyblob.K256, "user", "pass", 2, msg, false)
+	var cerr *csperr.Error
+	if !errors.As(err, &cerr) || cerr.Func != "signData: CryptSignHash (1)" || !errors.Is(err, csperr.ErrBadKey) {
+		t.Errorf("test failed: first failure: %v", err)
+		return
+	}
+	_, err = keys.Sign("sign", keyblob.K256, "user", "pass", 2, msg, false)
+	if !errors.As(err, &cerr) || cerr.Func != "signData: CryptSignHash (2)" || cerr.Code != -1 {
+		t.Errorf("test failed: second failure: %v", err)
+		return
+	}
+	if _, err = keys.Sign("sign", keyblob.K256, "user", "pass", 2, msg, false); err != nil {
+		t.Errorf("test failed: %s", err)
+		return
+	}
+}
+
+// The errors of the state of the containers are the errors of CSP.
+func TestStateErrors(t *testing.T) {
+	p := New()
+	keys := p.Keys()
+	msg := []byte("hello, world!")
+
+	if err := keys.CreateContainer("gen", keyblob.K256, "user", "pass", 0x1, true, "", ""); err != nil {
+		t.Errorf("test failed: %s", err)
+		return
+	}
+
+	tests := []struct {
+		err       error
+		code      int
+		errFn     string
+		lastError uint32
+		sentinel  error
+	}{
+		{
+			keys.CreateContainer("gen", keyblob.K256, "user", "pass", 0x1, true, "", ""),
+			1, "CreateContainer: CryptAcquireContext", csperr.NTE_EXISTS, csperr.ErrContainerExists,
+		},
+		{
+			keys.CheckContainer("check", keyblob.K256, "none", "pass"),
+			1, "openContainer: CryptAcquireContext", csperr.NTE_BAD_KEYSET, csperr.ErrBadKeyset,
+		},
+		{
+			keys.CheckContainer("check", keyblob.K256, "user", "wrong"),
+			2, "openContainer: CryptSetProvParam", csperr.SCARD_W_WRONG_CHV, csperr.ErrWrongPassword,
+		},
+		{
+			keys.CopyContainer("copy", keyblob.K256, "user", "pass", "user", "pass"),
+			3, "CopyContainer: CryptAcquireContext", csperr.NTE_EXISTS, csperr.ErrContainerExists,
+		},
+		{
+			func() error {
+				_, err := keys.Sign("sign", keyblob.K256, "user", "pass", 1, msg, false)
+				return err
+			}(),
+			-1, "signData: CryptSignHash (1)", csperr.NTE_NO_KEY, nil,
+		},
+		{
+			keys.CheckPubKey("check", keyblob.K256, []byte{1, 2, 3}),
+			-2, "ImportPublicKey: CryptImportKey", csperr.NTE_BAD_PUBLIC_KEY, csperr.ErrBadKey,
+		},
+	}
+	for i, v := range tests {
+		var cerr *csperr.Error
+		if !errors.As(v.err, &cerr) || cerr.Code != v.code || cerr.Func != v.errFn || cerr.LastError != v.lastError {
+			t.Errorf("test failed: %d: %v", i, v.err)
+			return
+		}
+		if v.sentinel != nil && !errors.Is(v.err, v.sentinel) {
+			t.Errorf("test failed: %d: sentinel: %v", i, v.err)
+			return
+		}
+	}
+
+	pub, _ := keys.PubKey("pub key", keyblob.K256, "user", "pass", 2)
+	sign, _ := keys.Sign("sign", keyblob.K256, "user", "pass", 2, msg, false)
+	v, err := keys.ImportPubKey("import", keyblob.K256, pub)
+	if err != nil {
+		t.Errorf("test failed: %s", err)
+		return
+	}
+	defer v.Close()
+	sign[0] ^= 1
+	err = v.Verify("verify", msg, sign, false)
+	var cerr *csperr.Error
+	if !errors.As(err, &cerr) || cerr.Code != 1 || cerr.Func != "verifyData: CryptVerifySignature" ||
+		!errors.Is(err, csperr.ErrBadSignature) {
+		t.Errorf("test failed: verify: %v", err)
+		return
+	}
+}
+
+func TestCalls(t *testing.T) {
+	p := New()
+	keys := p.Keys()
+	msg := []byte("hello, world!")
+
+	if err := keys.CreateContainer("gen", keyblob.K256, "user", "pass", 0x1, true, "", ""); err != nil {
+		t.Errorf("test failed: %s", err)
+		return
+	}
+	p.Reset()
+
+	s, err := keys.OpenSession("session", keyblob.K256, "user", "pass", 2)
+	if err != nil {
+		t.Errorf("test failed: %s", err)
+		return
+	}
+	defer s.Close()
+
+	for i := 0; i < 3; i++ {
+		if _, err := s.Sign("sign", msg, false); err != nil {
+			t.Errorf("test failed: %s", err)
+			return
+		}
+	}
+	if n := p.Calls("CryptAcquireContext"); n != 1 {
+		t.Errorf("test failed: CryptAcquireContext calls %d", n)
+		return
+	}
+	if n := p.Calls("signData: CryptSignHash (2)"); n != 3 {
+		t.Errorf("test failed: CryptSignHash calls %d", n)
+		return
+	}
+}
+
+func TestFailBackends(t *testing.T) {
+	p := New()
+
+	p.Fail("CryptGenRandom", csperr.NTE_FAIL)
+	if err := p.Rand().Rand("rand", make([]byte, 8)); err == nil {
+		t.Errorf("test failed: rand")
+		return
+	}
+
+	p.Fail("CryptEncrypt", csperr.NTE_BAD_DATA)
+	if _, err := p.Cipher().Encrypt("encrypt", []byte("data"), []byte("key"), make([]byte, 16)); err == nil {
+		t.Errorf("test failed: encrypt")
+		return
+	}
+
+	state, err := p.Hash().NewHash("new hash", keyblob.K256)
+	if err != nil {
+		t.Errorf("test failed: %s", err)
+		return
+	}
+	p.Fail("CryptHashData", csperr.NTE_BAD_LEN)
+	if _, err := p.Hash().SumHash("sum hash", keyblob.K256, state, []byte("data")); err == nil {
+		t.Errorf("test failed: sum hash")
+		return
+	}
+
+	priv, err := p.EphKeys().GenPrivKey("gen", keyblob.K256)
+	if err != nil {
+		t.Errorf("test failed: %s", err)
+		return
+	}
+	p.Fail("ImportPrivateKey: CryptImportKey", csperr.NTE_BAD_DATA)
+	if err := p.EphKeys().CheckPrivKey("load", keyblob.K256, priv); !errors.Is(err, csperr.ErrBadKey) {
+		t.Errorf("test failed: import private key: %v", err)
+		return
+	}
+}
+
+// The names of the steps are the names of the errors of the C functions
+// (PRINT_ERROR, SetCspError of gost.c), the steps follow the sources.
+func TestStepNames(t *testing.T) {
+	files, err := filepath.Glob("../../../*/gost.c")
+	if err != nil || len(files) == 0 {
+		t.Errorf("test failed: sources of gost.c: %v", err)
+		return
+	}
+	re := regexp.MustCompile(`(?:PRINT_ERROR|SetCspError)\("([^"]+)"`)
+	names := make(map[string]bool)
+	for _, f := range files {
+		src, err := os.ReadFile(f)
+		if err != nil {
+			t.Errorf("test failed: %s", err)
+			return
+		}
+		for _, m := range re.FindAllStringSubmatch(string(src), -1) {
+			names[m[1]] = true
+		}
+	}
+
+	p := New()
+	keys := p.Keys()
+	msg := []byte("hello, world!")
+	digest := make([]byte, 32)
+
+	keys.CreateContainer("gen", keyblob.K256, "user", "pass", 0x3, true, "1.2.643.2.2.35.1", "1.2.643.7.1.1.2.2")
+	keys.CreateContainer("gen", keyblob.K256, "user2", "pass", 0x1, true, "", "")
+	keys.CopyContainer("copy", keyblob.K256, "user", "pass", "copy", "pass")
+	keys.ChangePassword("change", keyblob.K256, "copy", "pass", "new")
+	keys.DeleteContainer("delete", keyblob.K256, "copy", "new")
+	keys.EnumContainers("enum", keyblob.K256)
+	keys.ContainerKeys("keys", keyblob.K256, "user")
+	keys.Sign("sign", keyblob.K256, "user", "pass", 2, msg, false)
+	sign, _ := keys.Sign("sign", keyblob.K256, "user", "pass", 2, digest, true)
+	pub, _ := keys.PubKey("pub key", keyblob.K256, "user", "pass", 2)
+	keys.CheckPubKey("check", keyblob.K256, pub)
+	if s, err := keys.OpenSession("session", keyblob.K256, "user", "pass", 2); err == nil {
+		s.Sign("sign", msg, false)
+		s.Sign("sign", digest, true)
+		s.PubKey("pub key")
+		s.Close()
+	}
+	if v, err := keys.ImportPubKey("import", keyblob.K256, pub); err == nil {
+		v.Verify("verify", msg, sign, false)
+		v.Verify("verify", digest, sign, true)
+		v.Close()
+	}
+
+	priv, _ := p.EphKeys().GenPrivKey("gen", keyblob.K256)
+	epub, _ := p.EphKeys().PubKey("pub key", keyblob.K256, priv)
+	p.EphKeys().CheckPubKey("check", keyblob.K256, epub)
+	p.EphKeys().SharedKey("shared", keyblob.K256, priv, epub)
+
+	state, _ := p.Hash().NewHash("new hash", keyblob.K256)
+	state, _ = p.Hash().WriteHash("write hash", keyblob.K256, state, msg)
+	p.Hash().SumHash("sum hash", keyblob.K256, state, msg)
+	p.Cipher().Encrypt("encrypt", msg, make([]byte, 32), make([]byte, 16))
+	p.Rand().Rand("rand", make([]byte, 8))
+
+	for fn := range p.calls {
+		if strings.Contains(fn, ": ") && !names[fn] {
+			t.Errorf("test failed: step %q is not in gost.c", fn)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package csptest
+
+import (
+	"github.com/towleeee/go-cryptopro/internal/backend/soft"
+	"github.com/towleeee/go-cryptopro/internal/csperr"
+)
+
+/*
+ * EPHEMERAL KEYS
+ */
+
+type EphKeys struct {
+	p *Provider
+}
+
+func (p *Provider) EphKeys() *EphKeys {
+	return &EphKeys{p: p}
+}
+
+func (e *EphKeys) GenPrivKey(op string, prov byte) ([]byte, error) {
+	err := e.p.call(op, csperr.KindCall,
+		step{fn: "GeneratePrivateKey: CryptAcquireContext", code: -1},
+		step{fn: "GeneratePrivateKey: CryptGenKey", code: -1},
+		step{fn: "BytesPrivateKey: CryptCreateHash", code: -1},
+		step{fn: "BytesPrivateKey: CryptDeriveKey", code: -1},
+		step{fn: "BytesPrivateKey: CryptExportKey (1)", code: -1},
+		step{fn: "BytesPrivateKey: CryptExportKey (2)", code: -1},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return soft.EphKeys{}.GenPrivKey(op, prov)
+}
+
+func (e *EphKeys) CheckPrivKey(op string, prov byte, priv []byte) error {
+	err := e.p.call(op, csperr.KindImport, importPrivateKeySteps()...)
+	if err != nil {
+		return err
+	}
+	return soft.EphKeys{}.CheckPrivKey(op, prov, priv)
+}
+
+func (e *EphKeys) PubKey(op string, prov byte, priv []byte) ([]byte, error) {
+	err := e.p.call(op, csperr.KindImport, importPrivateKeySteps()...)
+	if err != nil {
+		return nil, err
+	}
+	err = e.p.call(op, csperr.KindCall, bytesPublicKeySteps()...)
+	if err != nil {
+		return nil, err
+	}
+	return soft.EphKeys{}.PubKey(op, prov, priv)
+}
+
+func (e *EphKeys) CheckPubKey(op string, prov byte, pub []byte) error {
+	err := e.p.call(op, csperr.KindImport, importPublicKeySteps()...)
+	if err != nil {
+		return err
+	}
+	return soft.EphKeys{}.CheckPubKey(op, prov, pub)
+}
+
+func (e *EphKeys) SharedKey(op string, prov byte, priv, pub []byte) ([]byte, error) {
+	err := e.p.call(op, csperr.KindImport, importPrivateKeySteps()...)
+	if err != nil {
+		return nil, err
+	}
+	err = e.p.call(op, csperr.KindCall,
+		step{fn: "SharedSessionKey: CryptCreateHash", code: -1},
+		step{fn: "SharedSessionKey: CryptHashData", code: -1},
+		step{fn: "SharedSessionKey: CryptDeriveKey", code: -1},
+		step{fn: "SharedSessionKey: CryptImportKey", code: -1},
+		step{fn: "SharedSessionKey: CryptSetKeyParam", code: -1},
+		step{fn: "SharedSessionKey: CryptSetKeyParam (1)", code: -1},
+		step{fn: "SharedSessionKey: CryptSetKeyParam (2)", code: -1},
+		step{fn: "BytesSessionKey: CryptExportKey (1)", code: -1},
+		step{fn: "BytesSessionKey: CryptExportKey (2)", code: -1},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return soft.EphKeys{}.SharedKey(op, prov, priv, pub)
+}
+
+func importPrivateKeySteps() []step {
+	return []step{
+		{fn: "ImportPrivateKey: CryptAcquireContext", code: -1},
+		{fn: "ImportPrivateKey: CryptCreateHash", code: -2},
+		{fn: "ImportPrivateKey: CryptDeriveKey", code: -3},
+		{fn: "ImportPrivateKey: CryptImportKey", code: -4},
+	}
+}
@@ -0,0 +1,311 @@
+package csptest
+
+import (
+	"github.com/towleeee/go-cryptopro/internal/backend"
+	"github.com/towleeee/go-cryptopro/internal/backend/soft"
+	"github.com/towleeee/go-cryptopro/internal/csperr"
+)
+
+/*
+ * CONTAINERS
+ */
+
+type Keys struct {
+	p *Provider
+}
+
+// Keys of the containers of the provider,
+// the containers are shared by all values of Keys of p.
+func (p *Provider) Keys() *Keys {
+	return &Keys{p: p}
+}
+
+func (k *Keys) CreateContainer(op string, prov byte, container, password string, keys uint32, exportable bool, paramSet, digestSet string) error {
+	if keys == 0 {
+		return step{code: -4}.error(op, csperr.KindCreate, 0)
+	}
+
+	steps := []step{
+		{fn: "CreateContainer: CryptAcquireContext", code: -1, lastError: csperr.NTE_EXISTS, lastCode: 1},
+		{fn: "CreateContainer: CryptAcquireContext", code: -1},
+		{fn: "CreateContainer: CryptSetProvParam", code: -2},
+	}
+	for _, flag := range []uint32{0x1, 0x2} {
+		if keys&flag != 0 {
+			steps = append(steps, genKeySteps(paramSet, digestSet)...)
+		}
+	}
+	err := k.p.call(op, csperr.KindCreate, steps...)
+	if err != nil {
+		return err
+	}
+	err = k.p.store.Create(prov, container, password, keys, exportable, paramSet, digestSet)
+	return k.p.stateError(op, csperr.KindCreate, err, steps)
+}
+
+func (k *Keys) CheckContainer(op string, prov byte, container, password string) error {
+	steps := openSteps()
+	err := k.p.call(op, csperr.KindContainer, steps...)
+	if err != nil {
+		return err
+	}
+	err = k.p.store.Check(prov, container, password)
+	return k.p.stateError(op, csperr.KindContainer, err, steps)
+}
+
+func (k *Keys) DeleteContainer(op string, prov byte, container, password string) error {
+	steps := append(openSteps(),
+		step{fn: "DeleteContainer: CryptAcquireContext", code: -2},
+	)
+	err := k.p.call(op, csperr.KindContainer, steps...)
+	if err != nil {
+		return err
+	}
+	err = k.p.store.Delete(prov, container, password)
+	return k.p.stateError(op, csperr.KindContainer, err, steps)
+}
+
+func (k *Keys) CopyContainer(op string, prov byte, src, srcPassword, dst, dstPassword string) error {
+	steps := append(openSteps(),
+		step{fn: "CopyContainer: CryptAcquireContext", code: -2, lastError: csperr.NTE_EXISTS, lastCode: 3},
+		step{fn: "CopyContainer: CryptSetProvParam (PP_HCRYPTPROV)", code: -3},
+		step{fn: "CopyContainer: CryptSetProvParam (PP_SIGNATURE_PIN)", code: -4},
+		step{fn: "CopyContainer: CryptSetProvParam (PP_CHANGE_PIN)", code: -5},
+	)
+	err := k.p.call(op, csperr.KindContainer, steps...)
+	if err != nil {
+		return err
+	}
+	err = k.p.store.Copy(prov, src, srcPassword, dst, dstPassword)
+	return k.p.stateError(op, csperr.KindContainer, err, steps)
+}
+
+func (k *Keys) ChangePassword(op string, prov byte, container, password, newPassword string) error {
+	steps := append(openSteps(),
+		step{fn: "ChangePassword: CryptSetProvParam", code: -2},
+	)
+	err := k.p.call(op, csperr.KindContainer, steps...)
+	if err != nil {
+		return err
+	}
+	err = k.p.store.ChangePassword(prov, container, password, newPassword)
+	return k.p.stateError(op, csperr.KindContainer, err, steps)
+}
+
+func (k *Keys) EnumContainers(op string, prov byte) ([]string, error) {
+	err := k.p.call(op, csperr.KindCall,
+		step{fn: "EnumContainers: CryptAcquireContext", code: -1},
+		step{fn: "EnumContainers: CryptGetProvParam (1)", code: -1},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return k.p.store.List(prov), nil
+}
+
+func (k *Keys) ContainerKeys(op string, prov byte, container string) (uint32, error) {
+	steps := []step{
+		{fn: "ContainerKeys: CryptAcquireContext", code: -1},
+	}
+	err := k.p.call(op, csperr.KindCall, steps...)
+	if err != nil {
+		return 0, err
+	}
+	keys, err := k.p.store.KeyPairs(prov, container)
+	if err != nil {
+		return 0, k.p.stateError(op, csperr.KindCall, err, steps)
+	}
+	return keys, nil
+}
+
+func (k *Keys) Sign(op string, prov byte, container, password string, spec uint32, data []byte, digest bool) ([]byte, error) {
+	fn := "SignMessage"
+	if digest {
+		fn = "SignHash"
+	}
+
+	steps := append([]step{
+		{fn: fn + ": CryptAcquireContext", code: -1},
+		{fn: fn + ": CryptSetProvParam", code: -1},
+	}, signSteps(digest)...)
+	err := k.p.call(op, csperr.KindCall, steps...)
+	if err != nil {
+		return nil, err
+	}
+	key, err := k.p.store.Key(prov, container, password, spec)
+	if err != nil {
+		return nil, k.p.stateError(op, csperr.KindCall, err, steps)
+	}
+	return key.Sign(data, digest)
+}
+
+func (k *Keys) PubKey(op string, prov byte, container, password string, spec uint32) ([]byte, error) {
+	steps := append([]step{
+		{fn: "OpenContainer: CryptAcquireContext", code: -1},
+		{fn: "OpenContainer: CryptSetProvParam", code: -2},
+		{fn: "OpenContainer: CryptGetUserKey", code: -3},
+	}, bytesPublicKeySteps()...)
+	err := k.p.call(op, csperr.KindCall, steps...)
+	if err != nil {
+		return nil, err
+	}
+	key, err := k.p.store.Key(prov, container, password, spec)
+	if err != nil {
+		return nil, k.p.stateError(op, csperr.KindCall, err, steps)
+	}
+	return key.PubKey(), nil
+}
+
+func (k *Keys) CheckPubKey(op string, prov byte, pub []byte) error {
+	steps := importPublicKeySteps()
+	err := k.p.call(op, csperr.KindImport, steps...)
+	if err != nil {
+		return err
+	}
+	_, err = soft.ImportPubKey(prov, pub)
+	return k.p.stateError(op, csperr.KindImport, err, steps)
+}
+
+func (k *Keys) OpenSession(op string, prov byte, container, password string, spec uint32) (backend.Session, error) {
+	steps := append(openSteps(),
+		step{fn: "OpenSession: CryptGetUserKey", code: -2},
+	)
+	err := k.p.call(op, csperr.KindContainer, steps...)
+	if err != nil {
+		return nil, err
+	}
+	key, err := k.p.store.Key(prov, container, password, spec)
+	if err != nil {
+		return nil, k.p.stateError(op, csperr.KindContainer, err, steps)
+	}
+	return &session{p: k.p, key: key}, nil
+}
+
+func (k *Keys) ImportPubKey(op string, prov byte, pub []byte) (backend.Verifier, error) {
+	steps := importPublicKeySteps()
+	err := k.p.call(op, csperr.KindImport, steps...)
+	if err != nil {
+		return nil, err
+	}
+	key, err := soft.ImportPubKey(prov, pub)
+	if err != nil {
+		return nil, k.p.stateError(op, csperr.KindImport, err, steps)
+	}
+	return &verifier{p: k.p, key: key}, nil
+}
+
+/*
+ * SESSION
+ */
+
+type session struct {
+	p   *Provider
+	key *soft.Key
+}
+
+func (s *session) PubKey(op string) ([]byte, error) {
+	err := s.p.call(op, csperr.KindCall, bytesPublicKeySteps()...)
+	if err != nil {
+		return nil, err
+	}
+	return s.key.PubKey(), nil
+}
+
+func (s *session) Sign(op string, data []byte, digest bool) ([]byte, error) {
+	err := s.p.call(op, csperr.KindCall, signSteps(digest)...)
+	if err != nil {
+		return nil, err
+	}
+	return s.key.Sign(data, digest)
+}
+
+func (s *session) Close() {}
+
+/*
+ * VERIFIER
+ */
+
+type verifier struct {
+	p   *Provider
+	key *soft.PubKey
+}
+
+func (v *verifier) Verify(op string, data, sign []byte, digest bool) error {
+	hashFn := "verifyData: CryptHashData"
+	if digest {
+		hashFn = "verifyData: CryptSetHashParam"
+	}
+
+	steps := []step{
+		{fn: "verifyData: CryptCreateHash", code: -3},
+		{fn: hashFn, code: -4},
+		{fn: "verifyData: CryptVerifySignature", code: 1},
+	}
+	err := v.p.call(op, csperr.KindVerify, steps...)
+	if err != nil {
+		return err
+	}
+	err = v.key.Verify(data, sign, digest)
+	return v.p.stateError(op, csperr.KindVerify, err, steps)
+}
+
+func (v *verifier) Close() {}
+
+/*
+ * STEPS
+ */
+
+// openContainer of gost.c: 1 - not found, 2 - wrong password.
+func openSteps() []step {
+	return []step{
+		{fn: "openContainer: CryptAcquireContext", code: -1, lastError: csperr.NTE_BAD_KEYSET, lastCode: 1},
+		{fn: "openContainer: CryptSetProvParam", code: 2},
+	}
+}
+
+// genKey of gost.c, the error is set by CreateContainer.
+func genKeySteps(paramSet, digestSet string) []step {
+	const as = "CreateContainer: CryptGenKey"
+
+	if paramSet == "" {
+		return []step{
+			{fn: "genKey: CryptGenKey", code: -3, as: as},
+		}
+	}
+	steps := []step{
+		{fn: "genKey: CryptGenKey (CRYPT_PREGEN)", code: -3, as: as},
+		{fn: "genKey: CryptSetKeyParam (KP_DHOID)", code: -3, as: as},
+	}
+	if digestSet != "" {
+		steps = append(steps, step{fn: "genKey: CryptSetKeyParam (KP_HASHOID)", code: -3, as: as})
+	}
+	return append(steps, step{fn: "genKey: CryptSetKeyParam (KP_X)", code: -3, as: as})
+}
+
+// signData of gost.c.
+func signSteps(digest bool) []step {
+	hashFn := "signData: CryptHashData"
+	if digest {
+		hashFn = "signData: CryptSetHashParam"
+	}
+	return []step{
+		{fn: "signData: CryptCreateHash", code: -1},
+		{fn: hashFn, code: -1},
+		{fn: "signData: CryptSignHash (1)", code: -1},
+		{fn: "signData: CryptSignHash (2)", code: -1},
+	}
+}
+
+func bytesPublicKeySteps() []step {
+	return []step{
+		{fn: "BytesPublicKey: CryptExportKey (1)", code: -1},
+		{fn: "BytesPublicKey: CryptExportKey (2)", code: -1},
+	}
+}
+
+func importPublicKeySteps() []step {
+	return []step{
+		{fn: "ImportPublicKey: CryptAcquireContext", code: -1},
+		{fn: "ImportPublicKey: CryptImportKey", code: -2},
+	}
+}
@@ -0,0 +1,123 @@
+// Backend of the builds without cgo and without the tag purego:
+// CryptoPro CSP can not be called, every operation fails with
+// csperr.ErrUnavailable. The software backend (internal/backend/soft)
+// keeps the containers in the memory of the process and is selected
+// only explicitly by the tag purego.
+package nocsp
+
+import (
+	"fmt"
+
+	"github.com/towleeee/go-cryptopro/internal/backend"
+	"github.com/towleeee/go-cryptopro/internal/csperr"
+)
+
+var (
+	_ backend.Rand    = Rand{}
+	_ backend.Cipher  = Cipher{}
+	_ backend.Hash    = Hash{}
+	_ backend.EphKeys = EphKeys{}
+	_ backend.Keys    = Keys{}
+)
+
+func unavailable(op string) error {
+	return fmt.Errorf("error: %s: %w", op, csperr.ErrUnavailable)
+}
+
+type Rand struct{}
+
+func (Rand) Rand(op string, output []byte) error {
+	return unavailable(op)
+}
+
+type Cipher struct{}
+
+func (Cipher) Encrypt(op string, data, key, iv []byte) ([]byte, error) {
+	return nil, unavailable(op)
+}
+
+type Hash struct{}
+
+func (Hash) NewHash(op string, prov byte) ([]byte, error) {
+	return nil, unavailable(op)
+}
+
+func (Hash) WriteHash(op string, prov byte, state, data []byte) ([]byte, error) {
+	return nil, unavailable(op)
+}
+
+func (Hash) SumHash(op string, prov byte, state, data []byte) ([]byte, error) {
+	return nil, unavailable(op)
+}
+
+type EphKeys struct{}
+
+func (EphKeys) GenPrivKey(op string, prov byte) ([]byte, error) {
+	return nil, unavailable(op)
+}
+
+func (EphKeys) CheckPrivKey(op string, prov byte, priv []byte) error {
+	return unavailable(op)
+}
+
+func (EphKeys) PubKey(op string, prov byte, priv []byte) ([]byte, error) {
+	return nil, unavailable(op)
+}
+
+func (EphKeys) CheckPubKey(op string, prov byte, pub []byte) error {
+	return unavailable(op)
+}
+
+func (EphKeys) SharedKey(op string, prov byte, priv, pub []byte) ([]byte, error) {
+	return nil, unavailable(op)
+}
+
+type Keys struct{}
+
+func (Keys) CreateContainer(op string, prov byte, container, password string, keys uint32, exportable bool, paramSet, digestSet string) error {
+	return unavailable(op)
+}
+
+func (Keys) CheckContainer(op string, prov byte, container, password string) error {
+	return unavailable(op)
+}
+
+func (Keys) DeleteContainer(op string, prov byte, container, password string) error {
+	return unavailable(op)
+}
+
+func (Keys) CopyContainer(op string, prov byte, src, srcPassword, dst, dstPassword string) error {
+	return unavailable(op)
+}
+
+func (Keys) ChangePassword(op string, prov byte, container, password, newPassword string) error {
+	return unavailable(op)
+}
+
+func (Keys) EnumContainers(op string, prov byte) ([]string, error) {
+	return nil, unavailable(op)
+}
+
+func (Keys) ContainerKeys(op string, prov byte, container string) (uint32, error) {
+	return 0, unavailable(op)
+}
+
+func (Keys) Sign(op string, prov byte, container, password string, spec uint32, data []byte, digest bool) ([]byte, error) {
+	return nil, unavailable(op)
+}
+
+func (Keys) PubKey(op string, prov byte, container, password string, spec uint32) ([]byte, error) {
+	return nil, unavailable(op)
+}
+
+func (Keys) CheckPubKey(op string, prov byte, pub []byte) error {
+	return unavailable(op)
+}
+
+func (Keys) OpenSession(op string, prov byte, container, password string, spec uint32) (backend.Session, error) {
+	return nil, unavailable(op)
+}
+
+func (Keys) ImportPubKey(op string, prov byte, pub []byte) (backend.Verifier, error) {
+	return nil, unavailable(op)
+}
@@ -0,0 +1,24 @@
+// go test -v -bench=. -benchtime=100x
+package nocsp
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/towleeee/go-cryptopro/internal/csperr"
+)
+
+func TestUnavailable(t *testing.T) {
+	if err := (Keys{}).CreateContainer("gen", 80, "user", "pass", 0x1, true, "", ""); !errors.Is(err, csperr.ErrUnavailable) {
+		t.Errorf("test failed: create container: %v", err)
+		return
+	}
+	if _, err := (Hash{}).NewHash("new hash", 80); !errors.Is(err, csperr.ErrUnavailable) {
+		t.Errorf("test failed: new hash: %v", err)
+		return
+	}
+	if err := (Rand{}).Rand("rand", make([]byte, 8)); !errors.Is(err, csperr.ErrUnavailable) {
+		t.Errorf("test failed: rand: %v", err)
+		return
+	}
+}
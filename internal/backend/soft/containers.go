@@ -0,0 +1,383 @@
+package soft
+
+import (
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/towleeee/go-cryptopro/internal/csperr"
+	"github.com/towleeee/go-cryptopro/internal/keyblob"
+	"github.com/towleeee/go-cryptopro/internal/oids"
+)
+
+// Key pair of the spec is not in the container.
+var ErrNoKey = errors.New("error: key pair not found")
+
+// Reader of the containers created without a reader.
+const DefaultReader = "HDIMAGE"
+
+/*
+ * CONTAINERS
+ */
+
+type container struct {
+	prov       byte
+	reader     string
+	name       string
+	password   string
+	exportable bool
+	keys       map[uint32]*privKey
+}
+
+func (c *container) fqcn() string {
+	return fqcn(c.reader, c.name)
+}
+
+// Containers kept in the memory of the process by the reader and the
+// name, the keys are lost when the process exits. The store of Keys and
+// of the test double of CSP (internal/backend/csptest).
+//
+// The errors are not logged and wrap the sentinels of csperr
+// (ErrBadKeyset, ErrWrongPassword, ErrContainerExists, ErrBadKey,
+// ErrBadSignature) and ErrNoKey.
+type Containers struct {
+	mtx        sync.Mutex
+	containers map[string]*container
+}
+
+func NewContainers() *Containers {
+	return &Containers{
+		containers: make(map[string]*container),
+	}
+}
+
+// Creation of the container with the key pairs keys
+// (0x1 - AT_SIGNATURE, 0x2 - AT_KEYEXCHANGE), the reader is DefaultReader
+// if the name is not "\\.\READER\NAME".
+func (s *Containers) Create(prov byte, name, password string, keys uint32, exportable bool, paramSet, digestSet string) error {
+	if keys == 0 {
+		return fmt.Errorf("error: no key pairs to create")
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	reader, name := splitFQCN(name)
+	if reader == "" {
+		reader = DefaultReader
+	}
+	if _, ok := s.containers[fqcn(reader, name)]; ok {
+		return csperr.ErrContainerExists
+	}
+
+	c := &container{
+		prov:       prov,
+		reader:     reader,
+		name:       name,
+		password:   password,
+		exportable: exportable,
+		keys:       make(map[uint32]*privKey),
+	}
+	for _, v := range []struct {
+		flag uint32
+		spec uint32
+	}{
+		{0x1, AT_SIGNATURE},
+		{0x2, AT_KEYEXCHANGE},
+	} {
+		if keys&v.flag == 0 {
+			continue
+		}
+		key, err := genContainerKey(prov, v.spec, paramSet, digestSet)
+		if err != nil {
+			return err
+		}
+		c.keys[v.spec] = key
+	}
+	s.containers[c.fqcn()] = c
+	return nil
+}
+
+// Opening of the container by the password.
+func (s *Containers) Check(prov byte, name, password string) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	_, err := s.open(prov, name, password)
+	return err
+}
+
+func (s *Containers) Delete(prov byte, name, password string) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	c, err := s.open(prov, name, password)
+	if err != nil {
+		return err
+	}
+	delete(s.containers, c.fqcn())
+	return nil
+}
+
+// Copying of the container with all keys (also non-exportable, as the
+// copying of CSP) to dst with the password of dst.
+func (s *Containers) Copy(prov byte, src, srcPassword, dst, dstPassword string) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	c, err := s.open(prov, src, srcPassword)
+	if err != nil {
+		return err
+	}
+	reader, name := splitFQCN(dst)
+	if reader == "" {
+		reader = DefaultReader
+	}
+	if _, ok := s.containers[fqcn(reader, name)]; ok {
+		return csperr.ErrContainerExists
+	}
+
+	cp := &container{
+		prov:       c.prov,
+		reader:     reader,
+		name:       name,
+		password:   dstPassword,
+		exportable: c.exportable,
+		keys:       make(map[uint32]*privKey),
+	}
+	for spec, key := range c.keys {
+		cp.keys[spec] = key
+	}
+	s.containers[cp.fqcn()] = cp
+	return nil
+}
+
+func (s *Containers) ChangePassword(prov byte, name, password, newPassword string) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	c, err := s.open(prov, name, password)
+	if err != nil {
+		return err
+	}
+	c.password = newPassword
+	return nil
+}
+
+// Fully qualified names of the containers "\\.\READER\NAME".
+func (s *Containers) List(prov byte) []string {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	var list []string
+	for _, c := range s.containers {
+		if c.prov == prov {
+			list = append(list, c.fqcn())
+		}
+	}
+	sort.Strings(list)
+	return list
+}
+
+// Key pairs of the container (0x1 - AT_SIGNATURE, 0x2 - AT_KEYEXCHANGE),
+// the password is not checked.
+func (s *Containers) KeyPairs(prov byte, name string) (uint32, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	c := s.find(prov, name)
+	if c == nil {
+		return 0, csperr.ErrBadKeyset
+	}
+	var keys uint32
+	if c.keys[AT_SIGNATURE] != nil {
+		keys |= 0x1
+	}
+	if c.keys[AT_KEYEXCHANGE] != nil {
+		keys |= 0x2
+	}
+	return keys, nil
+}
+
+// Key pair of the spec of the container opened by the password.
+func (s *Containers) Key(prov byte, name, password string, spec uint32) (*Key, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	c, err := s.open(prov, name, password)
+	if err != nil {
+		return nil, err
+	}
+	if c.keys[spec] == nil {
+		return nil, ErrNoKey
+	}
+	return &Key{key: c.keys[spec], exportable: c.exportable}, nil
+}
+
+// Opening of the container by the password as openContainer of gost.c.
+func (s *Containers) open(prov byte, name, password string) (*container, error) {
+	c := s.find(prov, name)
+	if c == nil {
+		return nil, csperr.ErrBadKeyset
+	}
+	if c.password != password {
+		return nil, csperr.ErrWrongPassword
+	}
+	return c, nil
+}
+
+// Container by the name "\\.\READER\NAME" or by the name only,
+// the first in the order of the readers, nil if not found.
+func (s *Containers) find(prov byte, name string) *container {
+	reader, name := splitFQCN(name)
+	if reader != "" {
+		c, ok := s.containers[fqcn(reader, name)]
+		if !ok || c.prov != prov {
+			return nil
+		}
+		return c
+	}
+
+	var found *container
+	for _, c := range s.containers {
+		if c.name != name || c.prov != prov {
+			continue
+		}
+		if found == nil || c.reader < found.reader {
+			found = c
+		}
+	}
+	return found
+}
+
+func fqcn(reader, name string) string {
+	return fmt.Sprintf(`\\.\%s\%s`, reader, name)
+}
+
+// Splitting "\\.\READER\NAME" into the reader and the name.
+func splitFQCN(fqcn string) (reader, name string) {
+	rest := strings.TrimPrefix(fqcn, `\\.\`)
+	if rest == fqcn {
+		return "", fqcn
+	}
+	i := strings.Index(rest, `\`)
+	if i < 0 {
+		return "", fqcn
+	}
+	return rest[:i], rest[i+1:]
+}
+
+// Key pair of the container on the parameter set of CreateContainer,
+// the provider default parameters if paramSet is empty.
+func genContainerKey(prov byte, spec uint32, paramSet, digestSet string) (*privKey, error) {
+	alg := keyblob.CALG_GR3410_12_256
+	if prov == keyblob.K512 {
+		alg = keyblob.CALG_GR3410_12_512
+	}
+	if spec == AT_KEYEXCHANGE {
+		alg = keyblob.CALG_DH_GR3410_12_256_SF
+		if prov == keyblob.K512 {
+			alg = keyblob.CALG_DH_GR3410_12_512_SF
+		}
+	}
+
+	if paramSet == "" {
+		switch {
+		case prov == keyblob.K512:
+			return genKey(prov, alg, oids.Tc26Gost512A, oids.GostR3411_12_512)
+		case spec == AT_KEYEXCHANGE:
+			return genKey(prov, alg, oids.CryptoProXchA, oids.GostR3411_12_256)
+		default:
+			return genKey(prov, alg, oids.CryptoProA, oids.GostR3411_12_256)
+		}
+	}
+
+	params, err := parseOID(paramSet)
+	if err != nil {
+		return nil, err
+	}
+	var digest asn1.ObjectIdentifier
+	if digestSet != "" {
+		if digest, err = parseOID(digestSet); err != nil {
+			return nil, err
+		}
+	}
+	return genKey(prov, alg, params, digest)
+}
+
+// Parsing of the dotted form of the object identifier.
+func parseOID(s string) (asn1.ObjectIdentifier, error) {
+	var oid asn1.ObjectIdentifier
+	for _, v := range strings.Split(s, ".") {
+		var n int
+		if _, err := fmt.Sscanf(v, "%d", &n); err != nil {
+			return nil, fmt.Errorf("error: object identifier %s", s)
+		}
+		oid = append(oid, n)
+	}
+	return oid, nil
+}
+
+/*
+ * KEYS
+ */
+
+// Key pair of the container.
+type Key struct {
+	key        *privKey
+	exportable bool
+}
+
+// PUBLICKEYBLOB without the first byte prov.
+func (k *Key) PubKey() []byte {
+	return k.key.pubBlob()
+}
+
+// The key is created exportable (CRYPT_EXPORTABLE). The private key is
+// never exported by the backends, the flag is kept by the copies.
+func (k *Key) Exportable() bool {
+	return k.exportable
+}
+
+// Signature of signData of gost.c of the data or of the hash value.
+func (k *Key) Sign(data []byte, digest bool) ([]byte, error) {
+	hash, err := digestOf(k.key.prov, data, digest)
+	if err != nil {
+		return nil, err
+	}
+	return k.key.sign(hash)
+}
+
+// Public key of PUBLICKEYBLOB.
+type PubKey struct {
+	key *pubKey
+}
+
+// Import of PUBLICKEYBLOB without the first byte prov,
+// the error wraps csperr.ErrBadKey.
+func ImportPubKey(prov byte, pub []byte) (*PubKey, error) {
+	if keyblob.PointSize(prov) < 0 {
+		return nil, fmt.Errorf("error: prov type %d: %w", prov, csperr.ErrBadKey)
+	}
+	key, _, err := parsePubKey(prov, pub)
+	if err != nil {
+		return nil, csperr.ErrBadKey
+	}
+	return &PubKey{key: key}, nil
+}
+
+// Verification of the signature of the data or of the hash value,
+// csperr.ErrBadSignature for the incorrect signature.
+func (k *PubKey) Verify(data, sign []byte, digest bool) error {
+	hash, err := digestOf(k.key.prov, data, digest)
+	if err != nil {
+		return err
+	}
+	if !k.key.verify(hash, sign) {
+		return csperr.ErrBadSignature
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+package soft
+
+import (
+	"bytes"
+	"encoding/asn1"
+	"fmt"
+	"math/big"
+
+	"github.com/towleeee/go-cryptopro/internal/backend"
+	"github.com/towleeee/go-cryptopro/internal/csperr"
+	"github.com/towleeee/go-cryptopro/internal/curves"
+	"github.com/towleeee/go-cryptopro/internal/keyblob"
+	"github.com/towleeee/go-cryptopro/internal/oids"
+	"github.com/towleeee/go-cryptopro/internal/streebog"
+)
+
+var (
+	_ backend.EphKeys = EphKeys{}
+)
+
+/*
+ * EPHEMERAL KEYS
+ */
+
+// Private key blob: {4: magic, N: DER params padded by zeros, M: little-endian d},
+// the same length as the PRIVATEKEYBLOB of CSP.
+const (
+	ephMagic = "GEK\x01"
+
+	ephPrivSize256 = 117
+	ephPrivSize512 = 152
+)
+
+// Ephemeral exchange keys of VKO GOST R 34.10-2012 (RFC 7836) with UKM = 1.
+type EphKeys struct{}
+
+func (EphKeys) GenPrivKey(op string, prov byte) ([]byte, error) {
+	paramSet, digestSet := ephParams(prov)
+	if paramSet == nil {
+		return nil, softError(op, "GenPrivKey", fmt.Errorf("error: prov type %d", prov))
+	}
+	key, err := genKey(prov, keyblob.EphemAlgID(prov), paramSet, digestSet)
+	if err != nil {
+		return nil, softError(op, "GenPrivKey", err)
+	}
+	return marshalEphKey(key), nil
+}
+
+func (EphKeys) CheckPrivKey(op string, prov byte, priv []byte) error {
+	_, err := loadEphKey(op, prov, priv)
+	return err
+}
+
+func (EphKeys) PubKey(op string, prov byte, priv []byte) ([]byte, error) {
+	key, err := loadEphKey(op, prov, priv)
+	if err != nil {
+		return nil, err
+	}
+	return key.pubBlob(), nil
+}
+
+func (EphKeys) CheckPubKey(op string, prov byte, pub []byte) error {
+	_, err := loadEphPubKey(op, prov, pub)
+	return err
+}
+
+func (EphKeys) SharedKey(op string, prov byte, priv, pub []byte) ([]byte, error) {
+	key, err := loadEphKey(op, prov, priv)
+	if err != nil {
+		return nil, err
+	}
+	pubkey, err := loadEphPubKey(op, prov, pub)
+	if err != nil {
+		return nil, err
+	}
+	if pubkey.curve != key.curve {
+		return nil, softError(op, "SharedKey", csperr.ErrBadKey)
+	}
+
+	// K = cofactor * UKM * d * Q.
+	k := new(big.Int).Mul(key.d, big.NewInt(key.curve.Cofactor))
+	x, y := key.curve.ScalarMult(pubkey.x, pubkey.y, k)
+	return streebog.Sum(streebog.Size256, key.curve.Marshal(x, y)), nil
+}
+
+// Parameters of the ephemeral keys of CSP.
+func ephParams(prov byte) (paramSet, digestSet asn1.ObjectIdentifier) {
+	switch prov {
+	case keyblob.K256:
+		return oids.CryptoProXchA, oids.GostR3411_12_256
+	case keyblob.K512:
+		return oids.Tc26Gost512A, oids.GostR3411_12_512
+	default:
+		return nil, nil
+	}
+}
+
+func ephPrivSize(prov byte) int {
+	if prov == keyblob.K512 {
+		return ephPrivSize512
+	}
+	return ephPrivSize256
+}
+
+func marshalEphKey(key *privKey) []byte {
+	params, _ := asn1.Marshal(key.params)
+
+	out := make([]byte, ephPrivSize(key.prov))
+	copy(out, ephMagic)
+	copy(out[len(ephMagic):], params)
+
+	d := key.d.FillBytes(make([]byte, key.curve.Size))
+	for i := range d {
+		out[len(out)-1-i] = d[i]
+	}
+	return out
+}
+
+func loadEphKey(op string, prov byte, priv []byte) (*privKey, error) {
+	paramSet, _ := ephParams(prov)
+	if paramSet == nil {
+		return nil, softError(op, "loadEphKey", csperr.ErrBadKey)
+	}
+	if len(priv) != ephPrivSize(prov) || !bytes.HasPrefix(priv, []byte(ephMagic)) {
+		return nil, softError(op, "loadEphKey", csperr.ErrBadKey)
+	}
+
+	var params keyblob.Params
+	if _, err := asn1.Unmarshal(priv[len(ephMagic):], &params); err != nil {
+		return nil, softError(op, "loadEphKey", csperr.ErrBadKey)
+	}
+	curve := curves.ByParamSet(params.ParamSet)
+	if curve == nil || !params.ParamSet.Equal(paramSet) {
+		return nil, softError(op, "loadEphKey", csperr.ErrBadKey)
+	}
+
+	draw := priv[len(priv)-curve.Size:]
+	d := make([]byte, len(draw))
+	for i := range draw {
+		d[len(d)-1-i] = draw[i]
+	}
+	key := &privKey{
+		prov:   prov,
+		alg:    keyblob.EphemAlgID(prov),
+		params: params,
+		curve:  curve,
+		d:      new(big.Int).SetBytes(d),
+	}
+	if key.d.Sign() == 0 || key.d.Cmp(curve.Q) >= 0 {
+		return nil, softError(op, "loadEphKey", csperr.ErrBadKey)
+	}
+	return key, nil
+}
+
+func loadEphPubKey(op string, prov byte, pub []byte) (*pubKey, error) {
+	key, err := ImportPubKey(prov, pub)
+	if err != nil {
+		return nil, softError(op, "loadEphPubKey", err)
+	}
+	return key.key, nil
+}
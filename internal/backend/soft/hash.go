@@ -0,0 +1,70 @@
+package soft
+
+import (
+	"encoding"
+	"fmt"
+	"hash"
+
+	"github.com/towleeee/go-cryptopro/internal/backend"
+	"github.com/towleeee/go-cryptopro/internal/keyblob"
+	"github.com/towleeee/go-cryptopro/internal/streebog"
+)
+
+var (
+	_ backend.Hash = Hash{}
+)
+
+/*
+ * HASH
+ */
+
+// GOST R 34.11-2012, the state is the binary form of the streebog hash.
+type Hash struct{}
+
+func (Hash) NewHash(op string, prov byte) ([]byte, error) {
+	h, err := newHash(op, prov)
+	if err != nil {
+		return nil, err
+	}
+	return h.(encoding.BinaryMarshaler).MarshalBinary()
+}
+
+func (Hash) WriteHash(op string, prov byte, state, data []byte) ([]byte, error) {
+	h, err := loadHash(op, prov, state)
+	if err != nil {
+		return nil, err
+	}
+	h.Write(data)
+	return h.(encoding.BinaryMarshaler).MarshalBinary()
+}
+
+func (Hash) SumHash(op string, prov byte, state, data []byte) ([]byte, error) {
+	h, err := loadHash(op, prov, state)
+	if err != nil {
+		return nil, err
+	}
+	h.Write(data)
+	return h.Sum(nil), nil
+}
+
+func newHash(op string, prov byte) (hash.Hash, error) {
+	switch prov {
+	case keyblob.K256:
+		return streebog.New256(), nil
+	case keyblob.K512:
+		return streebog.New512(), nil
+	default:
+		return nil, softError(op, "newHash", fmt.Errorf("error: prov type %d", prov))
+	}
+}
+
+func loadHash(op string, prov byte, state []byte) (hash.Hash, error) {
+	h, err := newHash(op, prov)
+	if err != nil {
+		return nil, err
+	}
+	if err := h.(encoding.BinaryUnmarshaler).UnmarshalBinary(state); err != nil {
+		return nil, softError(op, "loadHash", err)
+	}
+	return h, nil
+}
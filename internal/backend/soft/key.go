@@ -0,0 +1,133 @@
+package soft
+
+import (
+	"encoding/asn1"
+	"fmt"
+	"math/big"
+
+	"github.com/towleeee/go-cryptopro/internal/curves"
+	"github.com/towleeee/go-cryptopro/internal/gost3410"
+	"github.com/towleeee/go-cryptopro/internal/keyblob"
+	"github.com/towleeee/go-cryptopro/internal/oids"
+	"github.com/towleeee/go-cryptopro/internal/streebog"
+)
+
+/*
+ * KEY PAIR
+ */
+
+// Private key d of the curve of the parameter set.
+type privKey struct {
+	prov   byte
+	alg    uint32
+	params keyblob.Params
+	curve  *curves.Curve
+	d      *big.Int
+}
+
+// Public key (x, y) of the key blob.
+type pubKey struct {
+	prov  byte
+	curve *curves.Curve
+	x, y  *big.Int
+}
+
+// New private key of the parameter set,
+// the digest set of the key blob is the default of CSP if nil.
+func genKey(prov byte, alg uint32, paramSet, digestSet asn1.ObjectIdentifier) (*privKey, error) {
+	size := oids.ParamSetSize(paramSet)
+	if (prov == keyblob.K256 && size != 256) || (prov == keyblob.K512 && size != 512) {
+		return nil, fmt.Errorf("error: parameter set %s", paramSet)
+	}
+	curve := curves.ByParamSet(paramSet)
+	if curve == nil {
+		return nil, fmt.Errorf("error: parameter set %s", paramSet)
+	}
+
+	if digestSet == nil {
+		digestSet = keyblob.DigestSet(prov)
+	}
+
+	d, err := gost3410.RandScalar(curve, Reader)
+	if err != nil {
+		return nil, err
+	}
+	return &privKey{
+		prov: prov,
+		alg:  alg,
+		params: keyblob.Params{
+			ParamSet:  paramSet,
+			DigestSet: digestSet,
+		},
+		curve: curve,
+		d:     d,
+	}, nil
+}
+
+// PUBLICKEYBLOB without the first byte prov.
+func (key *privKey) pubBlob() []byte {
+	pbytes, err := (&keyblob.PublicKey{
+		Prov:   key.prov,
+		AlgID:  key.alg,
+		Params: key.params,
+		Point:  key.curve.Marshal(key.curve.ScalarBaseMult(key.d)),
+	}).Bytes()
+	if err != nil {
+		return nil
+	}
+	return pbytes[1:]
+}
+
+// Signature of CryptoAPI of the hash value.
+func (key *privKey) sign(digest []byte) ([]byte, error) {
+	r, s, err := gost3410.Sign(key.curve, key.d, digest, Reader)
+	if err != nil {
+		return nil, err
+	}
+	return gost3410.MarshalSignature(key.curve, r, s), nil
+}
+
+// Hash value of the data for the signature of the prov.
+func digestOf(prov byte, data []byte, digest bool) ([]byte, error) {
+	size := streebog.Size256
+	if prov == keyblob.K512 {
+		size = streebog.Size512
+	}
+	if digest {
+		if len(data) != size {
+			return nil, fmt.Errorf("error: length of digest")
+		}
+		return data, nil
+	}
+	return streebog.Sum(size, data), nil
+}
+
+// Parsing of the PUBLICKEYBLOB without the first byte prov,
+// the point is checked to be on the curve and in the subgroup.
+func parsePubKey(prov byte, pub []byte) (*pubKey, *keyblob.PublicKey, error) {
+	blob, err := keyblob.Parse(append([]byte{prov}, pub...))
+	if err != nil {
+		return nil, nil, err
+	}
+	curve := curves.ByParamSet(blob.Params.ParamSet)
+	if curve == nil || 2*curve.Size != keyblob.PointSize(prov) {
+		return nil, nil, fmt.Errorf("error: parameter set %s", blob.Params.ParamSet)
+	}
+	if err := curve.CheckPoint(blob.Point); err != nil {
+		return nil, nil, err
+	}
+	x, y, err := curve.Unmarshal(blob.Point)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &pubKey{prov: prov, curve: curve, x: x, y: y}, blob, nil
+}
+
+// Check of the signature of CryptoAPI of the hash value.
+func (key *pubKey) verify(digest, sign []byte) bool {
+	if len(sign) != 2*key.curve.Size {
+		return false
+	}
+	r, s := gost3410.UnmarshalSignature(sign)
+	return gost3410.Verify(key.curve, key.x, key.y, digest, r, s)
+}
@@ -0,0 +1,160 @@
+package soft
+
+import (
+	"github.com/towleeee/go-cryptopro/internal/backend"
+)
+
+var (
+	_ backend.Keys     = &Keys{}
+	_ backend.Session  = &session{}
+	_ backend.Verifier = &verifier{}
+)
+
+// Key pairs of the container: AT_KEYEXCHANGE and AT_SIGNATURE of CryptoAPI.
+const (
+	AT_KEYEXCHANGE uint32 = 1
+	AT_SIGNATURE   uint32 = 2
+)
+
+/*
+ * CONTAINERS
+ */
+
+// Keys of the containers of the store.
+type Keys struct {
+	store *Containers
+}
+
+func NewKeys() *Keys {
+	return &Keys{store: NewContainers()}
+}
+
+func (s *Keys) CreateContainer(op string, prov byte, name, password string, keys uint32, exportable bool, paramSet, digestSet string) error {
+	err := s.store.Create(prov, name, password, keys, exportable, paramSet, digestSet)
+	if err != nil {
+		return softError(op, "CreateContainer", err)
+	}
+	return nil
+}
+
+func (s *Keys) CheckContainer(op string, prov byte, name, password string) error {
+	if err := s.store.Check(prov, name, password); err != nil {
+		return softError(op, "CheckContainer", err)
+	}
+	return nil
+}
+
+func (s *Keys) DeleteContainer(op string, prov byte, name, password string) error {
+	if err := s.store.Delete(prov, name, password); err != nil {
+		return softError(op, "DeleteContainer", err)
+	}
+	return nil
+}
+
+func (s *Keys) CopyContainer(op string, prov byte, src, srcPassword, dst, dstPassword string) error {
+	if err := s.store.Copy(prov, src, srcPassword, dst, dstPassword); err != nil {
+		return softError(op, "CopyContainer", err)
+	}
+	return nil
+}
+
+func (s *Keys) ChangePassword(op string, prov byte, name, password, newPassword string) error {
+	if err := s.store.ChangePassword(prov, name, password, newPassword); err != nil {
+		return softError(op, "ChangePassword", err)
+	}
+	return nil
+}
+
+func (s *Keys) EnumContainers(op string, prov byte) ([]string, error) {
+	return s.store.List(prov), nil
+}
+
+func (s *Keys) ContainerKeys(op string, prov byte, name string) (uint32, error) {
+	keys, err := s.store.KeyPairs(prov, name)
+	if err != nil {
+		return 0, softError(op, "ContainerKeys", err)
+	}
+	return keys, nil
+}
+
+func (s *Keys) Sign(op string, prov byte, name, password string, spec uint32, data []byte, digest bool) ([]byte, error) {
+	key, err := s.store.Key(prov, name, password, spec)
+	if err != nil {
+		return nil, softError(op, "Sign", err)
+	}
+	sign, err := key.Sign(data, digest)
+	if err != nil {
+		return nil, softError(op, "Sign", err)
+	}
+	return sign, nil
+}
+
+func (s *Keys) PubKey(op string, prov byte, name, password string, spec uint32) ([]byte, error) {
+	key, err := s.store.Key(prov, name, password, spec)
+	if err != nil {
+		return nil, softError(op, "PubKey", err)
+	}
+	return key.PubKey(), nil
+}
+
+func (s *Keys) CheckPubKey(op string, prov byte, pub []byte) error {
+	if _, err := ImportPubKey(prov, pub); err != nil {
+		return softError(op, "CheckPubKey", err)
+	}
+	return nil
+}
+
+func (s *Keys) OpenSession(op string, prov byte, name, password string, spec uint32) (backend.Session, error) {
+	key, err := s.store.Key(prov, name, password, spec)
+	if err != nil {
+		return nil, softError(op, "OpenSession", err)
+	}
+	return &session{key: key}, nil
+}
+
+func (s *Keys) ImportPubKey(op string, prov byte, pub []byte) (backend.Verifier, error) {
+	key, err := ImportPubKey(prov, pub)
+	if err != nil {
+		return nil, softError(op, "ImportPubKey", err)
+	}
+	return &verifier{key: key}, nil
+}
+
+/*
+ * SESSION
+ */
+
+type session struct {
+	key *Key
+}
+
+func (s *session) PubKey(op string) ([]byte, error) {
+	return s.key.PubKey(), nil
+}
+
+func (s *session) Sign(op string, data []byte, digest bool) ([]byte, error) {
+	sign, err := s.key.Sign(data, digest)
+	if err != nil {
+		return nil, softError(op, "Session.Sign", err)
+	}
+	return sign, nil
+}
+
+func (s *session) Close() {}
+
+/*
+ * VERIFIER
+ */
+
+type verifier struct {
+	key *PubKey
+}
+
+func (v *verifier) Verify(op string, data, sign []byte, digest bool) error {
+	if err := v.key.Verify(data, sign, digest); err != nil {
+		return softError(op, "Verify", err)
+	}
+	return nil
+}
+
+func (v *verifier) Close() {}
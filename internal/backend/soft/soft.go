@@ -0,0 +1,94 @@
+// Software implementation of the backend without CryptoPro CSP and cgo:
+// GOST R 34.11-2012, GOST R 34.10-2012, GOST R 34.12-2015 in pure Go
+// and the containers kept in the memory of the process.
+//
+// The errors are csperr.Error with the sentinels of csperr and the return
+// codes of the C functions of the packages for them (csperr.CodeSentinel),
+// the function is the function of the backend ("soft: Sign"), the value
+// of GetLastError() is zero.
+package soft
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/towleeee/go-cryptopro/internal/backend"
+	"github.com/towleeee/go-cryptopro/internal/csperr"
+	"github.com/towleeee/go-cryptopro/internal/kuznyechik"
+	"github.com/towleeee/go-cryptopro/internal/streebog"
+)
+
+var (
+	_ backend.Rand   = Rand{}
+	_ backend.Cipher = Cipher{}
+)
+
+// Source of the random numbers of the backend.
+var Reader io.Reader = rand.Reader
+
+// Error of the function fn of the backend wrapping err, the return code
+// is the code of the container functions of gost.h for the sentinel
+// (1 - not found, 2 - wrong password, 3 - already exists, 1 - signature
+// is incorrect) or -1. The error is passed to the logger of
+// csplog.SetLogger.
+func softError(op, fn string, err error) error {
+	code := -1
+	switch {
+	case errors.Is(err, csperr.ErrBadKeyset), errors.Is(err, csperr.ErrBadSignature):
+		code = 1
+	case errors.Is(err, csperr.ErrWrongPassword):
+		code = 2
+	case errors.Is(err, csperr.ErrContainerExists):
+		code = 3
+	}
+	e := csperr.New(op, code, "soft: "+fn, 0, err)
+	csperr.Log(e)
+	return e
+}
+
+/*
+ * RAND
+ */
+
+type Rand struct{}
+
+func (Rand) Rand(op string, output []byte) error {
+	if _, err := io.ReadFull(Reader, output); err != nil {
+		return softError(op, "Rand", err)
+	}
+	return nil
+}
+
+/*
+ * CIPHER
+ */
+
+type Cipher struct{}
+
+// Kuznyechik in the mode OFB of GOST R 34.13-2015 (m = n),
+// the key is the hash GOST R 34.11-2012 256 of the key argument.
+func (Cipher) Encrypt(op string, data, key, iv []byte) ([]byte, error) {
+	if len(iv) != kuznyechik.BlockSize {
+		return nil, softError(op, "Encrypt", fmt.Errorf("error: iv size %d", len(iv)))
+	}
+
+	block, err := kuznyechik.NewCipher(streebog.Sum(streebog.Size256, key))
+	if err != nil {
+		return nil, softError(op, "Encrypt", err)
+	}
+
+	var (
+		out   = make([]byte, len(data))
+		gamma = make([]byte, kuznyechik.BlockSize)
+	)
+	copy(gamma, iv)
+	for i := 0; i < len(data); i += kuznyechik.BlockSize {
+		block.Encrypt(gamma, gamma)
+		for j := i; j < len(data) && j < i+kuznyechik.BlockSize; j++ {
+			out[j] = data[j] ^ gamma[j-i]
+		}
+	}
+	return out, nil
+}
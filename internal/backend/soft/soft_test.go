@@ -0,0 +1,269 @@
+// go test -v -bench=. -benchtime=100x
+package soft
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/towleeee/go-cryptopro/internal/csperr"
+	"github.com/towleeee/go-cryptopro/internal/keyblob"
+)
+
+func TestContainers(t *testing.T) {
+	keys := NewKeys()
+
+	err := keys.CreateContainer("gen", keyblob.K256, "user", "pass", 0x1, true, "", "")
+	if err != nil {
+		t.Errorf("test failed: %s", err)
+		return
+	}
+	err = keys.CreateContainer("gen", keyblob.K256, "user", "pass", 0x1, true, "", "")
+	if !errors.Is(err, csperr.ErrContainerExists) {
+		t.Errorf("test failed: container exists: %v", err)
+		return
+	}
+	if err := keys.CheckContainer("check", keyblob.K256, `\\.\HDIMAGE\user`, "pass"); err != nil {
+		t.Errorf("test failed: fqcn: %s", err)
+		return
+	}
+	if err := keys.CheckContainer("check", keyblob.K512, "user", "pass"); !errors.Is(err, csperr.ErrBadKeyset) {
+		t.Errorf("test failed: prov mismatch: %v", err)
+		return
+	}
+	if err := keys.CheckContainer("check", keyblob.K256, "user", "wrong"); !errors.Is(err, csperr.ErrWrongPassword) {
+		t.Errorf("test failed: wrong password: %v", err)
+		return
+	}
+
+	if err := keys.ChangePassword("change", keyblob.K256, "user", "pass", "new"); err != nil {
+		t.Errorf("test failed: %s", err)
+		return
+	}
+	if err := keys.CopyContainer("copy", keyblob.K256, "user", "new", "copy", "pass"); err != nil {
+		t.Errorf("test failed: %s", err)
+		return
+	}
+	list, err := keys.EnumContainers("enum", keyblob.K256)
+	if err != nil || len(list) != 2 {
+		t.Errorf("test failed: enum %v: %v", list, err)
+		return
+	}
+	if err := keys.DeleteContainer("delete", keyblob.K256, "copy", "pass"); err != nil {
+		t.Errorf("test failed: %s", err)
+		return
+	}
+	if _, err := keys.ContainerKeys("keys", keyblob.K256, "copy"); err == nil {
+		t.Errorf("test failed: deleted container")
+		return
+	}
+}
+
+// The containers of the same name on the different readers,
+// the errors with the functions of the backend.
+func TestContainersReaders(t *testing.T) {
+	keys := NewKeys()
+
+	for _, name := range []string{`\\.\HDIMAGE\user`, `\\.\FLASH\user`} {
+		err := keys.CreateContainer("gen", keyblob.K256, name, "pass", 0x1, name == `\\.\FLASH\user`, "", "")
+		if err != nil {
+			t.Errorf("test failed: %s: %s", name, err)
+			return
+		}
+	}
+	list, err := keys.EnumContainers("enum", keyblob.K256)
+	if err != nil || len(list) != 2 {
+		t.Errorf("test failed: enum %v: %v", list, err)
+		return
+	}
+	if err := keys.DeleteContainer("delete", keyblob.K256, `\\.\FLASH\user`, "pass"); err != nil {
+		t.Errorf("test failed: %s", err)
+		return
+	}
+	if err := keys.CheckContainer("check", keyblob.K256, "user", "pass"); err != nil {
+		t.Errorf("test failed: container of the other reader: %s", err)
+		return
+	}
+
+	err = keys.CheckContainer("check", keyblob.K256, `\\.\FLASH\user`, "pass")
+	var cerr *csperr.Error
+	if !errors.As(err, &cerr) || cerr.Code != 1 || cerr.Func != "soft: CheckContainer" || cerr.LastError != 0 ||
+		!errors.Is(err, csperr.ErrBadKeyset) {
+		t.Errorf("test failed: error %v", err)
+		return
+	}
+}
+
+// The flag of the export is kept by the key pairs and the copies.
+func TestExportable(t *testing.T) {
+	store := NewContainers()
+
+	for _, exportable := range []bool{false, true} {
+		name := fmt.Sprintf("user%t", exportable)
+		if err := store.Create(keyblob.K256, name, "pass", 0x1, exportable, "", ""); err != nil {
+			t.Errorf("test failed: %s", err)
+			return
+		}
+		if err := store.Copy(keyblob.K256, name, "pass", name+"_copy", "pass"); err != nil {
+			t.Errorf("test failed: %s", err)
+			return
+		}
+		for _, v := range []string{name, name + "_copy"} {
+			key, err := store.Key(keyblob.K256, v, "pass", AT_SIGNATURE)
+			if err != nil || key.Exportable() != exportable {
+				t.Errorf("test failed: exportable of %s: %v", v, err)
+				return
+			}
+		}
+		if _, err := store.Key(keyblob.K256, name, "pass", AT_KEYEXCHANGE); !errors.Is(err, ErrNoKey) {
+			t.Errorf("test failed: no key: %v", err)
+			return
+		}
+	}
+}
+
+// The key blob has the digest set of the key size
+// as the key blobs of CSP, also for the explicit parameter set.
+func TestDigestSet(t *testing.T) {
+	keys := NewKeys()
+
+	tests := []struct {
+		prov      byte
+		paramSet  string
+		digestSet string
+	}{
+		{keyblob.K256, "", ""},
+		{keyblob.K256, "1.2.643.7.1.2.1.1.2", ""},
+		{keyblob.K256, "1.2.643.2.2.35.1", "1.2.643.7.1.1.2.2"},
+		{keyblob.K512, "1.2.643.7.1.2.1.2.2", ""},
+	}
+	for i, v := range tests {
+		name := fmt.Sprintf("digest%d", i)
+		err := keys.CreateContainer("gen", v.prov, name, "pass", 0x1, true, v.paramSet, v.digestSet)
+		if err != nil {
+			t.Errorf("test failed: %d: %s", i, err)
+			return
+		}
+		pub, err := keys.PubKey("pub key", v.prov, name, "pass", AT_SIGNATURE)
+		if err != nil {
+			t.Errorf("test failed: %d: %s", i, err)
+			return
+		}
+		blob, err := keyblob.Parse(append([]byte{v.prov}, pub...))
+		if err != nil {
+			t.Errorf("test failed: %d: %s", i, err)
+			return
+		}
+		if !blob.Params.DigestSet.Equal(keyblob.DigestSet(v.prov)) {
+			t.Errorf("test failed: %d: digest set %s", i, blob.Params.DigestSet)
+			return
+		}
+	}
+}
+
+func TestSignVerify(t *testing.T) {
+	keys := NewKeys()
+	msg := []byte("hello, world!")
+
+	for _, prov := range []byte{keyblob.K256, keyblob.K512} {
+		name := fmt.Sprintf("user%d", prov)
+		err := keys.CreateContainer("gen", prov, name, "pass", 0x1, true, "", "")
+		if err != nil {
+			t.Errorf("test failed: %s", err)
+			return
+		}
+		sign, err := keys.Sign("sign", prov, name, "pass", AT_SIGNATURE, msg, false)
+		if err != nil {
+			t.Errorf("test failed: %s", err)
+			return
+		}
+		pub, err := keys.PubKey("pub key", prov, name, "pass", AT_SIGNATURE)
+		if err != nil {
+			t.Errorf("test failed: %s", err)
+			return
+		}
+		v, err := keys.ImportPubKey("import", prov, pub)
+		if err != nil {
+			t.Errorf("test failed: %s", err)
+			return
+		}
+		if err := v.Verify("verify", msg, sign, false); err != nil {
+			t.Errorf("test failed: verify: %s", err)
+			return
+		}
+		sign[0] ^= 1
+		if err := v.Verify("verify", msg, sign, false); !errors.Is(err, csperr.ErrBadSignature) {
+			t.Errorf("test failed: bad signature: %v", err)
+			return
+		}
+		v.Close()
+
+		if _, err := keys.Sign("sign", prov, name, "pass", AT_KEYEXCHANGE, msg, false); err == nil {
+			t.Errorf("test failed: sign without the exchange key")
+			return
+		}
+	}
+}
+
+func TestSharedKey(t *testing.T) {
+	var eph EphKeys
+
+	for _, prov := range []byte{keyblob.K256, keyblob.K512} {
+		priv1, err := eph.GenPrivKey("gen", prov)
+		if err != nil {
+			t.Errorf("test failed: %s", err)
+			return
+		}
+		priv2, err := eph.GenPrivKey("gen", prov)
+		if err != nil {
+			t.Errorf("test failed: %s", err)
+			return
+		}
+		pub1, _ := eph.PubKey("pub key", prov, priv1)
+		pub2, _ := eph.PubKey("pub key", prov, priv2)
+
+		k1, err := eph.SharedKey("secret", prov, priv1, pub2)
+		if err != nil {
+			t.Errorf("test failed: %s", err)
+			return
+		}
+		k2, err := eph.SharedKey("secret", prov, priv2, pub1)
+		if err != nil {
+			t.Errorf("test failed: %s", err)
+			return
+		}
+		if !bytes.Equal(k1, k2) {
+			t.Errorf("test failed: shared keys differ")
+			return
+		}
+
+		if err := eph.CheckPrivKey("load", prov, priv1[1:]); !errors.Is(err, csperr.ErrBadKey) {
+			t.Errorf("test failed: bad private key: %v", err)
+			return
+		}
+	}
+}
+
+func TestCipher(t *testing.T) {
+	var cipher Cipher
+
+	key := []byte("key")
+	iv := make([]byte, 16)
+	msg := []byte("hello, world!")
+
+	enc, err := cipher.Encrypt("encrypt", msg, key, iv)
+	if err != nil {
+		t.Errorf("test failed: %s", err)
+		return
+	}
+	dec, err := cipher.Encrypt("encrypt", enc, key, iv)
+	if err != nil {
+		t.Errorf("test failed: %s", err)
+		return
+	}
+	if !bytes.Equal(dec, msg) || bytes.Equal(enc, msg) {
+		t.Errorf("test failed: round trip")
+		return
+	}
+}
@@ -0,0 +1,39 @@
+//go:build cgo && !purego
+// +build cgo,!purego
+
+package csperr
+
+/*
+#cgo CFLAGS: -I${SRCDIR}/../../headers
+#cgo linux,amd64 CFLAGS: -I/opt/cprocsp/include/cpcsp -DUNIX -DLINUX -DSIZEOF_VOID_P=8
+#cgo linux,386 CFLAGS: -I/opt/cprocsp/include/cpcsp -DUNIX -DLINUX -DSIZEOF_VOID_P=4
+
+#include "common.h"
+*/
+import "C"
+import (
+	"runtime"
+)
+
+// Locking of the goroutine to the thread of the C call
+// for reading of the CSP error saved by the C side.
+// Usage: defer csperr.Lock()().
+func Lock() func() {
+	runtime.LockOSThread()
+	C.ResetCspError()
+	return runtime.UnlockOSThread
+}
+
+// Error of the failed C call with the failing function and the value
+// of GetLastError() saved by the C side, the sentinel is of the return
+// code of the kind or else of GetLastError(). The caller holds Lock.
+// The error is passed to the logger of csplog.SetLogger.
+func Last(op string, kind Kind, code int) error {
+	var fn string
+	if f := C.CspErrorFunc(); f != nil {
+		fn = C.GoString(f)
+	}
+	err := New(op, code, fn, uint32(C.CspErrorCode()), CodeSentinel(kind, code))
+	Log(err)
+	return err
+}
@@ -0,0 +1,30 @@
+//go:build cgo && !purego
+// +build cgo,!purego
+
+#include "common.h"
+
+// The only definitions of the CSP error state,
+// the C code of the packages uses the declarations of common.h.
+__thread const char *cspErrorFunc = NULL;
+__thread DWORD cspErrorCode = 0;
+
+extern void SetCspError(const char *func, DWORD code) {
+	if (cspErrorFunc != NULL) {
+		return;
+	}
+	cspErrorFunc = func;
+	cspErrorCode = code;
+}
+
+extern void ResetCspError(void) {
+	cspErrorFunc = NULL;
+	cspErrorCode = 0;
+}
+
+extern const char *CspErrorFunc(void) {
+	return cspErrorFunc;
+}
+
+extern DWORD CspErrorCode(void) {
+	return cspErrorCode;
+}
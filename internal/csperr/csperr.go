@@ -0,0 +1,163 @@
+// Errors of CSP calls: the return code of the C function,
+// the failing CSP function and the value of GetLastError().
+package csperr
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/towleeee/go-cryptopro/csplog"
+)
+
+var (
+	// Container does not exist (NTE_BAD_KEYSET).
+	ErrBadKeyset = errors.New("error: container not found")
+	// Password of the container is wrong (SCARD_W_WRONG_CHV).
+	ErrWrongPassword = errors.New("error: wrong password")
+	// Container with the same name already exists (NTE_EXISTS).
+	ErrContainerExists = errors.New("error: container already exists")
+	// Signature is incorrect (NTE_BAD_SIGNATURE).
+	ErrBadSignature = errors.New("error: signature is incorrect")
+	// Key or key blob is incorrect (NTE_BAD_KEY, NTE_BAD_PUBLIC_KEY).
+	ErrBadKey = errors.New("error: bad key")
+	// Build without cgo and without the tag purego: CSP can not be called.
+	ErrUnavailable = errors.New("error: CryptoPro CSP is unavailable (build without cgo)")
+)
+
+// Values of GetLastError().
+const (
+	NTE_BAD_KEY        uint32 = 0x80090003
+	NTE_BAD_LEN        uint32 = 0x80090004
+	NTE_BAD_DATA       uint32 = 0x80090005
+	NTE_BAD_SIGNATURE  uint32 = 0x80090006
+	NTE_NO_KEY         uint32 = 0x8009000D
+	NTE_EXISTS         uint32 = 0x8009000F
+	NTE_BAD_PUBLIC_KEY uint32 = 0x80090015
+	NTE_BAD_KEYSET     uint32 = 0x80090016
+	NTE_FAIL           uint32 = 0x80090020
+	SCARD_W_WRONG_CHV  uint32 = 0x8010006B
+)
+
+// Kind of the C function of gost.h by the meaning of its return codes.
+type Kind int
+
+const (
+	// The sentinel is taken from the value of GetLastError() only.
+	KindCall Kind = iota
+	// Container functions: 1 - not found, 2 - wrong password,
+	// 3 - already exists.
+	KindContainer
+	// CreateContainer: > 0 - already exists.
+	KindCreate
+	// Verification of the signature: > 0 - signature is incorrect.
+	KindVerify
+	// Import of the key blob: < 0 - key is incorrect.
+	KindImport
+)
+
+// Sentinel error of the return code of the C function of the kind,
+// nil if the sentinel is taken from the value of GetLastError().
+func CodeSentinel(kind Kind, code int) error {
+	switch {
+	case kind == KindContainer && code == 1:
+		return ErrBadKeyset
+	case kind == KindContainer && code == 2:
+		return ErrWrongPassword
+	case kind == KindContainer && code == 3:
+		return ErrContainerExists
+	case kind == KindCreate && code > 0:
+		return ErrContainerExists
+	case kind == KindVerify && code > 0:
+		return ErrBadSignature
+	case kind == KindImport && code < 0:
+		return ErrBadKey
+	default:
+		return nil
+	}
+}
+
+// Error of the CSP call.
+type Error struct {
+	// Operation of the Go package, e.g. "sign".
+	Op string
+	// Return code of the C function.
+	Code int
+	// Failing CSP function, e.g. "SignHash: CryptSignHash (1)".
+	Func string
+	// Value of GetLastError() after the failure.
+	LastError uint32
+	// Sentinel error for errors.Is, may be nil.
+	Err error
+}
+
+// Creation of the error, the sentinel is taken from
+// the argument or else from the value of GetLastError().
+func New(op string, code int, fn string, lastError uint32, sentinel error) *Error {
+	if sentinel == nil {
+		sentinel = Sentinel(lastError)
+	}
+	return &Error{
+		Op:        op,
+		Code:      code,
+		Func:      fn,
+		LastError: lastError,
+		Err:       sentinel,
+	}
+}
+
+// Sentinel error of the value of GetLastError() or nil.
+func Sentinel(lastError uint32) error {
+	switch lastError {
+	case NTE_BAD_KEYSET:
+		return ErrBadKeyset
+	case SCARD_W_WRONG_CHV:
+		return ErrWrongPassword
+	case NTE_EXISTS:
+		return ErrContainerExists
+	case NTE_BAD_SIGNATURE:
+		return ErrBadSignature
+	case NTE_BAD_KEY, NTE_BAD_PUBLIC_KEY:
+		return ErrBadKey
+	default:
+		return nil
+	}
+}
+
+// "error: op: code N, func, last error 0x...: sentinel".
+func (e *Error) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "error: %s: code %d", e.Op, e.Code)
+	if e.Func != "" {
+		fmt.Fprintf(&b, ", %s", e.Func)
+	}
+	if e.LastError != 0 {
+		fmt.Fprintf(&b, ", last error 0x%08X", e.LastError)
+	}
+	if e.Err != nil {
+		fmt.Fprintf(&b, ": %s", strings.TrimPrefix(e.Err.Error(), "error: "))
+	}
+	return b.String()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Passing of the error to the logger of csplog.SetLogger
+// with the attributes "op", "code", "func" and "last_error".
+// The errors expected by the callers (container not found, wrong
+// password, incorrect signature) are logged at LevelDebug,
+// the other errors at LevelError.
+func Log(e *Error) {
+	level := csplog.LevelError
+	if errors.Is(e.Err, ErrBadKeyset) || errors.Is(e.Err, ErrWrongPassword) || errors.Is(e.Err, ErrBadSignature) {
+		level = csplog.LevelDebug
+	}
+	csplog.Log(level, "csp error",
+		csplog.Attr{Key: "op", Value: e.Op},
+		csplog.Attr{Key: "code", Value: e.Code},
+		csplog.Attr{Key: "func", Value: e.Func},
+		csplog.Attr{Key: "last_error", Value: e.LastError},
+	)
+}
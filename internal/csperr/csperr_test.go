@@ -0,0 +1,127 @@
+// go test -v -bench=. -benchtime=100x
+package csperr
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/towleeee/go-cryptopro/csplog"
+)
+
+func TestSentinel(t *testing.T) {
+	tests := []struct {
+		lastError uint32
+		sentinel  error
+	}{
+		{NTE_BAD_KEYSET, ErrBadKeyset},
+		{SCARD_W_WRONG_CHV, ErrWrongPassword},
+		{NTE_EXISTS, ErrContainerExists},
+		{NTE_BAD_SIGNATURE, ErrBadSignature},
+		{NTE_BAD_KEY, ErrBadKey},
+		{NTE_BAD_PUBLIC_KEY, ErrBadKey},
+		{0, nil},
+	}
+	for _, v := range tests {
+		err := New("op", -1, "Func: CryptFunc", v.lastError, nil)
+		if v.sentinel != nil && !errors.Is(err, v.sentinel) {
+			t.Errorf("test failed: sentinel of 0x%08X", v.lastError)
+			return
+		}
+		if v.sentinel == nil && err.Unwrap() != nil {
+			t.Errorf("test failed: sentinel of 0x%08X", v.lastError)
+			return
+		}
+	}
+}
+
+func TestCodeSentinel(t *testing.T) {
+	tests := []struct {
+		kind     Kind
+		code     int
+		sentinel error
+	}{
+		{KindContainer, 1, ErrBadKeyset},
+		{KindContainer, 2, ErrWrongPassword},
+		{KindContainer, 3, ErrContainerExists},
+		{KindContainer, -1, nil},
+		{KindCreate, 1, ErrContainerExists},
+		{KindCreate, -1, nil},
+		{KindVerify, 1, ErrBadSignature},
+		{KindVerify, -3, nil},
+		{KindImport, -2, ErrBadKey},
+		{KindImport, 1, nil},
+		{KindCall, 1, nil},
+		{KindCall, -1, nil},
+	}
+	for _, v := range tests {
+		if err := CodeSentinel(v.kind, v.code); err != v.sentinel {
+			t.Errorf("test failed: sentinel of kind %d code %d: %v", v.kind, v.code, err)
+			return
+		}
+	}
+}
+
+func TestError(t *testing.T) {
+	err := fmt.Errorf("wrap: %w", New("new priv key", -2, "CheckContainer: CryptSetProvParam", 0, ErrWrongPassword))
+
+	if !errors.Is(err, ErrWrongPassword) || errors.Is(err, ErrBadKeyset) {
+		t.Errorf("test failed: errors.Is")
+		return
+	}
+
+	var cerr *Error
+	if !errors.As(err, &cerr) || cerr.Code != -2 {
+		t.Errorf("test failed: errors.As")
+		return
+	}
+
+	msg := "error: new priv key: code -2, CheckContainer: CryptSetProvParam: wrong password"
+	if cerr.Error() != msg {
+		t.Errorf("test failed: message %q", cerr.Error())
+		return
+	}
+
+	cerr = New("sign", -1, "SignHash: CryptSignHash (1)", NTE_BAD_KEYSET, nil)
+	msg = "error: sign: code -1, SignHash: CryptSignHash (1), last error 0x80090016: container not found"
+	if cerr.Error() != msg {
+		t.Errorf("test failed: message %q", cerr.Error())
+		return
+	}
+}
+
+func TestLog(t *testing.T) {
+	defer csplog.SetLogger(nil)
+
+	var (
+		level csplog.Level
+		attrs []csplog.Attr
+	)
+	csplog.SetLogger(csplog.LoggerFunc(func(l csplog.Level, msg string, a ...csplog.Attr) {
+		level, attrs = l, a
+	}))
+	Log(New("sign", -1, "SignHash: CryptSignHash (1)", NTE_BAD_KEYSET, nil))
+
+	if len(attrs) != 4 || attrs[2].Value != "SignHash: CryptSignHash (1)" || attrs[3].Value != NTE_BAD_KEYSET {
+		t.Errorf("test failed: attributes %v", attrs)
+		return
+	}
+
+	tests := []struct {
+		lastError uint32
+		level     csplog.Level
+	}{
+		{NTE_BAD_KEYSET, csplog.LevelDebug},
+		{SCARD_W_WRONG_CHV, csplog.LevelDebug},
+		{NTE_BAD_SIGNATURE, csplog.LevelDebug},
+		{NTE_BAD_KEY, csplog.LevelError},
+		{NTE_FAIL, csplog.LevelError},
+	}
+	for _, v := range tests {
+		Log(New("op", -1, "Func: CryptFunc", v.lastError, nil))
+		if level != v.level {
+			t.Errorf("test failed: level of 0x%08X: %s", v.lastError, level)
+			return
+		}
+	}
+}
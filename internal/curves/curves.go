@@ -0,0 +1,457 @@
+// Elliptic curves of GOST R 34.10-2012 (RFC 4357, RFC 7836, RFC 9215)
+// in the short Weierstrass form y^2 = x^3 + ax + b (mod p).
+// The twisted Edwards curves of TC 26 are given by the equivalent form.
+package curves
+
+import (
+	"encoding/asn1"
+	"fmt"
+	"math/big"
+
+	"github.com/towleeee/go-cryptopro/internal/oids"
+)
+
+type Curve struct {
+	Name string
+	// Field prime, coefficients, order of the subgroup
+	// and the cofactor of the curve.
+	P, A, B, Q *big.Int
+	Cofactor   int64
+	// Base point of the subgroup.
+	X, Y *big.Int
+	// Size of the coordinate in bytes.
+	Size int
+}
+
+/*
+ * PARAMETER SETS
+ */
+
+var (
+	CryptoProA = newCurve("id-GostR3410-2001-CryptoPro-A-ParamSet", 1,
+		"FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFD97",
+		"FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFD94",
+		"00000000000000000000000000000000000000000000000000000000000000A6",
+		"FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF6C611070995AD10045841B09B761B893",
+		"0000000000000000000000000000000000000000000000000000000000000001",
+		"8D91E471E0989CDA27DF505A453F2B7635294F2DDF23E3B122ACC99C9E9F1E14",
+	)
+	CryptoProB = newCurve("id-GostR3410-2001-CryptoPro-B-ParamSet", 1,
+		"8000000000000000000000000000000000000000000000000000000000000C99",
+		"8000000000000000000000000000000000000000000000000000000000000C96",
+		"3E1AF419A269A5F866A7D3C25C3DF80AE979259373FF2B182F49D4CE7E1BBC8B",
+		"800000000000000000000000000000015F700CFFF1A624E5E497161BCC8A198F",
+		"0000000000000000000000000000000000000000000000000000000000000001",
+		"3FA8124359F96680B83D1C3EB2C070E5C545C9858D03ECFB744BF8D717717EFC",
+	)
+	CryptoProC = newCurve("id-GostR3410-2001-CryptoPro-C-ParamSet", 1,
+		"9B9F605F5A858107AB1EC85E6B41C8AACF846E86789051D37998F7B9022D759B",
+		"9B9F605F5A858107AB1EC85E6B41C8AACF846E86789051D37998F7B9022D7598",
+		"000000000000000000000000000000000000000000000000000000000000805A",
+		"9B9F605F5A858107AB1EC85E6B41C8AA582CA3511EDDFB74F02F3A6598980BB9",
+		"0000000000000000000000000000000000000000000000000000000000000000",
+		"41ECE55743711A8C3CBF3783CD08C0EE4D4DC440D4641A8F366E550DFDB3BB67",
+	)
+	Tc26Gost256A = newCurve("id-tc26-gost-3410-12-256-paramSetA", 4,
+		"FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFD97",
+		"C2173F1513981673AF4892C23035A27CE25E2013BF95AA33B22C656F277E7335",
+		"295F9BAE7428ED9CCC20E7C359A9D41A22FCCD9108E17BF7BA9337A6F8AE9513",
+		"400000000000000000000000000000000FD8CDDFC87B6635C115AF556C360C67",
+		"91E38443A5E82C0D880923425712B2BB658B9196932E02C78B2582FE742DAA28",
+		"32879423AB1A0375895786C4BB46E9565FDE0B5344766740AF268ADB32322E5C",
+	)
+	Tc26Gost512Test = newCurve("id-tc26-gost-3410-12-512-paramSetTest", 1,
+		"4531ACD1FE0023C7550D267B6B2FEE80922B14B2FFB90F04D4EB7C09B5D2D15D"+
+			"F1D852741AF4704A0458047E80E4546D35B8336FAC224DD81664BBF528BE6373",
+		"0000000000000000000000000000000000000000000000000000000000000000"+
+			"0000000000000000000000000000000000000000000000000000000000000007",
+		"1CFF0806A31116DA29D8CFA54E57EB748BC5F377E49400FDD788B649ECA1AC43"+
+			"61834013B2AD7322480A89CA58E0CF74BC9E540C2ADD6897FAD0A3084F302ADC",
+		"4531ACD1FE0023C7550D267B6B2FEE80922B14B2FFB90F04D4EB7C09B5D2D15D"+
+			"A82F2D7ECB1DBAC719905C5EECC423F1D86E25EDBE23C595D644AAF187E6E6DF",
+		"24D19CC64572EE30F396BF6EBBFD7A6C5213B3B3D7057CC825F91093A68CD762"+
+			"FD60611262CD838DC6B60AA7EEE804E28BC849977FAC33B4B530F1B120248A9A",
+		"2BB312A43BD2CE6E0D020613C857ACDDCFBF061E91E5F2C3F32447C259F39B2C"+
+			"83AB156D77F1496BF7EB3351E1EE4E43DC1A18B91B24640B6DBB92CB1ADD371E",
+	)
+	Tc26Gost512A = newCurve("id-tc26-gost-3410-12-512-paramSetA", 1,
+		"FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF"+
+			"FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFDC7",
+		"FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF"+
+			"FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFDC4",
+		"E8C2505DEDFC86DDC1BD0B2B6667F1DA34B82574761CB0E879BD081CFD0B6265"+
+			"EE3CB090F30D27614CB4574010DA90DD862EF9D4EBEE4761503190785A71C760",
+		"FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF"+
+			"27E69532F48D89116FF22B8D4E0560609B4B38ABFAD2B85DCACDB1411F10B275",
+		"0000000000000000000000000000000000000000000000000000000000000000"+
+			"0000000000000000000000000000000000000000000000000000000000000003",
+		"7503CFE87A836AE3A61B8816E25450E6CE5E1C93ACF1ABC1778064FDCBEFA921"+
+			"DF1626BE4FD036E93D75E6A50E3A41E98028FE5FC235F5B889A589CB5215F2A4",
+	)
+	Tc26Gost512B = newCurve("id-tc26-gost-3410-12-512-paramSetB", 1,
+		"8000000000000000000000000000000000000000000000000000000000000000"+
+			"000000000000000000000000000000000000000000000000000000000000006F",
+		"8000000000000000000000000000000000000000000000000000000000000000"+
+			"000000000000000000000000000000000000000000000000000000000000006C",
+		"687D1B459DC841457E3E06CF6F5E2517B97C7D614AF138BCBF85DC806C4B289F"+
+			"3E965D2DB1416D217F8B276FAD1AB69C50F78BEE1FA3106EFB8CCBC7C5140116",
+		"8000000000000000000000000000000000000000000000000000000000000001"+
+			"49A1EC142565A545ACFDB77BD9D40CFA8B996712101BEA0EC6346C54374F25BD",
+		"0000000000000000000000000000000000000000000000000000000000000000"+
+			"0000000000000000000000000000000000000000000000000000000000000002",
+		"1A8F7EDA389B094C2C071E3647A8940F3C123B697578C213BE6DD9E6C8EC7335"+
+			"DCB228FD1EDF4A39152CBCAAF8C0398828041055F94CEEEC7E21340780FE41BD",
+	)
+	Tc26Gost512C = newCurve("id-tc26-gost-3410-12-512-paramSetC", 4,
+		"FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF"+
+			"FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFDC7",
+		"DC9203E514A721875485A529D2C722FB187BC8980EB866644DE41C68E1430645"+
+			"46E861C0E2C9EDD92ADE71F46FCF50FF2AD97F951FDA9F2A2EB6546F39689BD3",
+		"B4C4EE28CEBC6C2C8AC12952CF37F16AC7EFB6A9F69F4B57FFDA2E4F0DE5ADE0"+
+			"38CBC2FFF719D2C18DE0284B8BFEF3B52B8CC7A5F5BF0A3C8D2319A5312557E1",
+		"3FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF"+
+			"C98CDBA46506AB004C33A9FF5147502CC8EDA9E7A769A12694623CEF47F023ED",
+		"E2E31EDFC23DE7BDEBE241CE593EF5DE2295B7A9CBAEF021D385F7074CEA043A"+
+			"A27272A7AE602BF2A7B9033DB9ED3610C6FB85487EAE97AAC5BC7928C1950148",
+		"F5CE40D95B5EB899ABBCCFF5911CB8577939804D6527378B8C108C3D2090FF9B"+
+			"E18E2D33E3021ED2EF32D85822423B6304F726AA854BAE07D0396E9A9ADDC40F",
+	)
+)
+
+// Curve of the parameter set, nil if unknown.
+// The exchange and TC 26 sets B, C, D are the CryptoPro curves.
+func ByParamSet(paramSet asn1.ObjectIdentifier) *Curve {
+	for _, v := range []struct {
+		oid   asn1.ObjectIdentifier
+		curve *Curve
+	}{
+		{oids.CryptoProA, CryptoProA},
+		{oids.CryptoProB, CryptoProB},
+		{oids.CryptoProC, CryptoProC},
+		{oids.CryptoProXchA, CryptoProA},
+		{oids.CryptoProXchB, CryptoProC},
+		{oids.Tc26Gost256A, Tc26Gost256A},
+		{oids.Tc26Gost256B, CryptoProA},
+		{oids.Tc26Gost256C, CryptoProB},
+		{oids.Tc26Gost256D, CryptoProC},
+		{oids.Tc26Gost512Test, Tc26Gost512Test},
+		{oids.Tc26Gost512A, Tc26Gost512A},
+		{oids.Tc26Gost512B, Tc26Gost512B},
+		{oids.Tc26Gost512C, Tc26Gost512C},
+	} {
+		if v.oid.Equal(paramSet) {
+			return v.curve
+		}
+	}
+	return nil
+}
+
+func newCurve(name string, cofactor int64, p, a, b, q, x, y string) *Curve {
+	return &Curve{
+		Name:     name,
+		P:        fromHex(p),
+		A:        fromHex(a),
+		B:        fromHex(b),
+		Q:        fromHex(q),
+		Cofactor: cofactor,
+		X:        fromHex(x),
+		Y:        fromHex(y),
+		Size:     len(p) / 2,
+	}
+}
+
+func fromHex(s string) *big.Int {
+	v, ok := new(big.Int).SetString(s, 16)
+	if !ok {
+		panic("curves: bad constant " + s)
+	}
+	return v
+}
+
+/*
+ * POINTS
+ */
+
+// Decoding of little-endian X || little-endian Y.
+func (c *Curve) Unmarshal(point []byte) (x, y *big.Int, err error) {
+	if len(point) != 2*c.Size {
+		return nil, nil, fmt.Errorf("error: length of point")
+	}
+	x = FromLE(point[:c.Size])
+	y = FromLE(point[c.Size:])
+	return x, y, nil
+}
+
+// Encoding to little-endian X || little-endian Y.
+func (c *Curve) Marshal(x, y *big.Int) []byte {
+	point := make([]byte, 2*c.Size)
+	FillLE(x, point[:c.Size])
+	FillLE(y, point[c.Size:])
+	return point
+}
+
+// Checking of the encoded point (little-endian X || little-endian Y):
+// the point is on the curve and in the subgroup of the order Q.
+func (c *Curve) CheckPoint(point []byte) error {
+	x, y, err := c.Unmarshal(point)
+	if err != nil {
+		return err
+	}
+	if !c.IsOnCurve(x, y) {
+		return fmt.Errorf("error: point is not on the curve %s", c.Name)
+	}
+	if !c.InSubgroup(x, y) {
+		return fmt.Errorf("error: point is not in the subgroup of the curve %s", c.Name)
+	}
+	return nil
+}
+
+// The point (x, y) satisfies the equation of the curve.
+func (c *Curve) IsOnCurve(x, y *big.Int) bool {
+	if x.Sign() < 0 || x.Cmp(c.P) >= 0 || y.Sign() < 0 || y.Cmp(c.P) >= 0 {
+		return false
+	}
+	left := new(big.Int).Mul(y, y)
+	left.Mod(left, c.P)
+
+	right := new(big.Int).Mul(x, x)
+	right.Add(right, c.A)
+	right.Mul(right, x)
+	right.Add(right, c.B)
+	right.Mod(right, c.P)
+
+	return left.Cmp(right) == 0
+}
+
+// The point on the curve has the order Q.
+// Every point has it if the cofactor is 1.
+func (c *Curve) InSubgroup(x, y *big.Int) bool {
+	if c.Cofactor == 1 {
+		return true
+	}
+	return c.toAffine(c.scalarMult(c.fromAffine(x, y), c.Q)) == nil
+}
+
+// Sum of the points, nil for the point at infinity.
+func (c *Curve) Add(x1, y1, x2, y2 *big.Int) (x, y *big.Int) {
+	xy := c.toAffine(c.add(c.fromAffine(x1, y1), c.fromAffine(x2, y2)))
+	if xy == nil {
+		return nil, nil
+	}
+	return xy[0], xy[1]
+}
+
+// Product k * (x, y), nil for the point at infinity.
+func (c *Curve) ScalarMult(x, y, k *big.Int) (rx, ry *big.Int) {
+	xy := c.toAffine(c.scalarMult(c.fromAffine(x, y), k))
+	if xy == nil {
+		return nil, nil
+	}
+	return xy[0], xy[1]
+}
+
+// Product k * (X, Y) of the base point.
+func (c *Curve) ScalarBaseMult(k *big.Int) (x, y *big.Int) {
+	return c.ScalarMult(c.X, c.Y, k)
+}
+
+// Sum k1 * (X, Y) + k2 * (x, y) by one pass (Shamir's trick).
+func (c *Curve) CombinedMult(x, y, k1, k2 *big.Int) (rx, ry *big.Int) {
+	var (
+		g   = c.fromAffine(c.X, c.Y)
+		q   = c.fromAffine(x, y)
+		gq  = c.add(g, q)
+		acc = c.infinity()
+	)
+	for i := maxBitLen(k1, k2) - 1; i >= 0; i-- {
+		acc = c.double(acc)
+		switch k1.Bit(i)<<1 | k2.Bit(i) {
+		case 1:
+			acc = c.add(acc, q)
+		case 2:
+			acc = c.add(acc, g)
+		case 3:
+			acc = c.add(acc, gq)
+		}
+	}
+	xy := c.toAffine(acc)
+	if xy == nil {
+		return nil, nil
+	}
+	return xy[0], xy[1]
+}
+
+/*
+ * JACOBIAN COORDINATES
+ */
+
+// Point (X/Z^2, Y/Z^3), the point at infinity if Z = 0.
+type jacobian [3]*big.Int
+
+func (c *Curve) infinity() jacobian {
+	return jacobian{big.NewInt(1), big.NewInt(1), new(big.Int)}
+}
+
+func (c *Curve) fromAffine(x, y *big.Int) jacobian {
+	return jacobian{new(big.Int).Set(x), new(big.Int).Set(y), big.NewInt(1)}
+}
+
+func (c *Curve) toAffine(p jacobian) []*big.Int {
+	if p[2].Sign() == 0 {
+		return nil
+	}
+	zinv := new(big.Int).ModInverse(p[2], c.P)
+	zinv2 := new(big.Int).Mul(zinv, zinv)
+	zinv2.Mod(zinv2, c.P)
+
+	x := new(big.Int).Mul(p[0], zinv2)
+	x.Mod(x, c.P)
+
+	y := zinv2.Mul(zinv2, zinv)
+	y.Mul(y, p[1])
+	y.Mod(y, c.P)
+
+	return []*big.Int{x, y}
+}
+
+// Doubling (dbl-2007-bl).
+func (c *Curve) double(p jacobian) jacobian {
+	if p[2].Sign() == 0 || p[1].Sign() == 0 {
+		return c.infinity()
+	}
+	var (
+		xx   = c.mul(p[0], p[0])
+		yy   = c.mul(p[1], p[1])
+		yyyy = c.mul(yy, yy)
+		zz   = c.mul(p[2], p[2])
+	)
+
+	s := new(big.Int).Add(p[0], yy)
+	s = c.mul(s, s)
+	s.Sub(s, xx)
+	s.Sub(s, yyyy)
+	s = c.mod(s.Lsh(s, 1))
+
+	m := new(big.Int).Mul(xx, big.NewInt(3))
+	m.Add(m, c.mul(c.A, c.mul(zz, zz)))
+	m = c.mod(m)
+
+	x3 := c.mul(m, m)
+	x3.Sub(x3, s)
+	x3.Sub(x3, s)
+	x3 = c.mod(x3)
+
+	y3 := new(big.Int).Sub(s, x3)
+	y3 = c.mul(m, y3)
+	y3.Sub(y3, new(big.Int).Lsh(yyyy, 3))
+	y3 = c.mod(y3)
+
+	z3 := new(big.Int).Add(p[1], p[2])
+	z3 = c.mul(z3, z3)
+	z3.Sub(z3, yy)
+	z3.Sub(z3, zz)
+	z3 = c.mod(z3)
+
+	return jacobian{x3, y3, z3}
+}
+
+// Addition (add-2007-bl).
+func (c *Curve) add(p1, p2 jacobian) jacobian {
+	if p1[2].Sign() == 0 {
+		return p2
+	}
+	if p2[2].Sign() == 0 {
+		return p1
+	}
+	var (
+		z1z1 = c.mul(p1[2], p1[2])
+		z2z2 = c.mul(p2[2], p2[2])
+		u1   = c.mul(p1[0], z2z2)
+		u2   = c.mul(p2[0], z1z1)
+		s1   = c.mul(p1[1], c.mul(p2[2], z2z2))
+		s2   = c.mul(p2[1], c.mul(p1[2], z1z1))
+	)
+
+	h := c.mod(new(big.Int).Sub(u2, u1))
+	r := c.mod(new(big.Int).Sub(s2, s1))
+	if h.Sign() == 0 {
+		if r.Sign() == 0 {
+			return c.double(p1)
+		}
+		return c.infinity()
+	}
+	r = c.mod(r.Lsh(r, 1))
+
+	i := new(big.Int).Lsh(h, 1)
+	i = c.mul(i, i)
+	j := c.mul(h, i)
+	v := c.mul(u1, i)
+
+	x3 := c.mul(r, r)
+	x3.Sub(x3, j)
+	x3.Sub(x3, new(big.Int).Lsh(v, 1))
+	x3 = c.mod(x3)
+
+	y3 := c.mul(r, new(big.Int).Sub(v, x3))
+	y3.Sub(y3, new(big.Int).Lsh(c.mul(s1, j), 1))
+	y3 = c.mod(y3)
+
+	z3 := new(big.Int).Add(p1[2], p2[2])
+	z3 = c.mul(z3, z3)
+	z3.Sub(z3, z1z1)
+	z3.Sub(z3, z2z2)
+	z3 = c.mul(z3, h)
+
+	return jacobian{x3, y3, z3}
+}
+
+func (c *Curve) scalarMult(p jacobian, k *big.Int) jacobian {
+	acc := c.infinity()
+	for i := k.BitLen() - 1; i >= 0; i-- {
+		acc = c.double(acc)
+		if k.Bit(i) == 1 {
+			acc = c.add(acc, p)
+		}
+	}
+	return acc
+}
+
+func (c *Curve) mul(a, b *big.Int) *big.Int {
+	r := new(big.Int).Mul(a, b)
+	return r.Mod(r, c.P)
+}
+
+func (c *Curve) mod(a *big.Int) *big.Int {
+	return a.Mod(a, c.P)
+}
+
+func maxBitLen(a, b *big.Int) int {
+	if a.BitLen() > b.BitLen() {
+		return a.BitLen()
+	}
+	return b.BitLen()
+}
+
+/*
+ * LITTLE-ENDIAN NUMBERS
+ */
+
+// Number of the little-endian bytes of CryptoAPI
+// (coordinates, digests, r and s of signatures).
+func FromLE(data []byte) *big.Int {
+	be := make([]byte, len(data))
+	for i, v := range data {
+		be[len(data)-1-i] = v
+	}
+	return new(big.Int).SetBytes(be)
+}
+
+// Little-endian bytes of the number n filling buf,
+// as big.Int.FillBytes for big-endian.
+func FillLE(n *big.Int, buf []byte) []byte {
+	n.FillBytes(buf)
+	for i, j := 0, len(buf)-1; i < j; i, j = i+1, j-1 {
+		buf[i], buf[j] = buf[j], buf[i]
+	}
+	return buf
+}
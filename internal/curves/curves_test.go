@@ -0,0 +1,156 @@
+// go test -v -bench=. -benchtime=100x
+package curves
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/towleeee/go-cryptopro/internal/oids"
+)
+
+const (
+	// Point of the public key blob with CryptoPro A parameter set.
+	TEST_POINT_256 = "2f6197366b7cd9fb002ec3b7b8ab066fed6a514617a01c6a3ea5124b6acde80fbdb3004940753e0bb350e3f08f9a778dc87b14836a7d7ecf0ec53e49ccdce28e"
+)
+
+func TestParamSets(t *testing.T) {
+	for _, paramSet := range append(oids.ParamSets256, oids.ParamSets512...) {
+		c := ByParamSet(paramSet)
+		if c == nil {
+			t.Errorf("test failed: curve of %s", paramSet)
+			return
+		}
+
+		if 8*c.Size != oids.ParamSetSize(paramSet) || c.P.BitLen() > 8*c.Size {
+			t.Errorf("test failed: size of %s", c.Name)
+			return
+		}
+		if !c.P.ProbablyPrime(20) || !c.Q.ProbablyPrime(20) {
+			t.Errorf("test failed: primes of %s", c.Name)
+			return
+		}
+		if !c.IsOnCurve(c.X, c.Y) {
+			t.Errorf("test failed: base point of %s", c.Name)
+			return
+		}
+		if x, _ := c.ScalarBaseMult(c.Q); x != nil {
+			t.Errorf("test failed: order of %s", c.Name)
+			return
+		}
+
+		// The order of the curve is about P (Hasse bound).
+		order := new(big.Int).Mul(c.Q, big.NewInt(c.Cofactor))
+		diff := new(big.Int).Sub(order, c.P)
+		if diff.Abs(diff).BitLen() > c.P.BitLen()/2+2 {
+			t.Errorf("test failed: cofactor of %s", c.Name)
+			return
+		}
+	}
+
+	if ByParamSet(oids.GostR3410_12_256) != nil {
+		t.Errorf("test failed: curve of unknown param set")
+		return
+	}
+}
+
+func TestArithmetic(t *testing.T) {
+	for _, c := range []*Curve{CryptoProA, Tc26Gost256A, Tc26Gost512C} {
+		var (
+			k1 = big.NewInt(12345)
+			k2 = big.NewInt(67890)
+		)
+
+		x1, y1 := c.ScalarBaseMult(k1)
+		x2, y2 := c.ScalarBaseMult(k2)
+		x3, y3 := c.Add(x1, y1, x2, y2)
+		x4, y4 := c.ScalarBaseMult(new(big.Int).Add(k1, k2))
+		if x3.Cmp(x4) != 0 || y3.Cmp(y4) != 0 {
+			t.Errorf("test failed: add (%s)", c.Name)
+			return
+		}
+
+		x5, y5 := c.Add(x1, y1, x1, y1)
+		x6, y6 := c.ScalarMult(x1, y1, big.NewInt(2))
+		if x5.Cmp(x6) != 0 || y5.Cmp(y6) != 0 {
+			t.Errorf("test failed: double (%s)", c.Name)
+			return
+		}
+
+		// k1 * G + k2 * (k1 * G) = k1 * (1 + k2) * G
+		x7, y7 := c.CombinedMult(x1, y1, k1, k2)
+		k := new(big.Int).Mul(k1, new(big.Int).Add(k2, big.NewInt(1)))
+		x8, y8 := c.ScalarBaseMult(k)
+		if x7.Cmp(x8) != 0 || y7.Cmp(y8) != 0 {
+			t.Errorf("test failed: combined mult (%s)", c.Name)
+			return
+		}
+	}
+}
+
+func TestCheckPoint(t *testing.T) {
+	point, _ := hex.DecodeString(TEST_POINT_256)
+	c := CryptoProA
+
+	if err := c.CheckPoint(point); err != nil {
+		t.Errorf("test failed: check point: %v", err)
+		return
+	}
+
+	x, y, err := c.Unmarshal(point)
+	if err != nil {
+		t.Errorf("test failed: unmarshal: %v", err)
+		return
+	}
+	if hex.EncodeToString(c.Marshal(x, y)) != TEST_POINT_256 {
+		t.Errorf("test failed: marshal")
+		return
+	}
+
+	wrong := append([]byte{}, point...)
+	wrong[0] ^= 0x1
+	if err := c.CheckPoint(wrong); err == nil {
+		t.Errorf("test failed: point is not on the curve")
+		return
+	}
+
+	if err := c.CheckPoint(point[1:]); err == nil {
+		t.Errorf("test failed: length of point")
+		return
+	}
+
+	// Most points of the curve with the cofactor 4
+	// are not in the subgroup of the order Q.
+	e := Tc26Gost256A
+	for i := int64(1); i < 100; i++ {
+		px := big.NewInt(i)
+		rhs := new(big.Int).Mul(px, px)
+		rhs.Add(rhs, e.A)
+		rhs.Mul(rhs, px)
+		rhs.Add(rhs, e.B)
+		rhs.Mod(rhs, e.P)
+		py := new(big.Int).ModSqrt(rhs, e.P)
+		if py == nil {
+			continue
+		}
+		if e.InSubgroup(px, py) {
+			continue
+		}
+		if err := e.CheckPoint(e.Marshal(px, py)); err == nil {
+			t.Errorf("test failed: point is not in the subgroup")
+		}
+		return
+	}
+	t.Errorf("test failed: point out of the subgroup not found")
+}
+
+func BenchmarkCheckPoint(b *testing.B) {
+	c := Tc26Gost512C
+	point := c.Marshal(c.ScalarBaseMult(big.NewInt(12345)))
+	for i := 0; i < b.N; i++ {
+		if err := c.CheckPoint(point); err != nil {
+			b.Errorf("benchmark failed: check point")
+			break
+		}
+	}
+}
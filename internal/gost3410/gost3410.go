@@ -0,0 +1,119 @@
+// Signature scheme GOST R 34.10-2012 (RFC 7091) in pure Go.
+// The digest is the little-endian number as in CryptoAPI.
+package gost3410
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/towleeee/go-cryptopro/internal/curves"
+)
+
+// Number alpha of the digest (little-endian) as in the standard.
+func DigestToInt(digest []byte) *big.Int {
+	return curves.FromLE(digest)
+}
+
+// Values r and s of the signature of CryptoAPI:
+// little-endian r || little-endian s.
+func UnmarshalSignature(sign []byte) (r, s *big.Int) {
+	half := len(sign) / 2
+	return curves.FromLE(sign[:half]), curves.FromLE(sign[half:])
+}
+
+// Signature of CryptoAPI of the size 2 * c.Size.
+func MarshalSignature(c *curves.Curve, r, s *big.Int) []byte {
+	sign := make([]byte, 2*c.Size)
+	curves.FillLE(r, sign[:c.Size])
+	curves.FillLE(s, sign[c.Size:])
+	return sign
+}
+
+// Random number in [1, q-1] for the private key or the number k:
+// 64 extra bits are read, so the bias of the reduction modulo q-1
+// is negligible for q much less than 2^(8*Size) (FIPS 186-4 B.4.1).
+func RandScalar(c *curves.Curve, rand io.Reader) (*big.Int, error) {
+	buf := make([]byte, c.Size+8)
+	if _, err := io.ReadFull(rand, buf); err != nil {
+		return nil, fmt.Errorf("error: read random: %w", err)
+	}
+	n := new(big.Int).Sub(c.Q, big.NewInt(1))
+	k := new(big.Int).SetBytes(buf)
+	k.Mod(k, n)
+	return k.Add(k, big.NewInt(1)), nil
+}
+
+// Signature (r, s) of the digest with the private key d,
+// k is generated from rand.
+func Sign(c *curves.Curve, d *big.Int, digest []byte, rand io.Reader) (r, s *big.Int, err error) {
+	for {
+		k, err := RandScalar(c, rand)
+		if err != nil {
+			return nil, nil, err
+		}
+		r, s = SignInt(c, d, DigestToInt(digest), k)
+		if r != nil {
+			return r, s, nil
+		}
+	}
+}
+
+// Signature (r, s) of the number alpha with the private key d
+// and the number k, steps 3-6 of the section 6.1 of the standard.
+// The result is nil if r or s is zero.
+func SignInt(c *curves.Curve, d, alpha, k *big.Int) (r, s *big.Int) {
+	e := new(big.Int).Mod(alpha, c.Q)
+	if e.Sign() == 0 {
+		e.SetInt64(1)
+	}
+
+	r, _ = c.ScalarBaseMult(k)
+	if r == nil {
+		return nil, nil
+	}
+	r.Mod(r, c.Q)
+	if r.Sign() == 0 {
+		return nil, nil
+	}
+
+	s = new(big.Int).Mul(r, d)
+	s.Add(s, new(big.Int).Mul(k, e))
+	s.Mod(s, c.Q)
+	if s.Sign() == 0 {
+		return nil, nil
+	}
+	return r, s
+}
+
+// Verification of the signature (r, s) of the digest
+// with the public key (x, y) on the curve.
+func Verify(c *curves.Curve, x, y *big.Int, digest []byte, r, s *big.Int) bool {
+	return VerifyInt(c, x, y, DigestToInt(digest), r, s)
+}
+
+// Verification of the signature (r, s) of the number alpha,
+// steps 1-7 of the section 6.2 of the standard.
+func VerifyInt(c *curves.Curve, x, y, alpha, r, s *big.Int) bool {
+	if r.Sign() <= 0 || r.Cmp(c.Q) >= 0 || s.Sign() <= 0 || s.Cmp(c.Q) >= 0 {
+		return false
+	}
+
+	e := new(big.Int).Mod(alpha, c.Q)
+	if e.Sign() == 0 {
+		e.SetInt64(1)
+	}
+
+	v := new(big.Int).ModInverse(e, c.Q)
+	z1 := new(big.Int).Mul(s, v)
+	z1.Mod(z1, c.Q)
+	z2 := new(big.Int).Mul(r, v)
+	z2.Neg(z2)
+	z2.Mod(z2, c.Q)
+
+	cx, _ := c.CombinedMult(x, y, z1, z2)
+	if cx == nil {
+		return false
+	}
+	return cx.Mod(cx, c.Q).Cmp(r) == 0
+}
@@ -0,0 +1,159 @@
+// go test -v -bench=. -benchtime=100x
+package gost3410
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/towleeee/go-cryptopro/internal/curves"
+)
+
+// Examples of the appendix A of the standard (RFC 7091, section 7).
+var examples = []struct {
+	name       string
+	curve      *curves.Curve
+	d, xq, yq  string
+	e, k, r, s string
+}{
+	{
+		name: "A.1",
+		curve: &curves.Curve{
+			Name:     "test 256",
+			P:        fromHex("8000000000000000000000000000000000000000000000000000000000000431"),
+			A:        big.NewInt(7),
+			B:        fromHex("5FBFF498AA938CE739B8E022FBAFEF40563F6E6A3472FC2A514C0CE9DAE23B7E"),
+			Q:        fromHex("8000000000000000000000000000000150FE8A1892976154C59CFC193ACCF5B3"),
+			Cofactor: 1,
+			X:        big.NewInt(2),
+			Y:        fromHex("08E2A8A0E65147D4BD6316030E16D19C85C97F0A9CA267122B96ABBCEA7E8FC8"),
+			Size:     32,
+		},
+		d:  "7A929ADE789BB9BE10ED359DD39A72C11B60961F49397EEE1D19CE9891EC3B28",
+		xq: "7F2B49E270DB6D90D8595BEC458B50C58585BA1D4E9B788F6689DBD8E56FD80B",
+		yq: "26F1B489D6701DD185C8413A977B3CBBAF64D1C593D26627DFFB101A87FF77DA",
+		e:  "2DFBC1B372D89A1188C09C52E0EEC61FCE52032AB1022E8E67ECE6672B043EE5",
+		k:  "77105C9B20BCD3122823C8CF6FCC7B956DE33814E95B7FE64FED924594DCEAB3",
+		r:  "41AA28D2F1AB148280CD9ED56FEDA41974053554A42767B83AD043FD39DC0493",
+		s:  "01456C64BA4642A1653C235A98A60249BCD6D3F746B631DF928014F6C5BF9C40",
+	},
+	{
+		name:  "A.2",
+		curve: curves.Tc26Gost512Test,
+		d: "0BA6048AADAE241BA40936D47756D7C93091A0E8514669700EE7508E508B1020" +
+			"72E8123B2200A0563322DAD2827E2714A2636B7BFD18AADFC62967821FA18DD4",
+		xq: "115DC5BC96760C7B48598D8AB9E740D4C4A85A65BE33C1815B5C320C854621DD" +
+			"5A515856D13314AF69BC5B924C8B4DDFF75C45415C1D9DD9DD33612CD530EFE1",
+		yq: "37C7C90CD40B0F5621DC3AC1B751CFA0E2634FA0503B3D52639F5D7FB72AFD61" +
+			"EA199441D943FFE7F0C70A2759A3CDB84C114E1F9339FDF27F35ECA93677BEEC",
+		e: "3754F3CFACC9E0615C4F4A7C4D8DAB531B09B6F9C170C533A71D147035B0C591" +
+			"7184EE536593F4414339976C647C5D5A407ADEDB1D560C4FC6777D2972075B8C",
+		k: "0359E7F4B1410FEACC570456C6801496946312120B39D019D455986E364F3658" +
+			"86748ED7A44B3E794434006011842286212273A6D14CF70EA3AF71BB1AE679F1",
+		r: "2F86FA60A081091A23DD795E1E3C689EE512A3C82EE0DCC2643C78EEA8FCACD3" +
+			"5492558486B20F1C9EC197C90699850260C93BCBCD9C5C3317E19344E173AE36",
+		s: "1081B394696FFE8E6585E7A9362D26B6325F56778AADBC081C0BFBE933D52FF5" +
+			"823CE288E8C4F362526080DF7F70CE406A6EEB1F56919CB92A9853BDE73E5B4A",
+	},
+}
+
+func fromHex(s string) *big.Int {
+	v, _ := new(big.Int).SetString(s, 16)
+	return v
+}
+
+func TestExamples(t *testing.T) {
+	for _, v := range examples {
+		var (
+			c  = v.curve
+			xq = fromHex(v.xq)
+			yq = fromHex(v.yq)
+			e  = fromHex(v.e)
+			r  = fromHex(v.r)
+			s  = fromHex(v.s)
+		)
+
+		if x, y := c.ScalarBaseMult(fromHex(v.d)); x.Cmp(xq) != 0 || y.Cmp(yq) != 0 {
+			t.Errorf("test failed: public key of %s", v.name)
+			return
+		}
+
+		if sr, ss := SignInt(c, fromHex(v.d), e, fromHex(v.k)); sr.Cmp(r) != 0 || ss.Cmp(s) != 0 {
+			t.Errorf("test failed: sign %s", v.name)
+			return
+		}
+
+		if !VerifyInt(c, xq, yq, e, r, s) {
+			t.Errorf("test failed: verify %s", v.name)
+			return
+		}
+
+		if VerifyInt(c, xq, yq, new(big.Int).Add(e, big.NewInt(1)), r, s) {
+			t.Errorf("test failed: verify other digest %s", v.name)
+			return
+		}
+		if VerifyInt(c, xq, yq, e, s, r) {
+			t.Errorf("test failed: verify swapped r, s %s", v.name)
+			return
+		}
+		if VerifyInt(c, xq, yq, e, new(big.Int).Add(r, c.Q), s) {
+			t.Errorf("test failed: verify r >= q %s", v.name)
+			return
+		}
+
+		// The digest of CryptoAPI is the little-endian number.
+		digest := e.FillBytes(make([]byte, c.Size))
+		for i, j := 0, len(digest)-1; i < j; i, j = i+1, j-1 {
+			digest[i], digest[j] = digest[j], digest[i]
+		}
+		if !Verify(c, xq, yq, digest, r, s) {
+			t.Errorf("test failed: verify digest %s", v.name)
+			return
+		}
+
+		sign := MarshalSignature(c, r, s)
+		if ur, us := UnmarshalSignature(sign); ur.Cmp(r) != 0 || us.Cmp(s) != 0 {
+			t.Errorf("test failed: signature encoding %s", v.name)
+			return
+		}
+	}
+}
+
+func TestRandScalar(t *testing.T) {
+	c := curves.CryptoProB
+
+	for _, fill := range []byte{0x00, 0xff} {
+		k, err := RandScalar(c, bytes.NewReader(bytes.Repeat([]byte{fill}, c.Size+8)))
+		if err != nil {
+			t.Errorf("test failed: %s", err)
+			return
+		}
+		if k.Sign() <= 0 || k.Cmp(c.Q) >= 0 {
+			t.Errorf("test failed: k out of range %x", k)
+			return
+		}
+	}
+
+	// The extra bits are read in full.
+	if _, err := RandScalar(c, bytes.NewReader(make([]byte, c.Size))); err == nil {
+		t.Errorf("test failed: short read")
+		return
+	}
+}
+
+func BenchmarkVerify256(b *testing.B) {
+	v := examples[0]
+	var (
+		xq = fromHex(v.xq)
+		yq = fromHex(v.yq)
+		e  = fromHex(v.e)
+		r  = fromHex(v.r)
+		s  = fromHex(v.s)
+	)
+	for i := 0; i < b.N; i++ {
+		if !VerifyInt(v.curve, xq, yq, e, r, s) {
+			b.Errorf("benchmark failed: verify")
+			break
+		}
+	}
+}
@@ -0,0 +1,163 @@
+// CryptoAPI PUBLICKEYBLOB of GOST R 34.10-2012 keys
+// in the form used by the gost_r_34_10_2012 packages:
+// {1: prov, 8: BLOBHEADER, 8: CRYPT_PUBKEYPARAM, N: DER params, M: X||Y}.
+package keyblob
+
+import (
+	"encoding/asn1"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/towleeee/go-cryptopro/internal/oids"
+)
+
+const (
+	K256 byte = 80
+	K512 byte = 81
+)
+
+const (
+	CALG_GR3410_12_256 uint32 = 0x2E49
+	CALG_GR3410_12_512 uint32 = 0x2E3D
+
+	CALG_DH_GR3410_12_256_SF    uint32 = 0xAA46
+	CALG_DH_GR3410_12_256_EPHEM uint32 = 0xAA47
+	CALG_DH_GR3410_12_512_SF    uint32 = 0xAA42
+	CALG_DH_GR3410_12_512_EPHEM uint32 = 0xAA43
+)
+
+const (
+	PUBLICKEYBLOB  = 0x06
+	BLOB_VERSION   = 0x20
+	GR3410_1_MAGIC = 0x3147414D
+
+	headerSize = 16
+)
+
+// Size of the coordinates X||Y in bytes.
+func PointSize(prov byte) int {
+	switch prov {
+	case K256:
+		return 64
+	case K512:
+		return 128
+	default:
+		return -1
+	}
+}
+
+// Digest param set of the key blob: the key blobs of CSP always have it,
+// GOST R 34.11-2012 of the size of the key unless it is set on generation.
+func DigestSet(prov byte) asn1.ObjectIdentifier {
+	switch prov {
+	case K256:
+		return oids.GostR3411_12_256
+	case K512:
+		return oids.GostR3411_12_512
+	default:
+		return nil
+	}
+}
+
+// Algorithm of the ephemeral exchange keys.
+func EphemAlgID(prov byte) uint32 {
+	if prov == K512 {
+		return CALG_DH_GR3410_12_512_EPHEM
+	}
+	return CALG_DH_GR3410_12_256_EPHEM
+}
+
+// GostR3410-2012-PublicKeyParameters (RFC 9215).
+type Params struct {
+	ParamSet  asn1.ObjectIdentifier
+	DigestSet asn1.ObjectIdentifier `asn1:"optional"`
+}
+
+// Decoded public key blob.
+type PublicKey struct {
+	Prov   byte
+	AlgID  uint32
+	Params Params
+	// Little-endian X || little-endian Y.
+	Point []byte
+}
+
+// Parsing of the bytes {prov || PUBLICKEYBLOB}.
+func Parse(pbytes []byte) (*PublicKey, error) {
+	if len(pbytes) < 1+headerSize {
+		return nil, fmt.Errorf("error: length of public key")
+	}
+
+	var (
+		prov = pbytes[0]
+		blob = pbytes[1:]
+	)
+
+	size := PointSize(prov)
+	if size < 0 {
+		return nil, fmt.Errorf("error: read prov type")
+	}
+
+	if blob[0] != PUBLICKEYBLOB || blob[1] != BLOB_VERSION {
+		return nil, fmt.Errorf("error: public key blob header")
+	}
+	if binary.LittleEndian.Uint32(blob[8:12]) != GR3410_1_MAGIC {
+		return nil, fmt.Errorf("error: public key blob magic")
+	}
+	if int(binary.LittleEndian.Uint32(blob[12:16])) != size*8 {
+		return nil, fmt.Errorf("error: public key blob bit length")
+	}
+
+	var params Params
+	rest, err := asn1.Unmarshal(blob[headerSize:], &params)
+	if err != nil {
+		return nil, fmt.Errorf("error: public key blob params: %w", err)
+	}
+	if len(rest) != size {
+		return nil, fmt.Errorf("error: length of public key point")
+	}
+
+	return &PublicKey{
+		Prov:   prov,
+		AlgID:  binary.LittleEndian.Uint32(blob[4:8]),
+		Params: params,
+		Point:  append([]byte{}, rest...),
+	}, nil
+}
+
+// Encoding to the bytes {prov || PUBLICKEYBLOB}.
+// Zero AlgID is replaced by the signature algorithm of the prov.
+func (key *PublicKey) Bytes() ([]byte, error) {
+	size := PointSize(key.Prov)
+	if size < 0 {
+		return nil, fmt.Errorf("error: read prov type")
+	}
+	if len(key.Point) != size {
+		return nil, fmt.Errorf("error: length of public key point")
+	}
+
+	params, err := asn1.Marshal(key.Params)
+	if err != nil {
+		return nil, err
+	}
+
+	alg := key.AlgID
+	if alg == 0 {
+		alg = CALG_GR3410_12_256
+		if key.Prov == K512 {
+			alg = CALG_GR3410_12_512
+		}
+	}
+
+	out := make([]byte, 1+headerSize, 1+headerSize+len(params)+size)
+	out[0] = key.Prov
+	out[1] = PUBLICKEYBLOB
+	out[2] = BLOB_VERSION
+	binary.LittleEndian.PutUint32(out[5:9], alg)
+	binary.LittleEndian.PutUint32(out[9:13], GR3410_1_MAGIC)
+	binary.LittleEndian.PutUint32(out[13:17], uint32(size*8))
+	out = append(out, params...)
+	out = append(out, key.Point...)
+
+	return out, nil
+}
@@ -0,0 +1,110 @@
+// go test -v -bench=. -benchtime=100x
+package keyblob
+
+import (
+	"bytes"
+	"encoding/asn1"
+	"encoding/hex"
+	"testing"
+)
+
+const (
+	TEST_PUBKEY_256 = "5006200000492e00004d41473100020000301306072a85030202230106082a850307010102022f6197366b7cd9fb002ec3b7b8ab066fed6a514617a01c6a3ea5124b6acde80fbdb3004940753e0bb350e3f08f9a778dc87b14836a7d7ecf0ec53e49ccdce28e"
+)
+
+func TestParseBytes(t *testing.T) {
+	pbytes, _ := hex.DecodeString(TEST_PUBKEY_256)
+
+	key, err := Parse(pbytes)
+	if err != nil {
+		t.Errorf("test failed: parse (%s)", err)
+		return
+	}
+
+	if key.Prov != K256 || key.AlgID != CALG_GR3410_12_256 {
+		t.Errorf("test failed: prov or algorithm")
+		return
+	}
+
+	if !key.Params.ParamSet.Equal(asn1.ObjectIdentifier{1, 2, 643, 2, 2, 35, 1}) {
+		t.Errorf("test failed: param set")
+		return
+	}
+
+	if !key.Params.DigestSet.Equal(asn1.ObjectIdentifier{1, 2, 643, 7, 1, 1, 2, 2}) {
+		t.Errorf("test failed: digest set")
+		return
+	}
+
+	if len(key.Point) != PointSize(K256) {
+		t.Errorf("test failed: length of point")
+		return
+	}
+
+	encoded, err := key.Bytes()
+	if err != nil {
+		t.Errorf("test failed: bytes (%s)", err)
+		return
+	}
+
+	if !bytes.Equal(encoded, pbytes) {
+		t.Errorf("test failed: encoded != original")
+		return
+	}
+
+	if _, err := Parse(pbytes[:len(pbytes)-1]); err == nil {
+		t.Errorf("test failed: parse truncated blob")
+		return
+	}
+}
+
+func TestPKIX(t *testing.T) {
+	pbytes, _ := hex.DecodeString(TEST_PUBKEY_256)
+
+	der, err := MarshalPKIX(pbytes)
+	if err != nil {
+		t.Errorf("test failed: marshal pkix (%s)", err)
+		return
+	}
+
+	// SEQUENCE { SEQUENCE { id-tc26-gost3410-12-256, params }, BIT STRING { OCTET STRING X||Y } }
+	prefix, _ := hex.DecodeString("3066301f06082a85030701010101301306072a85030202230106082a850307010102020343000440")
+	if !bytes.HasPrefix(der, prefix) || !bytes.Equal(der[len(prefix):], pbytes[len(pbytes)-PointSize(K256):]) {
+		t.Errorf("test failed: marshal pkix %X", der)
+		return
+	}
+
+	decoded, err := UnmarshalPKIX(der, 0)
+	if err != nil {
+		t.Errorf("test failed: unmarshal pkix (%s)", err)
+		return
+	}
+	if !bytes.Equal(decoded, pbytes) {
+		t.Errorf("test failed: decoded != original")
+		return
+	}
+
+	decoded, err = UnmarshalPKIX(der, EphemAlgID(K256))
+	if err != nil {
+		t.Errorf("test failed: unmarshal pkix eph (%s)", err)
+		return
+	}
+	key, err := Parse(decoded)
+	if err != nil || key.AlgID != CALG_DH_GR3410_12_256_EPHEM {
+		t.Errorf("test failed: algorithm of eph key")
+		return
+	}
+
+	if _, err := UnmarshalPKIX(append(der, 0), 0); err == nil {
+		t.Errorf("test failed: unmarshal trailing data")
+		return
+	}
+
+	// The 256 bit key with the 512 bit algorithm.
+	wrong := append([]byte{}, der...)
+	wrong[13] = 0x02
+	if _, err := UnmarshalPKIX(wrong, 0); err == nil {
+		t.Errorf("test failed: unmarshal wrong algorithm")
+		return
+	}
+}
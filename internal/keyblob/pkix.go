@@ -0,0 +1,160 @@
+package keyblob
+
+import (
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"fmt"
+
+	"github.com/towleeee/go-cryptopro/internal/oids"
+)
+
+/*
+ * SUBJECT PUBLIC KEY INFO
+ */
+
+// SubjectPublicKeyInfo (RFC 5280).
+type SubjectPublicKeyInfo struct {
+	Raw       asn1.RawContent
+	Algorithm pkix.AlgorithmIdentifier
+	PublicKey asn1.BitString
+}
+
+// Conversion to SubjectPublicKeyInfo (RFC 4491, RFC 9215).
+// The digest param set is kept only for the CryptoPro param sets.
+func (key *PublicKey) PKIX() (*SubjectPublicKeyInfo, error) {
+	if len(key.Point) != PointSize(key.Prov) {
+		return nil, fmt.Errorf("error: length of public key point")
+	}
+
+	alg := oids.GostR3410_12_256
+	if key.Prov == K512 {
+		alg = oids.GostR3410_12_512
+	}
+
+	params := key.Params
+	if key.Prov == K512 || isTc26ParamSet(params.ParamSet) {
+		params.DigestSet = nil
+	}
+
+	paramsBytes, err := asn1.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+	point, err := asn1.Marshal(key.Point)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SubjectPublicKeyInfo{
+		Algorithm: pkix.AlgorithmIdentifier{
+			Algorithm:  alg,
+			Parameters: asn1.RawValue{FullBytes: paramsBytes},
+		},
+		PublicKey: asn1.BitString{
+			Bytes:     point,
+			BitLength: 8 * len(point),
+		},
+	}, nil
+}
+
+// Conversion of SubjectPublicKeyInfo to the key blob
+// with the algorithm algID (the signature algorithm if zero).
+// The params are returned as they are in SubjectPublicKeyInfo,
+// the key blob gets the digest param set of the algorithm if it is omitted.
+func ParsePKIX(spki *SubjectPublicKeyInfo, algID uint32) (*PublicKey, Params, error) {
+	var (
+		params Params
+		prov   byte
+	)
+
+	switch alg := spki.Algorithm.Algorithm; {
+	case alg.Equal(oids.GostR3410_12_256):
+		prov = K256
+	case alg.Equal(oids.GostR3410_12_512):
+		prov = K512
+	default:
+		return nil, params, fmt.Errorf("error: public key algorithm %s", alg)
+	}
+
+	rest, err := asn1.Unmarshal(spki.Algorithm.Parameters.FullBytes, &params)
+	if err != nil {
+		return nil, params, fmt.Errorf("error: parse public key params: %w", err)
+	}
+	if len(rest) != 0 {
+		return nil, params, fmt.Errorf("error: trailing data after public key params")
+	}
+
+	size := oids.ParamSetSize(params.ParamSet)
+	if (prov == K256 && size != 256) || (prov == K512 && size != 512) {
+		return nil, params, fmt.Errorf("error: public key param set %s", params.ParamSet)
+	}
+
+	var point []byte
+	rest, err = asn1.Unmarshal(spki.PublicKey.RightAlign(), &point)
+	if err != nil {
+		return nil, params, fmt.Errorf("error: parse public key: %w", err)
+	}
+	if len(rest) != 0 {
+		return nil, params, fmt.Errorf("error: trailing data after public key")
+	}
+	if len(point) != PointSize(prov) {
+		return nil, params, fmt.Errorf("error: length of public key point")
+	}
+
+	// The digest param set is optional in certificates
+	// (omitted for 512 bit keys), but the key blob of CSP has it.
+	blobParams := params
+	if blobParams.DigestSet == nil {
+		blobParams.DigestSet = DigestSet(prov)
+	}
+
+	return &PublicKey{
+		Prov:   prov,
+		AlgID:  algID,
+		Params: blobParams,
+		Point:  point,
+	}, params, nil
+}
+
+// Encoding of the key blob {prov || PUBLICKEYBLOB}
+// to DER of SubjectPublicKeyInfo.
+func MarshalPKIX(pbytes []byte) ([]byte, error) {
+	key, err := Parse(pbytes)
+	if err != nil {
+		return nil, err
+	}
+	spki, err := key.PKIX()
+	if err != nil {
+		return nil, err
+	}
+	return asn1.Marshal(*spki)
+}
+
+// Decoding of DER of SubjectPublicKeyInfo
+// to the key blob {prov || PUBLICKEYBLOB}.
+func UnmarshalPKIX(der []byte, algID uint32) ([]byte, error) {
+	var spki SubjectPublicKeyInfo
+	rest, err := asn1.Unmarshal(der, &spki)
+	if err != nil {
+		return nil, fmt.Errorf("error: parse public key info: %w", err)
+	}
+	if len(rest) != 0 {
+		return nil, fmt.Errorf("error: trailing data after public key info")
+	}
+	key, _, err := ParsePKIX(&spki, algID)
+	if err != nil {
+		return nil, err
+	}
+	return key.Bytes()
+}
+
+func isTc26ParamSet(paramSet asn1.ObjectIdentifier) bool {
+	for _, v := range []asn1.ObjectIdentifier{
+		oids.Tc26Gost256A, oids.Tc26Gost256B, oids.Tc26Gost256C, oids.Tc26Gost256D,
+	} {
+		if v.Equal(paramSet) {
+			return true
+		}
+	}
+	return false
+}
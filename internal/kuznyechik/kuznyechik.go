@@ -0,0 +1,190 @@
+// Block cipher GOST R 34.12-2015 (Kuznyechik, RFC 7801) in pure Go.
+// The blocks and the key are the big-endian numbers of the standard.
+package kuznyechik
+
+import (
+	"crypto/cipher"
+	"fmt"
+)
+
+const (
+	BlockSize = 16
+	KeySize   = 32
+)
+
+var (
+	_ cipher.Block = &Cipher{}
+)
+
+type block [BlockSize]byte
+
+type Cipher struct {
+	keys [10]block
+}
+
+// Cipher with the key of 32 bytes.
+func NewCipher(key []byte) (*Cipher, error) {
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("error: key length != %d", KeySize)
+	}
+
+	c := new(Cipher)
+	copy(c.keys[0][:], key[:BlockSize])
+	copy(c.keys[1][:], key[BlockSize:])
+
+	// Feistel network with the constants C_i = L(Vec128(i)).
+	k1, k2 := c.keys[0], c.keys[1]
+	for i := 0; i < 4; i++ {
+		for j := 1; j <= 8; j++ {
+			var ci block
+			ci[BlockSize-1] = byte(8*i + j)
+			ci = l(ci)
+
+			t := lsx(k1, ci)
+			for n := range t {
+				t[n] ^= k2[n]
+			}
+			k1, k2 = t, k1
+		}
+		c.keys[2*i+2], c.keys[2*i+3] = k1, k2
+	}
+	return c, nil
+}
+
+func (c *Cipher) BlockSize() int {
+	return BlockSize
+}
+
+func (c *Cipher) Encrypt(dst, src []byte) {
+	var b block
+	copy(b[:], src[:BlockSize])
+	for i := 0; i < 9; i++ {
+		b = lsx(b, c.keys[i])
+	}
+	for n := range b {
+		b[n] ^= c.keys[9][n]
+	}
+	copy(dst, b[:])
+}
+
+func (c *Cipher) Decrypt(dst, src []byte) {
+	var b block
+	copy(b[:], src[:BlockSize])
+	for n := range b {
+		b[n] ^= c.keys[9][n]
+	}
+	for i := 8; i >= 0; i-- {
+		b = lInv(b)
+		for n := range b {
+			b[n] = piInv[b[n]] ^ c.keys[i][n]
+		}
+	}
+	copy(dst, b[:])
+}
+
+// LSX[k](a) by the table of the composition of S and L.
+func lsx(a, k block) block {
+	var out block
+	for i := range a {
+		t := &ls[i][pi[a[i]^k[i]]]
+		for n := range out {
+			out[n] ^= t[n]
+		}
+	}
+	return out
+}
+
+// Transformation L: 16 rounds of R.
+func l(a block) block {
+	for i := 0; i < BlockSize; i++ {
+		a = r(a)
+	}
+	return a
+}
+
+// R(a15 || ... || a0) = l(a15, ..., a0) || a15 || ... || a1.
+func r(a block) block {
+	var x byte
+	for i := range a {
+		x ^= mul(a[i], lc[i])
+	}
+	copy(a[1:], a[:BlockSize-1])
+	a[0] = x
+	return a
+}
+
+func lInv(a block) block {
+	for i := 0; i < BlockSize; i++ {
+		x := a[0]
+		copy(a[:], a[1:])
+		a[BlockSize-1] = x
+		x = 0
+		for n := range a {
+			x ^= mul(a[n], lc[n])
+		}
+		a[BlockSize-1] = x
+	}
+	return a
+}
+
+// Multiplication in GF(2^8) by the polynomial x^8 + x^7 + x^6 + x + 1.
+func mul(a, b byte) byte {
+	var p byte
+	for b != 0 {
+		if b&1 != 0 {
+			p ^= a
+		}
+		hi := a & 0x80
+		a <<= 1
+		if hi != 0 {
+			a ^= 0xc3
+		}
+		b >>= 1
+	}
+	return p
+}
+
+/*
+ * CONSTANTS
+ */
+
+// Coefficients of the linear function l.
+var lc = block{148, 32, 133, 16, 194, 192, 1, 251, 1, 192, 194, 16, 133, 32, 148, 1}
+
+var pi = [256]byte{
+	252, 238, 221, 17, 207, 110, 49, 22, 251, 196, 250, 218, 35, 197, 4, 77,
+	233, 119, 240, 219, 147, 46, 153, 186, 23, 54, 241, 187, 20, 205, 95, 193,
+	249, 24, 101, 90, 226, 92, 239, 33, 129, 28, 60, 66, 139, 1, 142, 79,
+	5, 132, 2, 174, 227, 106, 143, 160, 6, 11, 237, 152, 127, 212, 211, 31,
+	235, 52, 44, 81, 234, 200, 72, 171, 242, 42, 104, 162, 253, 58, 206, 204,
+	181, 112, 14, 86, 8, 12, 118, 18, 191, 114, 19, 71, 156, 183, 93, 135,
+	21, 161, 150, 41, 16, 123, 154, 199, 243, 145, 120, 111, 157, 158, 178, 177,
+	50, 117, 25, 61, 255, 53, 138, 126, 109, 84, 198, 128, 195, 189, 13, 87,
+	223, 245, 36, 169, 62, 168, 67, 201, 215, 121, 214, 246, 124, 34, 185, 3,
+	224, 15, 236, 222, 122, 148, 176, 188, 220, 232, 40, 80, 78, 51, 10, 74,
+	167, 151, 96, 115, 30, 0, 98, 68, 26, 184, 56, 130, 100, 159, 38, 65,
+	173, 69, 70, 146, 39, 94, 85, 47, 140, 163, 165, 125, 105, 213, 149, 59,
+	7, 88, 179, 64, 134, 172, 29, 247, 48, 55, 107, 228, 136, 217, 231, 137,
+	225, 27, 131, 73, 76, 63, 248, 254, 141, 83, 170, 144, 202, 216, 133, 97,
+	32, 113, 103, 164, 45, 43, 9, 91, 203, 155, 37, 208, 190, 229, 108, 82,
+	89, 166, 116, 210, 230, 244, 180, 192, 209, 102, 175, 194, 57, 75, 99, 182,
+}
+
+var piInv = func() (inv [256]byte) {
+	for i, v := range pi {
+		inv[v] = byte(i)
+	}
+	return inv
+}()
+
+// L of the block with the only byte x at the position i.
+var ls = func() (t [BlockSize][256]block) {
+	for i := 0; i < BlockSize; i++ {
+		for x := 0; x < 256; x++ {
+			var b block
+			b[i] = byte(x)
+			t[i][x] = l(b)
+		}
+	}
+	return t
+}()
@@ -0,0 +1,99 @@
+// go test -v -bench=. -benchtime=100x
+package kuznyechik
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+)
+
+// Examples of the appendix A.1 of GOST R 34.12-2015.
+var (
+	TEST_KEY, _        = hex.DecodeString("8899aabbccddeeff0011223344556677fedcba98765432100123456789abcdef")
+	TEST_PLAINTEXT, _  = hex.DecodeString("1122334455667700ffeeddccbbaa9988")
+	TEST_CIPHERTEXT, _ = hex.DecodeString("7f679d90bebc24305a468d42b9d4edcd")
+)
+
+func fromHex(s string) (b block) {
+	v, _ := hex.DecodeString(s)
+	copy(b[:], v)
+	return b
+}
+
+func TestTransformations(t *testing.T) {
+	if r(fromHex("00000000000000000000000000000100")) != fromHex("94000000000000000000000000000001") {
+		t.Errorf("test failed: R")
+		return
+	}
+	if l(fromHex("64a59400000000000000000000000000")) != fromHex("d456584dd0e3e84cc3166e4b7fa2890d") {
+		t.Errorf("test failed: L")
+		return
+	}
+	if lInv(fromHex("d456584dd0e3e84cc3166e4b7fa2890d")) != fromHex("64a59400000000000000000000000000") {
+		t.Errorf("test failed: L^-1")
+		return
+	}
+}
+
+func TestKeys(t *testing.T) {
+	c, err := NewCipher(TEST_KEY)
+	if err != nil {
+		t.Errorf("test failed: new cipher")
+		return
+	}
+	for i, v := range []string{
+		"8899aabbccddeeff0011223344556677",
+		"fedcba98765432100123456789abcdef",
+		"db31485315694343228d6aef8cc78c44",
+		"3d4553d8e9cfec6815ebadc40a9ffd04",
+		"57646468c44a5e28d3e59246f429f1ac",
+		"bd079435165c6432b532e82834da581b",
+		"51e640757e8745de705727265a0098b1",
+		"5a7925017b9fdd3ed72a91a22286f984",
+		"bb44e25378c73123a5f32f73cdb6e517",
+		"72e9dd7416bcf45b755dbaa88e4a4043",
+	} {
+		if c.keys[i] != fromHex(v) {
+			t.Errorf("test failed: round key %d", i+1)
+			return
+		}
+	}
+}
+
+func TestEncrypt(t *testing.T) {
+	c, err := NewCipher(TEST_KEY)
+	if err != nil {
+		t.Errorf("test failed: new cipher")
+		return
+	}
+
+	out := make([]byte, BlockSize)
+	c.Encrypt(out, TEST_PLAINTEXT)
+	if !bytes.Equal(out, TEST_CIPHERTEXT) {
+		t.Errorf("test failed: encrypt %x", out)
+		return
+	}
+
+	c.Decrypt(out, out)
+	if !bytes.Equal(out, TEST_PLAINTEXT) {
+		t.Errorf("test failed: decrypt %x", out)
+		return
+	}
+
+	if _, err := NewCipher(TEST_KEY[1:]); err == nil {
+		t.Errorf("test failed: short key")
+		return
+	}
+}
+
+func BenchmarkEncrypt(b *testing.B) {
+	c, err := NewCipher(TEST_KEY)
+	if err != nil {
+		b.Errorf("benchmark failed: new cipher")
+		return
+	}
+	out := make([]byte, BlockSize)
+	for i := 0; i < b.N; i++ {
+		c.Encrypt(out, TEST_PLAINTEXT)
+	}
+}
@@ -0,0 +1,126 @@
+// Object identifiers of GOST algorithms and PKIX structures.
+// https://datatracker.ietf.org/doc/html/rfc9215
+package oids
+
+import (
+	"encoding/asn1"
+)
+
+/*
+ * GOST R 34.10-2012, GOST R 34.11-2012
+ */
+
+var (
+	GostR3410_12_256 = asn1.ObjectIdentifier{1, 2, 643, 7, 1, 1, 1, 1}
+	GostR3410_12_512 = asn1.ObjectIdentifier{1, 2, 643, 7, 1, 1, 1, 2}
+
+	GostR3411_12_256 = asn1.ObjectIdentifier{1, 2, 643, 7, 1, 1, 2, 2}
+	GostR3411_12_512 = asn1.ObjectIdentifier{1, 2, 643, 7, 1, 1, 2, 3}
+
+	SignWithDigest256 = asn1.ObjectIdentifier{1, 2, 643, 7, 1, 1, 3, 2}
+	SignWithDigest512 = asn1.ObjectIdentifier{1, 2, 643, 7, 1, 1, 3, 3}
+)
+
+// Parameter sets of GOST R 34.10-2012 keys.
+var (
+	CryptoProA    = asn1.ObjectIdentifier{1, 2, 643, 2, 2, 35, 1}
+	CryptoProB    = asn1.ObjectIdentifier{1, 2, 643, 2, 2, 35, 2}
+	CryptoProC    = asn1.ObjectIdentifier{1, 2, 643, 2, 2, 35, 3}
+	CryptoProXchA = asn1.ObjectIdentifier{1, 2, 643, 2, 2, 36, 0}
+	CryptoProXchB = asn1.ObjectIdentifier{1, 2, 643, 2, 2, 36, 1}
+
+	Tc26Gost256A = asn1.ObjectIdentifier{1, 2, 643, 7, 1, 2, 1, 1, 1}
+	Tc26Gost256B = asn1.ObjectIdentifier{1, 2, 643, 7, 1, 2, 1, 1, 2}
+	Tc26Gost256C = asn1.ObjectIdentifier{1, 2, 643, 7, 1, 2, 1, 1, 3}
+	Tc26Gost256D = asn1.ObjectIdentifier{1, 2, 643, 7, 1, 2, 1, 1, 4}
+
+	Tc26Gost512Test = asn1.ObjectIdentifier{1, 2, 643, 7, 1, 2, 1, 2, 0}
+	Tc26Gost512A    = asn1.ObjectIdentifier{1, 2, 643, 7, 1, 2, 1, 2, 1}
+	Tc26Gost512B    = asn1.ObjectIdentifier{1, 2, 643, 7, 1, 2, 1, 2, 2}
+	Tc26Gost512C    = asn1.ObjectIdentifier{1, 2, 643, 7, 1, 2, 1, 2, 3}
+)
+
+var (
+	ParamSets256 = []asn1.ObjectIdentifier{
+		CryptoProA, CryptoProB, CryptoProC, CryptoProXchA, CryptoProXchB,
+		Tc26Gost256A, Tc26Gost256B, Tc26Gost256C, Tc26Gost256D,
+	}
+	ParamSets512 = []asn1.ObjectIdentifier{
+		Tc26Gost512Test, Tc26Gost512A, Tc26Gost512B, Tc26Gost512C,
+	}
+)
+
+/*
+ * X.509 (RFC 5280), russian attributes of the name
+ */
+
+var (
+	AttrINN    = asn1.ObjectIdentifier{1, 2, 643, 3, 131, 1, 1}
+	AttrOGRN   = asn1.ObjectIdentifier{1, 2, 643, 100, 1}
+	AttrSNILS  = asn1.ObjectIdentifier{1, 2, 643, 100, 3}
+	AttrINNLE  = asn1.ObjectIdentifier{1, 2, 643, 100, 4}
+	AttrOGRNIP = asn1.ObjectIdentifier{1, 2, 643, 100, 5}
+
+	AttrSurname      = asn1.ObjectIdentifier{2, 5, 4, 4}
+	AttrTitle        = asn1.ObjectIdentifier{2, 5, 4, 12}
+	AttrGivenName    = asn1.ObjectIdentifier{2, 5, 4, 42}
+	AttrEmailAddress = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 1}
+)
+
+var (
+	ExtSubjectKeyId          = asn1.ObjectIdentifier{2, 5, 29, 14}
+	ExtKeyUsage              = asn1.ObjectIdentifier{2, 5, 29, 15}
+	ExtSubjectAltName        = asn1.ObjectIdentifier{2, 5, 29, 17}
+	ExtBasicConstraints      = asn1.ObjectIdentifier{2, 5, 29, 19}
+	ExtCRLDistributionPoints = asn1.ObjectIdentifier{2, 5, 29, 31}
+	ExtCRLNumber             = asn1.ObjectIdentifier{2, 5, 29, 20}
+	ExtCRLReason             = asn1.ObjectIdentifier{2, 5, 29, 21}
+	ExtCertificatePolicies   = asn1.ObjectIdentifier{2, 5, 29, 32}
+	ExtAuthorityKeyId        = asn1.ObjectIdentifier{2, 5, 29, 35}
+	ExtExtKeyUsage           = asn1.ObjectIdentifier{2, 5, 29, 37}
+
+	ExtSubjectSignTool = asn1.ObjectIdentifier{1, 2, 643, 100, 111}
+	ExtIssuerSignTool  = asn1.ObjectIdentifier{1, 2, 643, 100, 112}
+)
+
+/*
+ * CMS (RFC 5652), CAdES (RFC 5035)
+ */
+
+var (
+	Data       = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 1}
+	SignedData = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 2}
+
+	AttrContentType          = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 3}
+	AttrMessageDigest        = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 4}
+	AttrSigningTime          = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 5}
+	AttrCounterSignature     = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 6}
+	AttrSigningCertificateV2 = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 16, 2, 47}
+)
+
+// Key size of the parameter set: 256, 512 or 0 if unknown.
+func ParamSetSize(paramSet asn1.ObjectIdentifier) int {
+	for _, v := range ParamSets256 {
+		if v.Equal(paramSet) {
+			return 256
+		}
+	}
+	for _, v := range ParamSets512 {
+		if v.Equal(paramSet) {
+			return 512
+		}
+	}
+	return 0
+}
+
+// Digest algorithm of the GOST R 34.10-2012 key algorithm.
+func DigestOf(keyAlg asn1.ObjectIdentifier) asn1.ObjectIdentifier {
+	switch {
+	case keyAlg.Equal(GostR3410_12_256), keyAlg.Equal(SignWithDigest256):
+		return GostR3411_12_256
+	case keyAlg.Equal(GostR3410_12_512), keyAlg.Equal(SignWithDigest512):
+		return GostR3411_12_512
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,316 @@
+// Hash function GOST R 34.11-2012 (Streebog, RFC 6986) in pure Go
+// with the byte order of the digest of CryptoAPI.
+package streebog
+
+import (
+	"encoding"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"hash"
+)
+
+const (
+	BlockSize = 64
+	Size256   = 32
+	Size512   = 64
+)
+
+var (
+	_ hash.Hash                  = &digest{}
+	_ encoding.BinaryMarshaler   = &digest{}
+	_ encoding.BinaryUnmarshaler = &digest{}
+)
+
+const (
+	magic     = "stb\x01"
+	stateSize = len(magic) + 1 + 3*BlockSize + 1 + BlockSize
+)
+
+type digest struct {
+	size  int
+	h     [BlockSize]byte
+	n     [BlockSize]byte
+	sigma [BlockSize]byte
+	buf   []byte
+}
+
+// Hash 256 bit.
+func New256() hash.Hash {
+	return newDigest(Size256)
+}
+
+// Hash 512 bit.
+func New512() hash.Hash {
+	return newDigest(Size512)
+}
+
+// Hash of the data, size is Size256 or Size512.
+func Sum(size int, data []byte) []byte {
+	d := newDigest(size)
+	_, _ = d.Write(data)
+	return d.Sum(nil)
+}
+
+func newDigest(size int) *digest {
+	d := &digest{size: size}
+	d.Reset()
+	return d
+}
+
+func (d *digest) Reset() {
+	var iv byte
+	if d.size == Size256 {
+		iv = 0x01
+	}
+	for i := range d.h {
+		d.h[i] = iv
+	}
+	d.n = [BlockSize]byte{}
+	d.sigma = [BlockSize]byte{}
+	d.buf = d.buf[:0]
+}
+
+func (d *digest) Size() int {
+	return d.size
+}
+
+func (d *digest) BlockSize() int {
+	return BlockSize
+}
+
+func (d *digest) Write(p []byte) (int, error) {
+	n := len(p)
+	if len(d.buf) > 0 {
+		k := copy(d.buf[len(d.buf):BlockSize], p)
+		d.buf = d.buf[:len(d.buf)+k]
+		p = p[k:]
+		if len(d.buf) < BlockSize {
+			return n, nil
+		}
+		d.block(d.buf, 8*BlockSize)
+		d.buf = d.buf[:0]
+	}
+	for len(p) >= BlockSize {
+		d.block(p[:BlockSize], 8*BlockSize)
+		p = p[BlockSize:]
+	}
+	if d.buf == nil {
+		d.buf = make([]byte, 0, BlockSize)
+	}
+	d.buf = append(d.buf, p...)
+	return n, nil
+}
+
+// Digest is appended to b, the state is not changed.
+func (d *digest) Sum(b []byte) []byte {
+	c := *d
+	c.buf = nil
+
+	var m [BlockSize]byte
+	copy(m[:], d.buf)
+	m[len(d.buf)] = 0x01
+	c.block(m[:], uint64(8*len(d.buf)))
+
+	var zero [BlockSize]byte
+	c.h = g(&zero, &c.h, &c.n)
+	c.h = g(&zero, &c.h, &c.sigma)
+
+	return append(b, c.h[BlockSize-d.size:]...)
+}
+
+// State of the hash: magic || size || h || N || Sigma || len(buf) || buf.
+func (d *digest) MarshalBinary() ([]byte, error) {
+	state := make([]byte, 0, stateSize)
+	state = append(state, magic...)
+	state = append(state, byte(d.size))
+	state = append(state, d.h[:]...)
+	state = append(state, d.n[:]...)
+	state = append(state, d.sigma[:]...)
+	state = append(state, byte(len(d.buf)))
+	state = append(state, d.buf...)
+	return append(state, make([]byte, stateSize-len(state))...), nil
+}
+
+func (d *digest) UnmarshalBinary(state []byte) error {
+	if len(state) != stateSize || string(state[:len(magic)]) != magic {
+		return errors.New("streebog: invalid hash state")
+	}
+	state = state[len(magic):]
+	if int(state[0]) != d.size {
+		return errors.New("streebog: hash state of other size")
+	}
+	state = state[1:]
+	state = state[copy(d.h[:], state):]
+	state = state[copy(d.n[:], state):]
+	state = state[copy(d.sigma[:], state):]
+	n := int(state[0])
+	if n >= BlockSize {
+		return errors.New("streebog: invalid hash state")
+	}
+	d.buf = make([]byte, n, BlockSize)
+	copy(d.buf, state[1:])
+	return nil
+}
+
+// Compression of the block m of bits bits.
+func (d *digest) block(m []byte, bits uint64) {
+	var block [BlockSize]byte
+	copy(block[:], m)
+
+	d.h = g(&d.n, &d.h, &block)
+	add512(&d.n, bits)
+	addBlock(&d.sigma, &block)
+}
+
+// g_N(h, m) = E(LPS(h ^ N), m) ^ h ^ m.
+func g(n, h, m *[BlockSize]byte) [BlockSize]byte {
+	k := lps(xor(h, n))
+	state := *m
+	for i := 0; i < 12; i++ {
+		state = lps(xor(&state, &k))
+		k = lps(xor(&k, &c[i]))
+	}
+	state = xor(&state, &k)
+	state = xor(&state, h)
+	return xor(&state, m)
+}
+
+// Transformations S, P and L by the table of the composition.
+func lps(in [BlockSize]byte) [BlockSize]byte {
+	var out [BlockSize]byte
+	for i := 0; i < 8; i++ {
+		var r uint64
+		for j := 0; j < 8; j++ {
+			r ^= spl[j][in[8*j+i]]
+		}
+		binary.LittleEndian.PutUint64(out[8*i:], r)
+	}
+	return out
+}
+
+func xor(x, y *[BlockSize]byte) [BlockSize]byte {
+	var out [BlockSize]byte
+	for i := range out {
+		out[i] = x[i] ^ y[i]
+	}
+	return out
+}
+
+// Sum of the little-endian numbers mod 2^512.
+func addBlock(x, y *[BlockSize]byte) {
+	var carry uint16
+	for i := range x {
+		carry += uint16(x[i]) + uint16(y[i])
+		x[i] = byte(carry)
+		carry >>= 8
+	}
+}
+
+func add512(x *[BlockSize]byte, v uint64) {
+	var y [BlockSize]byte
+	binary.LittleEndian.PutUint64(y[:], v)
+	addBlock(x, &y)
+}
+
+/*
+ * CONSTANTS
+ */
+
+var pi = [256]byte{
+	252, 238, 221, 17, 207, 110, 49, 22, 251, 196, 250, 218, 35, 197, 4, 77,
+	233, 119, 240, 219, 147, 46, 153, 186, 23, 54, 241, 187, 20, 205, 95, 193,
+	249, 24, 101, 90, 226, 92, 239, 33, 129, 28, 60, 66, 139, 1, 142, 79,
+	5, 132, 2, 174, 227, 106, 143, 160, 6, 11, 237, 152, 127, 212, 211, 31,
+	235, 52, 44, 81, 234, 200, 72, 171, 242, 42, 104, 162, 253, 58, 206, 204,
+	181, 112, 14, 86, 8, 12, 118, 18, 191, 114, 19, 71, 156, 183, 93, 135,
+	21, 161, 150, 41, 16, 123, 154, 199, 243, 145, 120, 111, 157, 158, 178, 177,
+	50, 117, 25, 61, 255, 53, 138, 126, 109, 84, 198, 128, 195, 189, 13, 87,
+	223, 245, 36, 169, 62, 168, 67, 201, 215, 121, 214, 246, 124, 34, 185, 3,
+	224, 15, 236, 222, 122, 148, 176, 188, 220, 232, 40, 80, 78, 51, 10, 74,
+	167, 151, 96, 115, 30, 0, 98, 68, 26, 184, 56, 130, 100, 159, 38, 65,
+	173, 69, 70, 146, 39, 94, 85, 47, 140, 163, 165, 125, 105, 213, 149, 59,
+	7, 88, 179, 64, 134, 172, 29, 247, 48, 55, 107, 228, 136, 217, 231, 137,
+	225, 27, 131, 73, 76, 63, 248, 254, 141, 83, 170, 144, 202, 216, 133, 97,
+	32, 113, 103, 164, 45, 43, 9, 91, 203, 155, 37, 208, 190, 229, 108, 82,
+	89, 166, 116, 210, 230, 244, 180, 192, 209, 102, 175, 194, 57, 75, 99, 182,
+}
+
+// Rows of the matrix A of the transformation L: in every group of eight
+// the next row is the previous one multiplied by x^-1 in GF(2^8) bytewise.
+var a = func() (m [64]uint64) {
+	for i, v := range []uint64{
+		0x8e20faa72ba0b470, 0xa011d380818e8f40, 0x90dab52a387ae76f, 0x9d4df05d5f661451,
+		0x86275df09ce8aaa8, 0x456c34887a3805b9, 0xe4fa2054a80b329c, 0x70a6a56e2440598e,
+	} {
+		for j := 0; j < 8; j++ {
+			m[8*i+j] = v
+			var next uint64
+			for k := 0; k < 64; k += 8 {
+				b := byte(v >> uint(k))
+				nb := b >> 1
+				if b&1 != 0 {
+					nb ^= 0x8e
+				}
+				next |= uint64(nb) << uint(k)
+			}
+			v = next
+		}
+	}
+	return m
+}()
+
+// Composition of S, P and L: byte j of the word is the byte x
+// substituted by pi, the bits of it select the rows of A.
+var spl = func() (t [8][256]uint64) {
+	for j := 0; j < 8; j++ {
+		for x := 0; x < 256; x++ {
+			v := pi[x]
+			for b := 0; b < 8; b++ {
+				if v&(1<<uint(b)) != 0 {
+					t[j][x] ^= a[63-8*j-b]
+				}
+			}
+		}
+	}
+	return t
+}()
+
+// Iteration constants C_1..C_12 as the numbers of the standard.
+var c = func() (cs [12][BlockSize]byte) {
+	for i, v := range []string{
+		"b1085bda1ecadae9ebcb2f81c0657c1f2f6a76432e45d016714eb88d7585c4fc" +
+			"4b7ce09192676901a2422a08a460d31505767436cc744d23dd806559f2a64507",
+		"6fa3b58aa99d2f1a4fe39d460f70b5d7f3feea720a232b9861d55e0f16b50131" +
+			"9ab5176b12d699585cb561c2db0aa7ca55dda21bd7cbcd56e679047021b19bb7",
+		"f574dcac2bce2fc70a39fc286a3d843506f15e5f529c1f8bf2ea7514b1297b7b" +
+			"d3e20fe490359eb1c1c93a376062db09c2b6f443867adb31991e96f50aba0ab2",
+		"ef1fdfb3e81566d2f948e1a05d71e4dd488e857e335c3c7d9d721cad685e353f" +
+			"a9d72c82ed03d675d8b71333935203be3453eaa193e837f1220cbebc84e3d12e",
+		"4bea6bacad4747999a3f410c6ca923637f151c1f1686104a359e35d7800fffbd" +
+			"bfcd1747253af5a3dfff00b723271a167a56a27ea9ea63f5601758fd7c6cfe57",
+		"ae4faeae1d3ad3d96fa4c33b7a3039c02d66c4f95142a46c187f9ab49af08ec6" +
+			"cffaa6b71c9ab7b40af21f66c2bec6b6bf71c57236904f35fa68407a46647d6e",
+		"f4c70e16eeaac5ec51ac86febf240954399ec6c7e6bf87c9d3473e33197a93c9" +
+			"0992abc52d822c3706476983284a05043517454ca23c4af38886564d3a14d493",
+		"9b1f5b424d93c9a703e7aa020c6e41414eb7f8719c36de1e89b4443b4ddbc49a" +
+			"f4892bcb929b069069d18d2bd1a5c42f36acc2355951a8d9a47f0dd4bf02e71e",
+		"378f5a541631229b944c9ad8ec165fde3a7d3a1b258942243cd955b7e00d0984" +
+			"800a440bdbb2ceb17b2b8a9aa6079c540e38dc92cb1f2a607261445183235adb",
+		"abbedea680056f52382ae548b2e4f3f38941e71cff8a78db1fffe18a1b336103" +
+			"9fe76702af69334b7a1e6c303b7652f43698fad1153bb6c374b4c7fb98459ced",
+		"7bcd9ed0efc889fb3002c6cd635afe94d8fa6bbbebab07612001802114846679" +
+			"8a1d71efea48b9caefbacd1d7d476e98dea2594ac06fd85d6bcaa4cd81f32d1b",
+		"378ee767f11631bad21380b00449b17acda43c32bcdf1d77f82012d430219f9b" +
+			"5d80ef9d1891cc86e71da4aa88e12852faf417d5d9b21b9948bc924af11bd720",
+	} {
+		b, err := hex.DecodeString(v)
+		if err != nil || len(b) != BlockSize {
+			panic("streebog: bad constant")
+		}
+		for j := range b {
+			cs[i][BlockSize-1-j] = b[j]
+		}
+	}
+	return cs
+}()
@@ -0,0 +1,124 @@
+// go test -v -bench=. -benchtime=100x
+package streebog
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/hex"
+	"testing"
+)
+
+const (
+	// Hashes of "aaabbb" by CryptoPro CSP.
+	HASH_RESULT_256 = "2e3cbeb240b4b8d1e2dc8610faff9e5bee23f95bb04c18d999034487dbecb490"
+	HASH_RESULT_512 = "9d76bd134189782acae0756763c7b1c89747c264a7d0ca3c47f5402d002e02ce6fe743159e7472eaab7c5aae5bbee31316ed5acc5051a69fe6bedf50a7bf273e"
+
+	// Example 1 of the standard (M1, RFC 6986).
+	M1_RESULT_256 = "9d151eefd8590b89daa6ba6cb74af9275dd051026bb149a452fd84e5e57b5500"
+	M1_RESULT_512 = "1b54d01a4af5b9d5cc3d86d68d285462b19abc2475222f35c085122be4ba1ffa00ad30f8767b3a82384c6574f024c311e2a481332b08ef7f41797891c1646f48"
+)
+
+var (
+	TEST_MESSAGE = []byte("aaabbb")
+	M1           = []byte("012345678901234567890123456789012345678901234567890123456789012")
+)
+
+func TestConstants(t *testing.T) {
+	var seen [256]bool
+	for _, v := range pi {
+		if seen[v] {
+			t.Errorf("test failed: pi is not a permutation")
+			return
+		}
+		seen[v] = true
+	}
+	if a[63] != 0x641c314b2b8ee083 || a[1] != 0x47107ddd9b505a38 {
+		t.Errorf("test failed: matrix A")
+		return
+	}
+}
+
+func TestSum(t *testing.T) {
+	for _, v := range []struct {
+		size int
+		data []byte
+		hash string
+	}{
+		{Size256, TEST_MESSAGE, HASH_RESULT_256},
+		{Size512, TEST_MESSAGE, HASH_RESULT_512},
+		{Size256, M1, M1_RESULT_256},
+		{Size512, M1, M1_RESULT_512},
+	} {
+		if res := hex.EncodeToString(Sum(v.size, v.data)); res != v.hash {
+			t.Errorf("test failed: hash %d of %q: %s", 8*v.size, v.data, res)
+			return
+		}
+	}
+}
+
+func TestWrite(t *testing.T) {
+	data := bytes.Repeat([]byte("0123456789"), 50)
+	want := Sum(Size512, data)
+
+	for _, step := range []int{1, 7, 63, 64, 65, 200} {
+		d := New512()
+		for i := 0; i < len(data); i += step {
+			end := i + step
+			if end > len(data) {
+				end = len(data)
+			}
+			d.Write(data[i:end])
+		}
+		if !bytes.Equal(d.Sum(nil), want) {
+			t.Errorf("test failed: write by %d bytes", step)
+			return
+		}
+
+		d.Reset()
+		d.Write(data)
+		if !bytes.Equal(d.Sum(nil), want) {
+			t.Errorf("test failed: reset")
+			return
+		}
+	}
+}
+
+func BenchmarkSum256(b *testing.B) {
+	data := make([]byte, 1024)
+	b.SetBytes(int64(len(data)))
+	for i := 0; i < b.N; i++ {
+		_ = Sum(Size256, data)
+	}
+}
+
+func TestMarshalBinary(t *testing.T) {
+	data := bytes.Repeat([]byte("0123456789"), 20)
+	want := Sum(Size256, data)
+
+	for _, split := range []int{0, 1, 63, 64, 65, 199} {
+		d := New256()
+		d.Write(data[:split])
+
+		state, err := d.(encoding.BinaryMarshaler).MarshalBinary()
+		if err != nil {
+			t.Errorf("test failed: marshal state")
+			return
+		}
+
+		cp := New256()
+		if err := cp.(encoding.BinaryUnmarshaler).UnmarshalBinary(state); err != nil {
+			t.Errorf("test failed: unmarshal state: %v", err)
+			return
+		}
+		cp.Write(data[split:])
+		if !bytes.Equal(cp.Sum(nil), want) {
+			t.Errorf("test failed: restored state at %d", split)
+			return
+		}
+	}
+
+	if err := New512().(encoding.BinaryUnmarshaler).UnmarshalBinary(make([]byte, 10)); err == nil {
+		t.Errorf("test failed: unmarshal broken state")
+		return
+	}
+}
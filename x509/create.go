@@ -0,0 +1,255 @@
+package x509
+
+import (
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"fmt"
+	"math/big"
+	"time"
+
+	gkeys "github.com/towleeee/go-cryptopro/gost_r_34_10_2012"
+	ghash "github.com/towleeee/go-cryptopro/gost_r_34_11_2012"
+	grand "github.com/towleeee/go-cryptopro/gost_r_iso_28640_2012"
+	"github.com/towleeee/go-cryptopro/internal/oids"
+)
+
+const (
+	serialSize = 16
+	keyIdSize  = 20
+)
+
+// Creation of DER X.509 v3 certificate of the public key
+// signed by the private key of the issuer (parent).
+// The certificate is self-signed if the parent is nil or the template.
+//
+// Fields of the template are SerialNumber (random if nil), Subject,
+// NotBefore (now if zero), NotAfter, KeyUsage, ExtKeyUsage,
+// BasicConstraintsValid, IsCA, MaxPathLen, MaxPathLenZero,
+// SubjectKeyId (hash of the key if nil), AuthorityKeyId (key id
+// of the parent if nil), DNSNames, EmailAddresses,
+// CRLDistributionPoints, PolicyIdentifiers, SubjectSignTool,
+// IssuerSignTool and ExtraExtensions.
+func CreateCertificate(template, parent *Certificate, pub gkeys.PubKey, priv gkeys.PrivKey) ([]byte, error) {
+	if parent == nil {
+		parent = template
+	}
+	selfSigned := parent == template
+
+	signer, err := gkeys.NewSigner(priv)
+	if err != nil {
+		return nil, err
+	}
+	issuerKey := signer.Public().(gkeys.PubKey)
+
+	switch {
+	case selfSigned && !pub.Equals(issuerKey):
+		return nil, fmt.Errorf("error: public key does not match private key")
+	case !selfSigned && (parent.PublicKey == nil || !parent.PublicKey.Equals(issuerKey)):
+		return nil, fmt.Errorf("error: parent public key does not match private key")
+	}
+
+	spki, err := marshalPublicKey(pub)
+	if err != nil {
+		return nil, err
+	}
+
+	serial := template.SerialNumber
+	if serial == nil {
+		serial, err = randomSerial()
+		if err != nil {
+			return nil, err
+		}
+	}
+	if serial.Sign() <= 0 {
+		return nil, fmt.Errorf("error: serial number must be positive")
+	}
+
+	notBefore := template.NotBefore
+	if notBefore.IsZero() {
+		notBefore = time.Now()
+	}
+	if !template.NotAfter.After(notBefore) {
+		return nil, fmt.Errorf("error: validity period")
+	}
+
+	subject, err := asn1.Marshal(template.Subject.ToRDNSequence())
+	if err != nil {
+		return nil, err
+	}
+	issuer := subject
+	if !selfSigned {
+		issuer = parent.RawSubject
+		if len(issuer) == 0 {
+			issuer, err = asn1.Marshal(parent.Subject.ToRDNSequence())
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	subjectKeyId := template.SubjectKeyId
+	if len(subjectKeyId) == 0 {
+		subjectKeyId, err = keyId(spki)
+		if err != nil {
+			return nil, err
+		}
+	}
+	authorityKeyId := template.AuthorityKeyId
+	if len(authorityKeyId) == 0 {
+		authorityKeyId = parent.SubjectKeyId
+		if selfSigned {
+			authorityKeyId = subjectKeyId
+		}
+	}
+
+	exts, err := template.buildExtensions(subjectKeyId, authorityKeyId)
+	if err != nil {
+		return nil, err
+	}
+
+	algo := signatureAlgorithm(issuerKey)
+	tbs, err := asn1.Marshal(tbsCertificate{
+		Version:            2,
+		SerialNumber:       serial,
+		SignatureAlgorithm: pkix.AlgorithmIdentifier{Algorithm: algo},
+		Issuer:             asn1.RawValue{FullBytes: issuer},
+		Validity: validity{
+			NotBefore: notBefore.UTC().Truncate(time.Second),
+			NotAfter:  template.NotAfter.UTC().Truncate(time.Second),
+		},
+		Subject:    asn1.RawValue{FullBytes: subject},
+		PublicKey:  spki,
+		Extensions: exts,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sign, err := signData(signer, algo, tbs)
+	if err != nil {
+		return nil, err
+	}
+
+	return asn1.Marshal(certificate{
+		TBSCertificate:     tbsCertificate{Raw: tbs},
+		SignatureAlgorithm: pkix.AlgorithmIdentifier{Algorithm: algo},
+		SignatureValue:     asn1.BitString{Bytes: sign, BitLength: 8 * len(sign)},
+	})
+}
+
+// Positive random serial number.
+func randomSerial() (*big.Int, error) {
+	serial, err := grand.RandE(serialSize)
+	if err != nil {
+		return nil, err
+	}
+	serial[0] &= 0x7F
+	serial[0] |= 0x01
+	return new(big.Int).SetBytes(serial), nil
+}
+
+// Key identifier: GOST R 34.11-2012 256 hash of the public key
+// truncated to 160 bits (RFC 5280, 4.2.1.2).
+func keyId(spki publicKeyInfo) ([]byte, error) {
+	hash, err := ghash.SumE(ghash.H256, spki.PublicKey.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	return hash[:keyIdSize], nil
+}
+
+func (c *Certificate) buildExtensions(subjectId, authorityId []byte) ([]pkix.Extension, error) {
+	exts := &extensions{extra: c.ExtraExtensions}
+
+	if c.BasicConstraintsValid {
+		maxPathLen := c.MaxPathLen
+		if maxPathLen == 0 && !c.MaxPathLenZero {
+			maxPathLen = -1
+		}
+		value, err := asn1.Marshal(basicConstraints{
+			IsCA:       c.IsCA,
+			MaxPathLen: maxPathLen,
+		})
+		err = exts.add(pkix.Extension{Id: oids.ExtBasicConstraints, Critical: true, Value: value}, err)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	value, err := asn1.Marshal(subjectId)
+	err = exts.add(pkix.Extension{Id: oids.ExtSubjectKeyId, Value: value}, err)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(authorityId) != 0 {
+		value, err := asn1.Marshal(authorityKeyId{Id: authorityId})
+		err = exts.add(pkix.Extension{Id: oids.ExtAuthorityKeyId, Value: value}, err)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = exts.addUsage(c.KeyUsage, c.ExtKeyUsage, c.DNSNames, c.EmailAddresses)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(c.CRLDistributionPoints) != 0 {
+		var points []distributionPoint
+		for _, v := range c.CRLDistributionPoints {
+			points = append(points, distributionPoint{
+				DistributionPoint: distributionPointName{
+					FullName: []asn1.RawValue{{
+						Class: asn1.ClassContextSpecific,
+						Tag:   nameTypeURI,
+						Bytes: []byte(v),
+					}},
+				},
+			})
+		}
+		value, err := asn1.Marshal(points)
+		err = exts.add(pkix.Extension{Id: oids.ExtCRLDistributionPoints, Value: value}, err)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(c.PolicyIdentifiers) != 0 {
+		var policies []policyInformation
+		for _, v := range c.PolicyIdentifiers {
+			policies = append(policies, policyInformation{Policy: v})
+		}
+		value, err := asn1.Marshal(policies)
+		err = exts.add(pkix.Extension{Id: oids.ExtCertificatePolicies, Value: value}, err)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if c.SubjectSignTool != "" {
+		value, err := asn1.MarshalWithParams(c.SubjectSignTool, "utf8")
+		err = exts.add(pkix.Extension{Id: oids.ExtSubjectSignTool, Value: value}, err)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(c.IssuerSignTool) != 0 {
+		if len(c.IssuerSignTool) != 4 {
+			return nil, fmt.Errorf("error: issuer sign tool must have 4 values")
+		}
+		value, err := asn1.Marshal(issuerSignTool{
+			SignTool:     c.IssuerSignTool[0],
+			CATool:       c.IssuerSignTool[1],
+			SignToolCert: c.IssuerSignTool[2],
+			CAToolCert:   c.IssuerSignTool[3],
+		})
+		err = exts.add(pkix.Extension{Id: oids.ExtIssuerSignTool, Value: value}, err)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return exts.build(), nil
+}
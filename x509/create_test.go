@@ -0,0 +1,206 @@
+// go test -v -bench=. -benchtime=100x
+package x509
+
+import (
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"math/big"
+	"testing"
+	"time"
+
+	gkeys "github.com/towleeee/go-cryptopro/gost_r_34_10_2012"
+)
+
+func testRoot() *Certificate {
+	return &Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject: Name{
+			Name: pkix.Name{CommonName: "Test Root CA", Country: []string{"RU"}},
+			OGRN: "1027700000000",
+		},
+		NotAfter:              time.Now().AddDate(10, 0, 0),
+		KeyUsage:              KeyUsageCertSign | KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		MaxPathLen:            1,
+	}
+}
+
+func testLeaf() *Certificate {
+	return &Certificate{
+		Subject: Name{
+			Name:  pkix.Name{CommonName: "Test Leaf"},
+			INN:   "771234567890",
+			SNILS: "12345678901",
+		},
+		NotAfter:              time.Now().AddDate(1, 0, 0),
+		KeyUsage:              KeyUsageDigitalSignature | KeyUsageContentCommitment,
+		ExtKeyUsage:           []asn1.ObjectIdentifier{ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		EmailAddresses:        []string{"test@example.com"},
+		CRLDistributionPoints: []string{"http://example.com/root.crl"},
+	}
+}
+
+func TestCreateSelfSigned(t *testing.T) {
+	pub := PRIVATE_KEY.PubKey(gkeys.AT_SIGNATURE)
+
+	der, err := CreateCertificate(testRoot(), nil, pub, PRIVATE_KEY)
+	if err != nil {
+		t.Errorf("test failed: create certificate")
+		return
+	}
+
+	root, err := ParseCertificate(der)
+	if err != nil {
+		t.Errorf("test failed: parse certificate")
+		return
+	}
+
+	err = root.CheckSignature(root.SignatureAlgorithm, root.RawTBSCertificate, root.Signature)
+	if err != nil {
+		t.Errorf("test failed: check signature: %s", err)
+		return
+	}
+
+	if root.Subject.String() != root.Issuer.String() ||
+		root.Subject.OGRN != "1027700000000" ||
+		root.SerialNumber.Int64() != 1 {
+		t.Errorf("test failed: names")
+		return
+	}
+
+	if !root.IsCA || root.MaxPathLen != 1 ||
+		root.KeyUsage != KeyUsageCertSign|KeyUsageCRLSign ||
+		len(root.SubjectKeyId) == 0 ||
+		string(root.SubjectKeyId) != string(root.AuthorityKeyId) {
+		t.Errorf("test failed: extensions")
+		return
+	}
+
+	if !root.PublicKey.Equals(pub) {
+		t.Errorf("test failed: public key")
+		return
+	}
+
+	_, err = CreateCertificate(testRoot(), nil, PRIVATE_KEY_2.PubKey(gkeys.AT_SIGNATURE), PRIVATE_KEY)
+	if err == nil {
+		t.Errorf("test failed: self-signed with foreign key")
+		return
+	}
+}
+
+func TestCreateIssued(t *testing.T) {
+	der, err := CreateCertificate(testRoot(), nil, PRIVATE_KEY.PubKey(gkeys.AT_SIGNATURE), PRIVATE_KEY)
+	if err != nil {
+		t.Errorf("test failed: create root")
+		return
+	}
+
+	root, err := ParseCertificate(der)
+	if err != nil {
+		t.Errorf("test failed: parse root")
+		return
+	}
+
+	der, err = CreateCertificate(testLeaf(), root, PRIVATE_KEY_2.PubKey(gkeys.AT_SIGNATURE), PRIVATE_KEY)
+	if err != nil {
+		t.Errorf("test failed: create leaf")
+		return
+	}
+
+	leaf, err := ParseCertificate(der)
+	if err != nil {
+		t.Errorf("test failed: parse leaf")
+		return
+	}
+
+	err = root.CheckSignature(leaf.SignatureAlgorithm, leaf.RawTBSCertificate, leaf.Signature)
+	if err != nil {
+		t.Errorf("test failed: check signature: %s", err)
+		return
+	}
+
+	if leaf.Issuer.String() != root.Subject.String() ||
+		leaf.Subject.INN != "771234567890" ||
+		leaf.SerialNumber.Sign() <= 0 {
+		t.Errorf("test failed: names")
+		return
+	}
+
+	if leaf.IsCA || !leaf.BasicConstraintsValid ||
+		string(leaf.AuthorityKeyId) != string(root.SubjectKeyId) ||
+		len(leaf.EmailAddresses) != 1 ||
+		len(leaf.CRLDistributionPoints) != 1 {
+		t.Errorf("test failed: extensions")
+		return
+	}
+
+	// The issuer key must match the parent certificate.
+	_, err = CreateCertificate(testLeaf(), root, PRIVATE_KEY_2.PubKey(gkeys.AT_SIGNATURE), PRIVATE_KEY_2)
+	if err == nil {
+		t.Errorf("test failed: issued with foreign key")
+		return
+	}
+}
+
+// The parsed CA certificate without the digest set
+// (tc26 256 and 512 bit keys) issues the certificates.
+func TestCreateIssuedParamSets(t *testing.T) {
+	for _, v := range []struct {
+		prov     gkeys.ProvType
+		paramSet asn1.ObjectIdentifier
+	}{
+		{gkeys.K256, gkeys.ParamSetTc26256B},
+		{gkeys.K512, gkeys.ParamSetTc26512A},
+	} {
+		cfg := gkeys.NewConfig(v.prov, TEST_SUBJECT+"_ca_"+v.paramSet.String(), TEST_PASSWORD).
+			WithParamSet(v.paramSet)
+		if err := gkeys.GenPrivKey(cfg); err != nil {
+			println("test warning: key already exist?")
+		}
+		priv, err := gkeys.NewPrivKey(cfg)
+		if err != nil {
+			t.Errorf("test failed: %s: new priv key: %v", v.paramSet, err)
+			return
+		}
+
+		der, err := CreateCertificate(testRoot(), nil, priv.PubKey(gkeys.AT_SIGNATURE), priv)
+		if err != nil {
+			t.Errorf("test failed: %s: create root: %v", v.paramSet, err)
+			return
+		}
+		root, err := ParseCertificate(der)
+		if err != nil {
+			t.Errorf("test failed: %s: parse root: %v", v.paramSet, err)
+			return
+		}
+
+		der, err = CreateCertificate(testLeaf(), root, PRIVATE_KEY_2.PubKey(gkeys.AT_SIGNATURE), priv)
+		if err != nil {
+			t.Errorf("test failed: %s: create leaf: %v", v.paramSet, err)
+			return
+		}
+		leaf, err := ParseCertificate(der)
+		if err != nil {
+			t.Errorf("test failed: %s: parse leaf: %v", v.paramSet, err)
+			return
+		}
+		err = root.CheckSignature(leaf.SignatureAlgorithm, leaf.RawTBSCertificate, leaf.Signature)
+		if err != nil {
+			t.Errorf("test failed: %s: check signature: %s", v.paramSet, err)
+			return
+		}
+	}
+}
+
+func BenchmarkCreateCertificate(b *testing.B) {
+	pub := PRIVATE_KEY.PubKey(gkeys.AT_SIGNATURE)
+	for i := 0; i < b.N; i++ {
+		_, err := CreateCertificate(testRoot(), nil, pub, PRIVATE_KEY)
+		if err != nil {
+			b.Errorf("benchmark failed: create certificate")
+			break
+		}
+	}
+}
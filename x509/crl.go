@@ -0,0 +1,296 @@
+package x509
+
+import (
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"os"
+	"time"
+
+	gkeys "github.com/towleeee/go-cryptopro/gost_r_34_10_2012"
+	"github.com/towleeee/go-cryptopro/internal/oids"
+)
+
+const (
+	PemRevocationList = "X509 CRL"
+)
+
+/*
+ * ASN.1
+ */
+
+type certificateList struct {
+	Raw                asn1.RawContent
+	TBSCertList        tbsCertList
+	SignatureAlgorithm pkix.AlgorithmIdentifier
+	SignatureValue     asn1.BitString
+}
+
+type tbsCertList struct {
+	Raw                 asn1.RawContent
+	Version             int `asn1:"optional,default:0"`
+	Signature           pkix.AlgorithmIdentifier
+	Issuer              asn1.RawValue
+	ThisUpdate          time.Time
+	NextUpdate          time.Time            `asn1:"optional"`
+	RevokedCertificates []revokedCertificate `asn1:"optional"`
+	Extensions          []pkix.Extension     `asn1:"tag:0,optional,explicit"`
+}
+
+type revokedCertificate struct {
+	SerialNumber   *big.Int
+	RevocationTime time.Time
+	Extensions     []pkix.Extension `asn1:"optional"`
+}
+
+/*
+ * REVOCATION LIST
+ */
+
+// Revoked certificate of CRL.
+type RevokedCertificate struct {
+	SerialNumber   *big.Int
+	RevocationTime time.Time
+	// Value of the reason code extension (RFC 5280, 5.3.1),
+	// zero (unspecified) if absent.
+	ReasonCode int
+}
+
+// Parsed X.509 v2 CRL (RFC 5280) signed with GOST R 34.10-2012.
+// Fields of the template for CreateRevocationList are ThisUpdate
+// (now if zero), NextUpdate, Number and RevokedCertificates.
+type RevocationList struct {
+	Raw                  []byte // DER of the CRL
+	RawTBSRevocationList []byte // DER of the signed part
+	RawIssuer            []byte
+
+	Issuer     Name
+	ThisUpdate time.Time
+	NextUpdate time.Time
+
+	SignatureAlgorithm asn1.ObjectIdentifier
+	// Big-endian s || r (RFC 4491).
+	Signature []byte
+
+	RevokedCertificates []RevokedCertificate
+
+	Number         *big.Int
+	AuthorityKeyId []byte
+	Extensions     []pkix.Extension
+	// Critical extensions of the CRL and of its entries which are
+	// not parsed (e.g. issuingDistributionPoint of a partial CRL),
+	// the CRL is not used by Verify.
+	UnhandledCriticalExtensions []asn1.ObjectIdentifier
+}
+
+// Parsing of DER CRL.
+func ParseRevocationList(der []byte) (*RevocationList, error) {
+	var crl certificateList
+	rest, err := asn1.Unmarshal(der, &crl)
+	if err != nil {
+		return nil, fmt.Errorf("error: parse revocation list: %w", err)
+	}
+	if len(rest) != 0 {
+		return nil, fmt.Errorf("error: trailing data after revocation list")
+	}
+
+	tbs := &crl.TBSCertList
+	out := &RevocationList{
+		Raw:                  crl.Raw,
+		RawTBSRevocationList: tbs.Raw,
+		RawIssuer:            tbs.Issuer.FullBytes,
+		ThisUpdate:           tbs.ThisUpdate,
+		NextUpdate:           tbs.NextUpdate,
+		SignatureAlgorithm:   crl.SignatureAlgorithm.Algorithm,
+		Signature:            crl.SignatureValue.RightAlign(),
+		Extensions:           tbs.Extensions,
+	}
+
+	out.Issuer, err = parseName(tbs.Issuer.FullBytes)
+	if err != nil {
+		return nil, fmt.Errorf("error: parse issuer: %w", err)
+	}
+
+	for _, v := range tbs.RevokedCertificates {
+		revoked := RevokedCertificate{
+			SerialNumber:   v.SerialNumber,
+			RevocationTime: v.RevocationTime,
+		}
+		for _, ext := range v.Extensions {
+			if !ext.Id.Equal(oids.ExtCRLReason) {
+				if ext.Critical {
+					out.UnhandledCriticalExtensions = append(out.UnhandledCriticalExtensions, ext.Id)
+				}
+				continue
+			}
+			var reason asn1.Enumerated
+			_, err := asn1.Unmarshal(ext.Value, &reason)
+			if err != nil {
+				return nil, fmt.Errorf("error: parse reason code: %w", err)
+			}
+			revoked.ReasonCode = int(reason)
+		}
+		out.RevokedCertificates = append(out.RevokedCertificates, revoked)
+	}
+
+	for _, ext := range tbs.Extensions {
+		switch {
+		case ext.Id.Equal(oids.ExtCRLNumber):
+			_, err = asn1.Unmarshal(ext.Value, &out.Number)
+		case ext.Id.Equal(oids.ExtAuthorityKeyId):
+			var aki authorityKeyId
+			_, err = asn1.Unmarshal(ext.Value, &aki)
+			out.AuthorityKeyId = aki.Id
+		case ext.Critical:
+			out.UnhandledCriticalExtensions = append(out.UnhandledCriticalExtensions, ext.Id)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error: parse extension %s: %w", ext.Id, err)
+		}
+	}
+
+	return out, nil
+}
+
+// Parsing of PEM block "X509 CRL".
+func ParseRevocationListPEM(data []byte) (*RevocationList, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("error: pem block not found")
+	}
+	if block.Type != PemRevocationList {
+		return nil, fmt.Errorf("error: pem type %q", block.Type)
+	}
+	return ParseRevocationList(block.Bytes)
+}
+
+// Loading of CRL file in DER or PEM form.
+func LoadRevocationList(path string) (*RevocationList, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if block, _ := pem.Decode(data); block != nil {
+		return ParseRevocationListPEM(data)
+	}
+	return ParseRevocationList(data)
+}
+
+// PEM encoding of the CRL.
+func (crl *RevocationList) PEM() []byte {
+	return pem.EncodeToMemory(&pem.Block{
+		Type:  PemRevocationList,
+		Bytes: crl.Raw,
+	})
+}
+
+// Revocation of the certificate by serial number at the time.
+func (crl *RevocationList) revoked(serial *big.Int, at time.Time) (RevokedCertificate, bool) {
+	for _, v := range crl.RevokedCertificates {
+		if v.SerialNumber.Cmp(serial) == 0 && !v.RevocationTime.After(at) {
+			return v, true
+		}
+	}
+	return RevokedCertificate{}, false
+}
+
+// The CRL is issued before the time and the next CRL is not due
+// (NextUpdate is optional, RFC 5280, 5.1.2.5).
+func (crl *RevocationList) validAt(at time.Time) bool {
+	if crl.ThisUpdate.After(at) {
+		return false
+	}
+	return crl.NextUpdate.IsZero() || at.Before(crl.NextUpdate)
+}
+
+// Checking of the CRL signature by the issuer certificate.
+func (c *Certificate) CheckCRLSignature(crl *RevocationList) error {
+	if string(crl.RawIssuer) != string(c.RawSubject) {
+		return fmt.Errorf("error: revocation list issuer mismatch")
+	}
+	if c.KeyUsage != 0 && c.KeyUsage&KeyUsageCRLSign == 0 {
+		return fmt.Errorf("error: issuer key usage does not permit crl signing")
+	}
+	return c.CheckSignature(crl.SignatureAlgorithm, crl.RawTBSRevocationList, crl.Signature)
+}
+
+// Creation of DER CRL signed by the private key of the issuer.
+func CreateRevocationList(template *RevocationList, issuer *Certificate, priv gkeys.PrivKey) ([]byte, error) {
+	signer, err := gkeys.NewSigner(priv)
+	if err != nil {
+		return nil, err
+	}
+	issuerKey := signer.Public().(gkeys.PubKey)
+
+	if issuer.PublicKey == nil || !issuer.PublicKey.Equals(issuerKey) {
+		return nil, fmt.Errorf("error: issuer public key does not match private key")
+	}
+	if issuer.KeyUsage != 0 && issuer.KeyUsage&KeyUsageCRLSign == 0 {
+		return nil, fmt.Errorf("error: issuer key usage does not permit crl signing")
+	}
+
+	thisUpdate := template.ThisUpdate
+	if thisUpdate.IsZero() {
+		thisUpdate = time.Now()
+	}
+
+	tbs := tbsCertList{
+		Version:    1,
+		Issuer:     asn1.RawValue{FullBytes: issuer.RawSubject},
+		ThisUpdate: thisUpdate.UTC().Truncate(time.Second),
+	}
+	if !template.NextUpdate.IsZero() {
+		tbs.NextUpdate = template.NextUpdate.UTC().Truncate(time.Second)
+	}
+
+	for _, v := range template.RevokedCertificates {
+		revoked := revokedCertificate{
+			SerialNumber:   v.SerialNumber,
+			RevocationTime: v.RevocationTime.UTC().Truncate(time.Second),
+		}
+		if v.ReasonCode != 0 {
+			value, err := asn1.Marshal(asn1.Enumerated(v.ReasonCode))
+			if err != nil {
+				return nil, err
+			}
+			revoked.Extensions = []pkix.Extension{{Id: oids.ExtCRLReason, Value: value}}
+		}
+		tbs.RevokedCertificates = append(tbs.RevokedCertificates, revoked)
+	}
+
+	if len(issuer.SubjectKeyId) != 0 {
+		value, err := asn1.Marshal(authorityKeyId{Id: issuer.SubjectKeyId})
+		if err != nil {
+			return nil, err
+		}
+		tbs.Extensions = append(tbs.Extensions, pkix.Extension{Id: oids.ExtAuthorityKeyId, Value: value})
+	}
+	if template.Number != nil {
+		value, err := asn1.Marshal(template.Number)
+		if err != nil {
+			return nil, err
+		}
+		tbs.Extensions = append(tbs.Extensions, pkix.Extension{Id: oids.ExtCRLNumber, Value: value})
+	}
+
+	algo := signatureAlgorithm(issuerKey)
+	tbs.Signature = pkix.AlgorithmIdentifier{Algorithm: algo}
+
+	tbsBytes, err := asn1.Marshal(tbs)
+	if err != nil {
+		return nil, err
+	}
+
+	sign, err := signData(signer, algo, tbsBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	return asn1.Marshal(certificateList{
+		TBSCertList:        tbsCertList{Raw: tbsBytes},
+		SignatureAlgorithm: pkix.AlgorithmIdentifier{Algorithm: algo},
+		SignatureValue:     asn1.BitString{Bytes: sign, BitLength: 8 * len(sign)},
+	})
+}
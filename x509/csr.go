@@ -0,0 +1,248 @@
+package x509
+
+import (
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"fmt"
+
+	gkeys "github.com/towleeee/go-cryptopro/gost_r_34_10_2012"
+)
+
+const (
+	PemCertificateRequest = "CERTIFICATE REQUEST"
+)
+
+var (
+	oidExtensionRequest = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 14}
+)
+
+/*
+ * ASN.1
+ */
+
+type certificateRequest struct {
+	Raw                asn1.RawContent
+	TBSCSR             tbsCertificateRequest
+	SignatureAlgorithm pkix.AlgorithmIdentifier
+	SignatureValue     asn1.BitString
+}
+
+type tbsCertificateRequest struct {
+	Raw           asn1.RawContent
+	Version       int
+	Subject       asn1.RawValue
+	PublicKey     publicKeyInfo
+	RawAttributes []asn1.RawValue `asn1:"tag:0"`
+}
+
+type requestAttribute struct {
+	Type   asn1.ObjectIdentifier
+	Values []asn1.RawValue `asn1:"set"`
+}
+
+/*
+ * CERTIFICATE REQUEST
+ */
+
+// PKCS#10 certificate request (RFC 2986) with GOST R 34.10-2012 key.
+// Fields of the template for CreateCertificateRequest are
+// Subject, KeyUsage, ExtKeyUsage, DNSNames, EmailAddresses
+// and ExtraExtensions.
+type CertificateRequest struct {
+	Raw                      []byte // DER of the request
+	RawTBSCertificateRequest []byte // DER of the signed part
+	RawSubjectPublicKeyInfo  []byte
+	RawSubject               []byte
+
+	Version int
+	Subject Name
+
+	SignatureAlgorithm asn1.ObjectIdentifier
+	// Big-endian s || r (RFC 4491).
+	Signature []byte
+
+	PublicKeyAlgorithm asn1.ObjectIdentifier
+	PublicKey          gkeys.PubKey
+	ParamSet           asn1.ObjectIdentifier
+	DigestSet          asn1.ObjectIdentifier
+
+	// Requested extensions including the parsed ones.
+	Extensions []pkix.Extension
+	// Extensions added to the request as is.
+	ExtraExtensions []pkix.Extension
+
+	KeyUsage       KeyUsage
+	ExtKeyUsage    []asn1.ObjectIdentifier
+	DNSNames       []string
+	EmailAddresses []string
+}
+
+// Creation of DER certificate request signed by the private key.
+// The key of the request is the key of the container (PrivContainer
+// uses its KeySpec, other keys use AT_SIGNATURE).
+func CreateCertificateRequest(template *CertificateRequest, priv gkeys.PrivKey) ([]byte, error) {
+	signer, err := gkeys.NewSigner(priv)
+	if err != nil {
+		return nil, err
+	}
+	pub := signer.Public().(gkeys.PubKey)
+
+	spki, err := marshalPublicKey(pub)
+	if err != nil {
+		return nil, err
+	}
+
+	subject, err := asn1.Marshal(template.Subject.ToRDNSequence())
+	if err != nil {
+		return nil, err
+	}
+
+	exts := &extensions{extra: template.ExtraExtensions}
+	err = exts.addUsage(
+		template.KeyUsage,
+		template.ExtKeyUsage,
+		template.DNSNames,
+		template.EmailAddresses,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var attrs []asn1.RawValue
+	if list := exts.build(); len(list) != 0 {
+		value, err := asn1.Marshal(list)
+		if err != nil {
+			return nil, err
+		}
+		attr, err := asn1.Marshal(requestAttribute{
+			Type:   oidExtensionRequest,
+			Values: []asn1.RawValue{{FullBytes: value}},
+		})
+		if err != nil {
+			return nil, err
+		}
+		attrs = append(attrs, asn1.RawValue{FullBytes: attr})
+	}
+
+	tbs, err := asn1.Marshal(tbsCertificateRequest{
+		Version:       0,
+		Subject:       asn1.RawValue{FullBytes: subject},
+		PublicKey:     spki,
+		RawAttributes: attrs,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	algo := signatureAlgorithm(pub)
+	sign, err := signData(signer, algo, tbs)
+	if err != nil {
+		return nil, err
+	}
+
+	return asn1.Marshal(certificateRequest{
+		TBSCSR:             tbsCertificateRequest{Raw: tbs},
+		SignatureAlgorithm: pkix.AlgorithmIdentifier{Algorithm: algo},
+		SignatureValue:     asn1.BitString{Bytes: sign, BitLength: 8 * len(sign)},
+	})
+}
+
+// Creation of DER certificate request with the key of the container.
+func CreateCertificateRequestConfig(template *CertificateRequest, cfg *gkeys.Config) ([]byte, error) {
+	priv, err := gkeys.NewPrivKey(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return CreateCertificateRequest(template, priv)
+}
+
+// Parsing of DER certificate request.
+func ParseCertificateRequest(der []byte) (*CertificateRequest, error) {
+	var csr certificateRequest
+	rest, err := asn1.Unmarshal(der, &csr)
+	if err != nil {
+		return nil, fmt.Errorf("error: parse certificate request: %w", err)
+	}
+	if len(rest) != 0 {
+		return nil, fmt.Errorf("error: trailing data after certificate request")
+	}
+
+	tbs := &csr.TBSCSR
+	out := &CertificateRequest{
+		Raw:                      csr.Raw,
+		RawTBSCertificateRequest: tbs.Raw,
+		RawSubjectPublicKeyInfo:  tbs.PublicKey.Raw,
+		RawSubject:               tbs.Subject.FullBytes,
+		Version:                  tbs.Version,
+		SignatureAlgorithm:       csr.SignatureAlgorithm.Algorithm,
+		Signature:                csr.SignatureValue.RightAlign(),
+		PublicKeyAlgorithm:       tbs.PublicKey.Algorithm.Algorithm,
+	}
+
+	out.Subject, err = parseName(tbs.Subject.FullBytes)
+	if err != nil {
+		return nil, fmt.Errorf("error: parse subject: %w", err)
+	}
+
+	pub, params, err := parsePublicKey(&tbs.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	out.PublicKey = pub
+	out.ParamSet = params.ParamSet
+	out.DigestSet = params.DigestSet
+
+	for _, raw := range tbs.RawAttributes {
+		var attr requestAttribute
+		_, err := asn1.Unmarshal(raw.FullBytes, &attr)
+		if err != nil {
+			return nil, fmt.Errorf("error: parse attribute: %w", err)
+		}
+		if !attr.Type.Equal(oidExtensionRequest) || len(attr.Values) != 1 {
+			continue
+		}
+		_, err = asn1.Unmarshal(attr.Values[0].FullBytes, &out.Extensions)
+		if err != nil {
+			return nil, fmt.Errorf("error: parse extension request: %w", err)
+		}
+	}
+
+	// The requested extensions are parsed as in the certificate.
+	cert := &Certificate{Extensions: out.Extensions}
+	err = cert.parseExtensions()
+	if err != nil {
+		return nil, err
+	}
+	out.KeyUsage = cert.KeyUsage
+	out.ExtKeyUsage = cert.ExtKeyUsage
+	out.DNSNames = cert.DNSNames
+	out.EmailAddresses = cert.EmailAddresses
+
+	return out, nil
+}
+
+// Parsing of PEM block "CERTIFICATE REQUEST".
+func ParseCertificateRequestPEM(data []byte) (*CertificateRequest, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("error: pem block not found")
+	}
+	if block.Type != PemCertificateRequest {
+		return nil, fmt.Errorf("error: pem type %q", block.Type)
+	}
+	return ParseCertificateRequest(block.Bytes)
+}
+
+// PEM encoding of the certificate request.
+func (r *CertificateRequest) PEM() []byte {
+	return pem.EncodeToMemory(&pem.Block{
+		Type:  PemCertificateRequest,
+		Bytes: r.Raw,
+	})
+}
+
+// Checking of the signature of the request with its own key.
+func (r *CertificateRequest) CheckSignature() error {
+	return checkSignature(r.SignatureAlgorithm, r.RawTBSCertificateRequest, r.Signature, r.PublicKey)
+}
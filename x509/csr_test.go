@@ -0,0 +1,112 @@
+// go test -v -bench=. -benchtime=100x
+package x509
+
+import (
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"testing"
+
+	gkeys "github.com/towleeee/go-cryptopro/gost_r_34_10_2012"
+)
+
+var (
+	TEST_REQUEST = &CertificateRequest{
+		Subject: Name{
+			Name: pkix.Name{
+				CommonName:   "Иванов Иван Иванович",
+				Organization: []string{"ООО \"Тест\""},
+				Country:      []string{"RU"},
+			},
+			Surname:   "Иванов",
+			GivenName: "Иван Иванович",
+			INN:       "771234567890",
+			OGRN:      "1027700000000",
+			SNILS:     "12345678901",
+		},
+		KeyUsage:       KeyUsageDigitalSignature | KeyUsageContentCommitment,
+		ExtKeyUsage:    []asn1.ObjectIdentifier{ExtKeyUsageClientAuth},
+		EmailAddresses: []string{"test@example.com"},
+	}
+)
+
+func TestCertificateRequest(t *testing.T) {
+	der, err := CreateCertificateRequest(TEST_REQUEST, PRIVATE_KEY)
+	if err != nil {
+		t.Errorf("test failed: create certificate request")
+		return
+	}
+
+	csr, err := ParseCertificateRequest(der)
+	if err != nil {
+		t.Errorf("test failed: parse certificate request")
+		return
+	}
+
+	csr, err = ParseCertificateRequestPEM(csr.PEM())
+	if err != nil {
+		t.Errorf("test failed: parse certificate request pem")
+		return
+	}
+
+	if err := csr.CheckSignature(); err != nil {
+		t.Errorf("test failed: check signature: %s", err)
+		return
+	}
+
+	if !csr.PublicKey.Equals(PRIVATE_KEY.PubKey(gkeys.AT_SIGNATURE)) {
+		t.Errorf("test failed: public key")
+		return
+	}
+
+	if csr.Subject.String() != TEST_REQUEST.Subject.String() ||
+		csr.Subject.INN != TEST_REQUEST.Subject.INN ||
+		csr.Subject.SNILS != TEST_REQUEST.Subject.SNILS {
+		t.Errorf("test failed: subject %s", csr.Subject)
+		return
+	}
+
+	if csr.KeyUsage != TEST_REQUEST.KeyUsage ||
+		len(csr.ExtKeyUsage) != 1 ||
+		len(csr.EmailAddresses) != 1 {
+		t.Errorf("test failed: extensions")
+		return
+	}
+
+	csr.Signature[7] ^= byte(0x1)
+
+	if err := csr.CheckSignature(); err == nil {
+		t.Errorf("test failed: check wrong signature")
+		return
+	}
+}
+
+func TestCertificateRequestConfig(t *testing.T) {
+	cfg := gkeys.NewConfig(gkeys.K256, TEST_SUBJECT, TEST_PASSWORD)
+
+	der, err := CreateCertificateRequestConfig(TEST_REQUEST, cfg)
+	if err != nil {
+		t.Errorf("test failed: create certificate request")
+		return
+	}
+
+	csr, err := ParseCertificateRequest(der)
+	if err != nil {
+		t.Errorf("test failed: parse certificate request")
+		return
+	}
+
+	if err := csr.CheckSignature(); err != nil {
+		t.Errorf("test failed: check signature: %s", err)
+		return
+	}
+}
+
+func BenchmarkCreateCertificateRequest(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, err := CreateCertificateRequest(TEST_REQUEST, PRIVATE_KEY)
+		if err != nil {
+			b.Errorf("benchmark failed: create certificate request")
+			break
+		}
+	}
+}
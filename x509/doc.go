@@ -0,0 +1,70 @@
+/*
+func ParseCertificate(der []byte) (*Certificate, error) {}
+func ParseCertificatePEM(data []byte) (*Certificate, error) {}
+func (c *Certificate) PEM() []byte {}
+func (c *Certificate) CheckSignature(algo asn1.ObjectIdentifier, signed, signature []byte) error {}
+func (c *Certificate) Equal(cmp *Certificate) bool {}
+func CreateCertificate(template, parent *Certificate, pub gkeys.PubKey, priv gkeys.PrivKey) ([]byte, error) {}
+
+func (c *Certificate) CheckSignatureFrom(parent *Certificate) error {}
+func (c *Certificate) Verify(opts VerifyOptions) ([][]*Certificate, error) {}
+
+func NewCertPool() *CertPool {}
+func (p *CertPool) AddCert(cert *Certificate) {}
+func (p *CertPool) AppendCertsFromPEM(data []byte) bool {}
+func (p *CertPool) Len() int {}
+
+func ParseRevocationList(der []byte) (*RevocationList, error) {}
+func ParseRevocationListPEM(data []byte) (*RevocationList, error) {}
+func LoadRevocationList(path string) (*RevocationList, error) {}
+func (crl *RevocationList) PEM() []byte {}
+func (c *Certificate) CheckCRLSignature(crl *RevocationList) error {}
+func CreateRevocationList(template *RevocationList, issuer *Certificate, priv gkeys.PrivKey) ([]byte, error) {}
+
+func CreateCertificateRequest(template *CertificateRequest, priv gkeys.PrivKey) ([]byte, error) {}
+func CreateCertificateRequestConfig(template *CertificateRequest, cfg *gkeys.Config) ([]byte, error) {}
+func ParseCertificateRequest(der []byte) (*CertificateRequest, error) {}
+func ParseCertificateRequestPEM(data []byte) (*CertificateRequest, error) {}
+func (r *CertificateRequest) PEM() []byte {}
+func (r *CertificateRequest) CheckSignature() error {}
+
+func (n *Name) FillFromRDNSequence(rdns *pkix.RDNSequence) {}
+func (n Name) ToRDNSequence() pkix.RDNSequence {}
+func (n Name) String() string {}
+*/
+package x509
+
+/*
+package main
+
+import (
+	"fmt"
+	"os"
+
+	gx509 "github.com/towleeee/go-cryptopro/x509"
+)
+
+func main() {
+	data, err := os.ReadFile("username.cer")
+	if err != nil {
+		panic(err)
+	}
+
+	cert, err := gx509.ParseCertificate(data)
+	if err != nil {
+		panic(err)
+	}
+
+	// Checking of the self-signed certificate.
+	err = cert.CheckSignature(cert.SignatureAlgorithm, cert.RawTBSCertificate, cert.Signature)
+
+	fmt.Printf(
+		"Subject: %s;\nINN: %s;\nSNILS: %s;\nPubKey: %x;\nSuccess: %t;\n",
+		cert.Subject,
+		cert.Subject.INN,
+		cert.Subject.SNILS,
+		cert.PublicKey.Bytes(),
+		err == nil,
+	)
+}
+*/
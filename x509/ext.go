@@ -0,0 +1,295 @@
+package x509
+
+import (
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"fmt"
+	"math/big"
+
+	"github.com/towleeee/go-cryptopro/internal/oids"
+)
+
+/*
+ * KEY USAGE
+ */
+
+// Bits of the key usage extension (RFC 5280, 4.2.1.3).
+type KeyUsage int
+
+const (
+	KeyUsageDigitalSignature KeyUsage = 1 << iota
+	KeyUsageContentCommitment
+	KeyUsageKeyEncipherment
+	KeyUsageDataEncipherment
+	KeyUsageKeyAgreement
+	KeyUsageCertSign
+	KeyUsageCRLSign
+	KeyUsageEncipherOnly
+	KeyUsageDecipherOnly
+)
+
+// Purposes of the extended key usage extension (RFC 5280, 4.2.1.12).
+var (
+	ExtKeyUsageAny             = asn1.ObjectIdentifier{2, 5, 29, 37, 0}
+	ExtKeyUsageServerAuth      = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 3, 1}
+	ExtKeyUsageClientAuth      = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 3, 2}
+	ExtKeyUsageCodeSigning     = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 3, 3}
+	ExtKeyUsageEmailProtection = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 3, 4}
+	ExtKeyUsageTimeStamping    = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 3, 8}
+	ExtKeyUsageOCSPSigning     = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 3, 9}
+)
+
+/*
+ * ASN.1
+ */
+
+type basicConstraints struct {
+	IsCA       bool `asn1:"optional"`
+	MaxPathLen int  `asn1:"optional,default:-1"`
+}
+
+type authorityKeyId struct {
+	Id           []byte        `asn1:"optional,tag:0"`
+	Issuer       asn1.RawValue `asn1:"optional,tag:1"`
+	SerialNumber *big.Int      `asn1:"optional,tag:2"`
+}
+
+type distributionPoint struct {
+	DistributionPoint distributionPointName `asn1:"optional,tag:0"`
+	Reason            asn1.BitString        `asn1:"optional,tag:1"`
+	CRLIssuer         asn1.RawValue         `asn1:"optional,tag:2"`
+}
+
+type distributionPointName struct {
+	FullName     []asn1.RawValue  `asn1:"optional,tag:0"`
+	RelativeName pkix.RDNSequence `asn1:"optional,tag:1"`
+}
+
+type policyInformation struct {
+	Policy asn1.ObjectIdentifier
+}
+
+type issuerSignTool struct {
+	SignTool     string `asn1:"utf8"`
+	CATool       string `asn1:"utf8"`
+	SignToolCert string `asn1:"utf8"`
+	CAToolCert   string `asn1:"utf8"`
+}
+
+// Tags of GeneralName (RFC 5280, 4.2.1.6).
+const (
+	nameTypeEmail = 1
+	nameTypeDNS   = 2
+	nameTypeURI   = 6
+)
+
+/*
+ * PARSING
+ */
+
+func (c *Certificate) parseExtensions() error {
+	for _, ext := range c.Extensions {
+		var err error
+		switch {
+		case ext.Id.Equal(oids.ExtKeyUsage):
+			c.KeyUsage, err = parseKeyUsage(ext.Value)
+		case ext.Id.Equal(oids.ExtExtKeyUsage):
+			_, err = asn1.Unmarshal(ext.Value, &c.ExtKeyUsage)
+		case ext.Id.Equal(oids.ExtBasicConstraints):
+			var bc basicConstraints
+			_, err = asn1.Unmarshal(ext.Value, &bc)
+			c.BasicConstraintsValid = true
+			c.IsCA = bc.IsCA
+			c.MaxPathLen = bc.MaxPathLen
+			c.MaxPathLenZero = bc.MaxPathLen == 0
+		case ext.Id.Equal(oids.ExtSubjectKeyId):
+			_, err = asn1.Unmarshal(ext.Value, &c.SubjectKeyId)
+		case ext.Id.Equal(oids.ExtAuthorityKeyId):
+			var aki authorityKeyId
+			_, err = asn1.Unmarshal(ext.Value, &aki)
+			c.AuthorityKeyId = aki.Id
+		case ext.Id.Equal(oids.ExtSubjectAltName):
+			err = c.parseAltName(ext.Value)
+		case ext.Id.Equal(oids.ExtCRLDistributionPoints):
+			err = c.parseDistributionPoints(ext.Value)
+		case ext.Id.Equal(oids.ExtCertificatePolicies):
+			var policies []policyInformation
+			_, err = asn1.Unmarshal(ext.Value, &policies)
+			for _, v := range policies {
+				c.PolicyIdentifiers = append(c.PolicyIdentifiers, v.Policy)
+			}
+		case ext.Id.Equal(oids.ExtSubjectSignTool):
+			_, err = asn1.Unmarshal(ext.Value, &c.SubjectSignTool)
+		case ext.Id.Equal(oids.ExtIssuerSignTool):
+			var tool issuerSignTool
+			_, err = asn1.Unmarshal(ext.Value, &tool)
+			c.IssuerSignTool = []string{tool.SignTool, tool.CATool, tool.SignToolCert, tool.CAToolCert}
+		default:
+			if ext.Critical {
+				c.UnhandledCriticalExtensions = append(c.UnhandledCriticalExtensions, ext.Id)
+			}
+		}
+		if err != nil {
+			return fmt.Errorf("error: parse extension %s: %w", ext.Id, err)
+		}
+	}
+	return nil
+}
+
+func parseKeyUsage(der []byte) (KeyUsage, error) {
+	var bits asn1.BitString
+	_, err := asn1.Unmarshal(der, &bits)
+	if err != nil {
+		return 0, err
+	}
+	var usage KeyUsage
+	for i := 0; i < 9; i++ {
+		if bits.At(i) != 0 {
+			usage |= 1 << uint(i)
+		}
+	}
+	return usage, nil
+}
+
+func (c *Certificate) parseAltName(der []byte) error {
+	var names []asn1.RawValue
+	_, err := asn1.Unmarshal(der, &names)
+	if err != nil {
+		return err
+	}
+	for _, v := range names {
+		if v.Class != asn1.ClassContextSpecific {
+			continue
+		}
+		switch v.Tag {
+		case nameTypeEmail:
+			c.EmailAddresses = append(c.EmailAddresses, string(v.Bytes))
+		case nameTypeDNS:
+			c.DNSNames = append(c.DNSNames, string(v.Bytes))
+		}
+	}
+	return nil
+}
+
+func (c *Certificate) parseDistributionPoints(der []byte) error {
+	var points []distributionPoint
+	_, err := asn1.Unmarshal(der, &points)
+	if err != nil {
+		return err
+	}
+	for _, p := range points {
+		for _, v := range p.DistributionPoint.FullName {
+			if v.Class == asn1.ClassContextSpecific && v.Tag == nameTypeURI {
+				c.CRLDistributionPoints = append(c.CRLDistributionPoints, string(v.Bytes))
+			}
+		}
+	}
+	return nil
+}
+
+/*
+ * ENCODING
+ */
+
+func marshalKeyUsage(usage KeyUsage) (pkix.Extension, error) {
+	var (
+		data   [2]byte
+		length int
+	)
+	for i := 0; i < 9; i++ {
+		if usage&(1<<uint(i)) != 0 {
+			data[i/8] |= 0x80 >> uint(i%8)
+			length = i + 1
+		}
+	}
+
+	value, err := asn1.Marshal(asn1.BitString{
+		Bytes:     data[:(length+7)/8],
+		BitLength: length,
+	})
+	if err != nil {
+		return pkix.Extension{}, err
+	}
+
+	return pkix.Extension{Id: oids.ExtKeyUsage, Critical: true, Value: value}, nil
+}
+
+func marshalExtKeyUsage(usage []asn1.ObjectIdentifier) (pkix.Extension, error) {
+	value, err := asn1.Marshal(usage)
+	if err != nil {
+		return pkix.Extension{}, err
+	}
+	return pkix.Extension{Id: oids.ExtExtKeyUsage, Value: value}, nil
+}
+
+func marshalAltName(dnsNames, emailAddresses []string) (pkix.Extension, error) {
+	var names []asn1.RawValue
+	for _, v := range dnsNames {
+		names = append(names, asn1.RawValue{
+			Class: asn1.ClassContextSpecific,
+			Tag:   nameTypeDNS,
+			Bytes: []byte(v),
+		})
+	}
+	for _, v := range emailAddresses {
+		names = append(names, asn1.RawValue{
+			Class: asn1.ClassContextSpecific,
+			Tag:   nameTypeEmail,
+			Bytes: []byte(v),
+		})
+	}
+
+	value, err := asn1.Marshal(names)
+	if err != nil {
+		return pkix.Extension{}, err
+	}
+	return pkix.Extension{Id: oids.ExtSubjectAltName, Value: value}, nil
+}
+
+// List of extensions, the extra extensions of the caller
+// replace the extensions with the same identifier.
+type extensions struct {
+	list  []pkix.Extension
+	extra []pkix.Extension
+}
+
+func (e *extensions) add(ext pkix.Extension, err error) error {
+	if err != nil {
+		return err
+	}
+	for _, v := range e.extra {
+		if v.Id.Equal(ext.Id) {
+			return nil
+		}
+	}
+	e.list = append(e.list, ext)
+	return nil
+}
+
+// Extensions of the key usage and the alternative names.
+func (e *extensions) addUsage(
+	usage KeyUsage,
+	extUsage []asn1.ObjectIdentifier,
+	dnsNames, emailAddresses []string,
+) error {
+	if usage != 0 {
+		if err := e.add(marshalKeyUsage(usage)); err != nil {
+			return err
+		}
+	}
+	if len(extUsage) != 0 {
+		if err := e.add(marshalExtKeyUsage(extUsage)); err != nil {
+			return err
+		}
+	}
+	if len(dnsNames) != 0 || len(emailAddresses) != 0 {
+		if err := e.add(marshalAltName(dnsNames, emailAddresses)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *extensions) build() []pkix.Extension {
+	return append(e.list, e.extra...)
+}
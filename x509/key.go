@@ -0,0 +1,106 @@
+package x509
+
+import (
+	"encoding/asn1"
+	"fmt"
+
+	gkeys "github.com/towleeee/go-cryptopro/gost_r_34_10_2012"
+	ghash "github.com/towleeee/go-cryptopro/gost_r_34_11_2012"
+	"github.com/towleeee/go-cryptopro/internal/keyblob"
+	"github.com/towleeee/go-cryptopro/internal/oids"
+)
+
+/*
+ * SUBJECT PUBLIC KEY INFO
+ */
+
+type publicKeyInfo = keyblob.SubjectPublicKeyInfo
+
+// Conversion of SubjectPublicKeyInfo (RFC 9215)
+// to the format of PubKey256/PubKey512.
+func parsePublicKey(spki *publicKeyInfo) (gkeys.PubKey, keyblob.Params, error) {
+	key, params, err := keyblob.ParsePKIX(spki, 0)
+	if err != nil {
+		return nil, params, err
+	}
+
+	pbytes, err := key.Bytes()
+	if err != nil {
+		return nil, params, err
+	}
+
+	pub, err := gkeys.LoadPubKey(pbytes)
+	if err != nil {
+		return nil, params, err
+	}
+
+	return pub, params, nil
+}
+
+/*
+ * SIGNATURE
+ */
+
+// Checking of the signature (big-endian s || r) over the signed data
+// with GOST R 34.10-2012 signature algorithm of the key size.
+func checkSignature(algo asn1.ObjectIdentifier, signed, signature []byte, pub gkeys.PubKey) error {
+	var hprov ghash.ProvType
+	switch {
+	case algo.Equal(oids.SignWithDigest256):
+		hprov = ghash.H256
+	case algo.Equal(oids.SignWithDigest512):
+		hprov = ghash.H512
+	default:
+		return fmt.Errorf("error: signature algorithm %s", algo)
+	}
+
+	pbytes := pub.Bytes()
+	if len(pbytes) == 0 || pbytes[0] != byte(hprov) {
+		return fmt.Errorf("error: signature algorithm %s does not match key", algo)
+	}
+
+	digest, err := ghash.SumE(hprov, signed)
+	if err != nil {
+		return err
+	}
+	return pub.VerifyDigestE(digest, signature, gkeys.FormatRFC4491)
+}
+
+// Conversion of PubKey256/PubKey512 to SubjectPublicKeyInfo (RFC 9215).
+func marshalPublicKey(pub gkeys.PubKey) (publicKeyInfo, error) {
+	key, err := keyblob.Parse(pub.Bytes())
+	if err != nil {
+		return publicKeyInfo{}, err
+	}
+	spki, err := key.PKIX()
+	if err != nil {
+		return publicKeyInfo{}, err
+	}
+	return *spki, nil
+}
+
+// Signature algorithm of the key size.
+func signatureAlgorithm(pub gkeys.PubKey) asn1.ObjectIdentifier {
+	pbytes := pub.Bytes()
+	if len(pbytes) != 0 && pbytes[0] == byte(gkeys.K512) {
+		return oids.SignWithDigest512
+	}
+	return oids.SignWithDigest256
+}
+
+// Signature (big-endian s || r) over the signed data.
+func signData(signer *gkeys.Signer, algo asn1.ObjectIdentifier, signed []byte) ([]byte, error) {
+	hprov := ghash.H256
+	if algo.Equal(oids.SignWithDigest512) {
+		hprov = ghash.H512
+	}
+	digest, err := ghash.SumE(hprov, signed)
+	if err != nil {
+		return nil, err
+	}
+	sign, err := signer.Sign(nil, digest, nil)
+	if err != nil {
+		return nil, err
+	}
+	return gkeys.ConvertSignature(gkeys.ProvType(hprov), sign, gkeys.FormatCryptoAPI, gkeys.FormatRFC4491)
+}
@@ -0,0 +1,198 @@
+package x509
+
+import (
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"fmt"
+	"strings"
+
+	"github.com/towleeee/go-cryptopro/internal/oids"
+)
+
+// Distinguished name with the attributes
+// of russian qualified certificates.
+type Name struct {
+	pkix.Name
+
+	Surname      string
+	GivenName    string
+	Title        string
+	EmailAddress string
+
+	INN    string // ИНН физического лица
+	INNLE  string // ИНН юридического лица
+	OGRN   string // ОГРН
+	OGRNIP string // ОГРНИП
+	SNILS  string // СНИЛС
+}
+
+// Filling of the name from the parsed RDNSequence.
+func (n *Name) FillFromRDNSequence(rdns *pkix.RDNSequence) {
+	n.Name.FillFromRDNSequence(rdns)
+
+	for _, v := range n.Name.Names {
+		value, ok := v.Value.(string)
+		if !ok {
+			continue
+		}
+		if field := n.field(v.Type); field != nil {
+			*field = value
+		}
+	}
+}
+
+// Encoding of the name to RDNSequence, the attributes
+// of the fields follow the attributes of pkix.Name.
+func (n Name) ToRDNSequence() pkix.RDNSequence {
+	name := n.Name
+	name.ExtraNames = nil
+
+	for _, v := range n.Name.ExtraNames {
+		if n.field(v.Type) == nil {
+			name.ExtraNames = append(name.ExtraNames, v)
+		}
+	}
+
+	for _, v := range []struct {
+		oid   asn1.ObjectIdentifier
+		value string
+		tag   int
+	}{
+		{oids.AttrSurname, n.Surname, asn1.TagUTF8String},
+		{oids.AttrGivenName, n.GivenName, asn1.TagUTF8String},
+		{oids.AttrTitle, n.Title, asn1.TagUTF8String},
+		{oids.AttrEmailAddress, n.EmailAddress, asn1.TagIA5String},
+		{oids.AttrINN, n.INN, asn1.TagNumericString},
+		{oids.AttrINNLE, n.INNLE, asn1.TagNumericString},
+		{oids.AttrOGRN, n.OGRN, asn1.TagNumericString},
+		{oids.AttrOGRNIP, n.OGRNIP, asn1.TagNumericString},
+		{oids.AttrSNILS, n.SNILS, asn1.TagNumericString},
+	} {
+		if v.value == "" {
+			continue
+		}
+		name.ExtraNames = append(name.ExtraNames, pkix.AttributeTypeAndValue{
+			Type: v.oid,
+			Value: asn1.RawValue{
+				Class: asn1.ClassUniversal,
+				Tag:   v.tag,
+				Bytes: []byte(v.value),
+			},
+		})
+	}
+
+	return name.ToRDNSequence()
+}
+
+// RFC 4514 form of the name with the short names of russian attributes.
+func (n Name) String() string {
+	var (
+		rdns = n.ToRDNSequence()
+		list []string
+	)
+	for i := len(rdns) - 1; i >= 0; i-- {
+		var set []string
+		for _, v := range rdns[i] {
+			set = append(set, attributeName(v.Type)+"="+escapeValue(v.Value))
+		}
+		list = append(list, strings.Join(set, "+"))
+	}
+	return strings.Join(list, ",")
+}
+
+var attributeNames = []struct {
+	oid  asn1.ObjectIdentifier
+	name string
+}{
+	{asn1.ObjectIdentifier{2, 5, 4, 3}, "CN"},
+	{asn1.ObjectIdentifier{2, 5, 4, 5}, "SERIALNUMBER"},
+	{asn1.ObjectIdentifier{2, 5, 4, 6}, "C"},
+	{asn1.ObjectIdentifier{2, 5, 4, 7}, "L"},
+	{asn1.ObjectIdentifier{2, 5, 4, 8}, "ST"},
+	{asn1.ObjectIdentifier{2, 5, 4, 9}, "STREET"},
+	{asn1.ObjectIdentifier{2, 5, 4, 10}, "O"},
+	{asn1.ObjectIdentifier{2, 5, 4, 11}, "OU"},
+	{asn1.ObjectIdentifier{2, 5, 4, 17}, "POSTALCODE"},
+	{oids.AttrSurname, "SN"},
+	{oids.AttrGivenName, "G"},
+	{oids.AttrTitle, "T"},
+	{oids.AttrEmailAddress, "E"},
+	{oids.AttrINN, "INN"},
+	{oids.AttrINNLE, "INNLE"},
+	{oids.AttrOGRN, "OGRN"},
+	{oids.AttrOGRNIP, "OGRNIP"},
+	{oids.AttrSNILS, "SNILS"},
+}
+
+func attributeName(oid asn1.ObjectIdentifier) string {
+	for _, v := range attributeNames {
+		if v.oid.Equal(oid) {
+			return v.name
+		}
+	}
+	return oid.String()
+}
+
+func escapeValue(value interface{}) string {
+	var s string
+	switch v := value.(type) {
+	case string:
+		s = v
+	case asn1.RawValue:
+		s = string(v.Bytes)
+	default:
+		s = fmt.Sprint(v)
+	}
+	var b strings.Builder
+	for i, r := range s {
+		switch {
+		case strings.ContainsRune(",+\"<>;\\", r),
+			i == 0 && (r == ' ' || r == '#'),
+			i == len(s)-1 && r == ' ':
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func (n *Name) field(oid asn1.ObjectIdentifier) *string {
+	switch {
+	case oid.Equal(oids.AttrSurname):
+		return &n.Surname
+	case oid.Equal(oids.AttrGivenName):
+		return &n.GivenName
+	case oid.Equal(oids.AttrTitle):
+		return &n.Title
+	case oid.Equal(oids.AttrEmailAddress):
+		return &n.EmailAddress
+	case oid.Equal(oids.AttrINN):
+		return &n.INN
+	case oid.Equal(oids.AttrINNLE):
+		return &n.INNLE
+	case oid.Equal(oids.AttrOGRN):
+		return &n.OGRN
+	case oid.Equal(oids.AttrOGRNIP):
+		return &n.OGRNIP
+	case oid.Equal(oids.AttrSNILS):
+		return &n.SNILS
+	default:
+		return nil
+	}
+}
+
+func parseName(der []byte) (Name, error) {
+	var (
+		name Name
+		rdns pkix.RDNSequence
+	)
+	rest, err := asn1.Unmarshal(der, &rdns)
+	if err != nil {
+		return name, err
+	}
+	if len(rest) != 0 {
+		return name, fmt.Errorf("error: trailing data after name")
+	}
+	name.FillFromRDNSequence(&rdns)
+	return name, nil
+}
@@ -0,0 +1,87 @@
+package x509
+
+import (
+	"bytes"
+	"encoding/pem"
+)
+
+// Set of certificates: trusted roots or intermediates.
+type CertPool struct {
+	certs []*Certificate
+}
+
+// Create empty pool.
+func NewCertPool() *CertPool {
+	return &CertPool{}
+}
+
+// Adding a certificate if it is not included yet.
+func (p *CertPool) AddCert(cert *Certificate) {
+	if p.contains(cert) {
+		return
+	}
+	p.certs = append(p.certs, cert)
+}
+
+// Adding of all "CERTIFICATE" blocks of PEM data,
+// the result is true if at least one certificate was added.
+func (p *CertPool) AppendCertsFromPEM(data []byte) bool {
+	ok := false
+	for len(data) > 0 {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type != PemCertificate {
+			continue
+		}
+		cert, err := ParseCertificate(block.Bytes)
+		if err != nil {
+			continue
+		}
+		p.AddCert(cert)
+		ok = true
+	}
+	return ok
+}
+
+// Number of certificates in the pool.
+func (p *CertPool) Len() int {
+	if p == nil {
+		return 0
+	}
+	return len(p.certs)
+}
+
+func (p *CertPool) contains(cert *Certificate) bool {
+	if p == nil {
+		return false
+	}
+	for _, v := range p.certs {
+		if v.Equal(cert) {
+			return true
+		}
+	}
+	return false
+}
+
+// Candidates for the issuer of the certificate: the subject
+// is the issuer of the certificate and the key identifiers match.
+func (p *CertPool) findIssuers(cert *Certificate) []*Certificate {
+	if p == nil {
+		return nil
+	}
+	var list []*Certificate
+	for _, v := range p.certs {
+		if !bytes.Equal(v.RawSubject, cert.RawIssuer) {
+			continue
+		}
+		if len(cert.AuthorityKeyId) != 0 && len(v.SubjectKeyId) != 0 &&
+			!bytes.Equal(cert.AuthorityKeyId, v.SubjectKeyId) {
+			continue
+		}
+		list = append(list, v)
+	}
+	return list
+}
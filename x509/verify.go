@@ -0,0 +1,237 @@
+package x509
+
+import (
+	"encoding/asn1"
+	"fmt"
+	"time"
+)
+
+const (
+	maxChainLength = 10
+)
+
+// Options of the path validation.
+type VerifyOptions struct {
+	// Trusted root certificates, required.
+	Roots *CertPool
+	// Intermediate certificates for chain building.
+	Intermediates *CertPool
+	// Time of the validation (e.g. signing time),
+	// the current time is used if zero.
+	CurrentTime time.Time
+	// Key usage bits required from the leaf certificate.
+	KeyUsage KeyUsage
+	// Extended key usages, one of them is required
+	// from the leaf certificate with the extension.
+	ExtKeyUsage []asn1.ObjectIdentifier
+	// Local CRLs of the issuers.
+	CRLs []*RevocationList
+	// Each certificate except the root must be covered by a CRL
+	// valid at CurrentTime (ThisUpdate <= CurrentTime < NextUpdate).
+	RevocationRequired bool
+}
+
+// Checking of the signature of the certificate by the parent:
+// the parent must be CA with the certificate signing key usage.
+func (c *Certificate) CheckSignatureFrom(parent *Certificate) error {
+	if parent.Version == 3 && (!parent.BasicConstraintsValid || !parent.IsCA) {
+		return fmt.Errorf("error: parent certificate is not ca")
+	}
+	if parent.KeyUsage != 0 && parent.KeyUsage&KeyUsageCertSign == 0 {
+		return fmt.Errorf("error: parent key usage does not permit certificate signing")
+	}
+	return parent.CheckSignature(c.SignatureAlgorithm, c.RawTBSCertificate, c.Signature)
+}
+
+// Building and validation of the chains from the certificate
+// to the trusted roots. Each chain begins with the certificate
+// and ends with the root. The signatures, validity periods,
+// path length constraints and revocation by local CRLs are checked
+// for each certificate of the chain, the key usage is checked
+// for the leaf certificate.
+func (c *Certificate) Verify(opts VerifyOptions) ([][]*Certificate, error) {
+	if opts.Roots.Len() == 0 {
+		return nil, fmt.Errorf("error: roots are empty")
+	}
+	if opts.CurrentTime.IsZero() {
+		opts.CurrentTime = time.Now()
+	}
+
+	err := c.checkUsage(&opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var chains [][]*Certificate
+	err = c.buildChains(&opts, []*Certificate{c}, &chains)
+	if len(chains) == 0 {
+		if err == nil {
+			err = fmt.Errorf("error: certificate signed by unknown authority")
+		}
+		return nil, err
+	}
+
+	return chains, nil
+}
+
+func (c *Certificate) checkUsage(opts *VerifyOptions) error {
+	if opts.KeyUsage != 0 && c.KeyUsage != 0 && c.KeyUsage&opts.KeyUsage != opts.KeyUsage {
+		return fmt.Errorf("error: certificate key usage")
+	}
+
+	if len(opts.ExtKeyUsage) == 0 || len(c.ExtKeyUsage) == 0 {
+		return nil
+	}
+	for _, v := range c.ExtKeyUsage {
+		if v.Equal(ExtKeyUsageAny) {
+			return nil
+		}
+		for _, w := range opts.ExtKeyUsage {
+			if v.Equal(w) {
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("error: certificate extended key usage")
+}
+
+// Depth-first search of the issuers,
+// the last error is returned if no chain is found.
+func (c *Certificate) buildChains(opts *VerifyOptions, chain []*Certificate, chains *[][]*Certificate) error {
+	err := c.checkValidity(opts.CurrentTime)
+	if err != nil {
+		return err
+	}
+	if len(c.UnhandledCriticalExtensions) != 0 {
+		return fmt.Errorf(
+			"error: certificate %s has unhandled critical extension %s",
+			c.Subject.CommonName,
+			c.UnhandledCriticalExtensions[0],
+		)
+	}
+
+	if opts.Roots.contains(c) {
+		*chains = append(*chains, append([]*Certificate{}, chain...))
+		return nil
+	}
+
+	if len(chain) >= maxChainLength {
+		return fmt.Errorf("error: chain is too long")
+	}
+
+	var lastErr error
+	for _, pool := range []*CertPool{opts.Roots, opts.Intermediates} {
+		for _, parent := range pool.findIssuers(c) {
+			if inChain(chain, parent) {
+				continue
+			}
+
+			err := c.CheckSignatureFrom(parent)
+			if err == nil {
+				err = checkPathLen(parent, chain)
+			}
+			if err == nil {
+				err = c.checkRevocation(parent, opts)
+			}
+			if err == nil {
+				err = parent.buildChains(opts, append(chain, parent), chains)
+			}
+			if err != nil {
+				lastErr = err
+			}
+		}
+	}
+
+	return lastErr
+}
+
+func (c *Certificate) checkValidity(now time.Time) error {
+	if now.Before(c.NotBefore) {
+		return fmt.Errorf("error: certificate %s is not yet valid", c.Subject.CommonName)
+	}
+	if now.After(c.NotAfter) {
+		return fmt.Errorf("error: certificate %s has expired", c.Subject.CommonName)
+	}
+	return nil
+}
+
+// The number of intermediate certificates below the parent
+// must not exceed its path length constraint.
+func checkPathLen(parent *Certificate, chain []*Certificate) error {
+	if !parent.BasicConstraintsValid || parent.MaxPathLen < 0 {
+		return nil
+	}
+	if parent.MaxPathLen == 0 && !parent.MaxPathLenZero {
+		return nil
+	}
+	if len(chain)-1 > parent.MaxPathLen {
+		return fmt.Errorf("error: path length constraint of %s", parent.Subject.CommonName)
+	}
+	return nil
+}
+
+// Checking of the certificate by the CRLs of the issuer.
+// The revocations of all verified CRLs are applied, but only a CRL valid
+// at the time of the check covers the certificate. A CRL failing the
+// verification (signature, key usage of the issuer) is skipped.
+func (c *Certificate) checkRevocation(issuer *Certificate, opts *VerifyOptions) error {
+	covered, stale := false, false
+	var unverified error
+	for _, crl := range opts.CRLs {
+		if string(crl.RawIssuer) != string(issuer.RawSubject) {
+			continue
+		}
+		if len(crl.AuthorityKeyId) != 0 && len(issuer.SubjectKeyId) != 0 &&
+			string(crl.AuthorityKeyId) != string(issuer.SubjectKeyId) {
+			continue
+		}
+		// A partial or indirect CRL does not cover the certificate.
+		if len(crl.UnhandledCriticalExtensions) != 0 {
+			continue
+		}
+		if err := issuer.CheckCRLSignature(crl); err != nil {
+			unverified = err
+			continue
+		}
+		if crl.validAt(opts.CurrentTime) {
+			covered = true
+		} else {
+			stale = true
+		}
+		if revoked, ok := crl.revoked(c.SerialNumber, opts.CurrentTime); ok {
+			return fmt.Errorf(
+				"error: certificate %s was revoked at %s",
+				c.Subject.CommonName,
+				revoked.RevocationTime.Format(time.RFC3339),
+			)
+		}
+	}
+	switch {
+	case !opts.RevocationRequired || covered:
+		// pass
+	case stale:
+		return fmt.Errorf(
+			"error: revocation list of %s is not valid at %s",
+			issuer.Subject.CommonName,
+			opts.CurrentTime.Format(time.RFC3339),
+		)
+	case unverified != nil:
+		return fmt.Errorf(
+			"error: revocation list of %s is not verified: %w",
+			issuer.Subject.CommonName,
+			unverified,
+		)
+	default:
+		return fmt.Errorf("error: revocation list of %s not found", issuer.Subject.CommonName)
+	}
+	return nil
+}
+
+func inChain(chain []*Certificate, cert *Certificate) bool {
+	for _, v := range chain {
+		if v.Equal(cert) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,335 @@
+// go test -v -bench=. -benchtime=100x
+package x509
+
+import (
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	gkeys "github.com/towleeee/go-cryptopro/gost_r_34_10_2012"
+)
+
+var (
+	TEST_TIME = time.Now().UTC().Truncate(time.Second)
+)
+
+// Chain root (PRIVATE_KEY) -> intermediate (PRIVATE_KEY_2) -> leaf (PRIVATE_KEY_3).
+func testChain() (root, inter, leaf *Certificate, err error) {
+	create := func(template, parent *Certificate, priv, issuer gkeys.PrivKey) (*Certificate, error) {
+		der, err := CreateCertificate(template, parent, priv.PubKey(gkeys.AT_SIGNATURE), issuer)
+		if err != nil {
+			return nil, err
+		}
+		return ParseCertificate(der)
+	}
+
+	root, err = create(&Certificate{
+		Subject:               Name{Name: pkix.Name{CommonName: "Test Root CA"}},
+		NotBefore:             TEST_TIME.AddDate(-1, 0, 0),
+		NotAfter:              TEST_TIME.AddDate(10, 0, 0),
+		KeyUsage:              KeyUsageCertSign | KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}, nil, PRIVATE_KEY, PRIVATE_KEY)
+	if err != nil {
+		return
+	}
+
+	inter, err = create(&Certificate{
+		Subject:               Name{Name: pkix.Name{CommonName: "Test Intermediate CA"}},
+		NotBefore:             TEST_TIME.AddDate(-1, 0, 0),
+		NotAfter:              TEST_TIME.AddDate(5, 0, 0),
+		KeyUsage:              KeyUsageCertSign | KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		MaxPathLenZero:        true,
+	}, root, PRIVATE_KEY_2, PRIVATE_KEY)
+	if err != nil {
+		return
+	}
+
+	leaf, err = create(&Certificate{
+		SerialNumber:          big.NewInt(0x1234),
+		Subject:               Name{Name: pkix.Name{CommonName: "Test Leaf"}},
+		NotBefore:             TEST_TIME.AddDate(-1, 0, 0),
+		NotAfter:              TEST_TIME.AddDate(1, 0, 0),
+		KeyUsage:              KeyUsageDigitalSignature,
+		ExtKeyUsage:           []asn1.ObjectIdentifier{ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+	}, inter, PRIVATE_KEY_3, PRIVATE_KEY_2)
+	return
+}
+
+func testOptions(root, inter *Certificate) VerifyOptions {
+	roots := NewCertPool()
+	roots.AddCert(root)
+	intermediates := NewCertPool()
+	intermediates.AddCert(inter)
+	return VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		CurrentTime:   TEST_TIME,
+	}
+}
+
+func TestVerify(t *testing.T) {
+	root, inter, leaf, err := testChain()
+	if err != nil {
+		t.Errorf("test failed: create chain")
+		return
+	}
+
+	opts := testOptions(root, inter)
+	opts.KeyUsage = KeyUsageDigitalSignature
+	opts.ExtKeyUsage = []asn1.ObjectIdentifier{ExtKeyUsageClientAuth}
+
+	chains, err := leaf.Verify(opts)
+	if err != nil {
+		t.Errorf("test failed: verify: %s", err)
+		return
+	}
+	if len(chains) != 1 || len(chains[0]) != 3 ||
+		!chains[0][0].Equal(leaf) || !chains[0][1].Equal(inter) || !chains[0][2].Equal(root) {
+		t.Errorf("test failed: chain")
+		return
+	}
+
+	opts.KeyUsage = KeyUsageKeyEncipherment
+	if _, err := leaf.Verify(opts); err == nil {
+		t.Errorf("test failed: verify key usage")
+		return
+	}
+
+	opts = testOptions(root, inter)
+	opts.CurrentTime = TEST_TIME.AddDate(2, 0, 0)
+	if _, err := leaf.Verify(opts); err == nil {
+		t.Errorf("test failed: verify expired")
+		return
+	}
+
+	opts = testOptions(root, inter)
+	opts.Intermediates = nil
+	if _, err := leaf.Verify(opts); err == nil {
+		t.Errorf("test failed: verify without intermediate")
+		return
+	}
+
+}
+
+// Certificates with the critical extensions which are not handled
+// (nameConstraints, policyConstraints) are rejected (RFC 5280, 4.2).
+func TestVerifyCriticalExtension(t *testing.T) {
+	root, inter, _, err := testChain()
+	if err != nil {
+		t.Errorf("test failed: create chain")
+		return
+	}
+
+	policyConstraints := asn1.ObjectIdentifier{2, 5, 29, 36}
+	for _, critical := range []bool{false, true} {
+		der, err := CreateCertificate(&Certificate{
+			Subject:   Name{Name: pkix.Name{CommonName: "Test Leaf Constraints"}},
+			NotBefore: TEST_TIME.AddDate(-1, 0, 0),
+			NotAfter:  TEST_TIME.AddDate(1, 0, 0),
+			ExtraExtensions: []pkix.Extension{
+				{Id: policyConstraints, Critical: critical, Value: []byte{0x30, 0x00}},
+			},
+		}, inter, PRIVATE_KEY_3.PubKey(gkeys.AT_SIGNATURE), PRIVATE_KEY_2)
+		if err != nil {
+			t.Errorf("test failed: create certificate: %s", err)
+			return
+		}
+		leaf, err := ParseCertificate(der)
+		if err != nil {
+			t.Errorf("test failed: parse certificate: %s", err)
+			return
+		}
+		if (len(leaf.UnhandledCriticalExtensions) == 1) != critical {
+			t.Errorf("test failed: unhandled critical extensions %v", leaf.UnhandledCriticalExtensions)
+			return
+		}
+		if _, err := leaf.Verify(testOptions(root, inter)); (err == nil) == critical {
+			t.Errorf("test failed: verify critical %t: %v", critical, err)
+			return
+		}
+	}
+}
+
+func TestVerifyRevocation(t *testing.T) {
+	root, inter, leaf, err := testChain()
+	if err != nil {
+		t.Errorf("test failed: create chain")
+		return
+	}
+
+	der, err := CreateRevocationList(&RevocationList{
+		ThisUpdate: TEST_TIME,
+		NextUpdate: TEST_TIME.AddDate(0, 0, 7),
+		Number:     big.NewInt(1),
+		RevokedCertificates: []RevokedCertificate{{
+			SerialNumber:   leaf.SerialNumber,
+			RevocationTime: TEST_TIME.AddDate(0, 0, -1),
+			ReasonCode:     1,
+		}},
+	}, inter, PRIVATE_KEY_2)
+	if err != nil {
+		t.Errorf("test failed: create revocation list")
+		return
+	}
+
+	path := filepath.Join(t.TempDir(), "inter.crl")
+	if err := os.WriteFile(path, der, 0600); err != nil {
+		t.Errorf("test failed: write revocation list")
+		return
+	}
+
+	crl, err := LoadRevocationList(path)
+	if err != nil {
+		t.Errorf("test failed: load revocation list")
+		return
+	}
+
+	if err := inter.CheckCRLSignature(crl); err != nil {
+		t.Errorf("test failed: check crl signature: %s", err)
+		return
+	}
+
+	if len(crl.RevokedCertificates) != 1 ||
+		crl.RevokedCertificates[0].ReasonCode != 1 ||
+		crl.Number.Int64() != 1 {
+		t.Errorf("test failed: parse revocation list")
+		return
+	}
+
+	opts := testOptions(root, inter)
+	opts.CRLs = []*RevocationList{crl}
+	if _, err := leaf.Verify(opts); err == nil {
+		t.Errorf("test failed: verify revoked")
+		return
+	}
+
+	// The certificate was not revoked at signing time.
+	opts.CurrentTime = TEST_TIME.AddDate(0, 0, -2)
+	if _, err := leaf.Verify(opts); err != nil {
+		t.Errorf("test failed: verify before revocation: %s", err)
+		return
+	}
+
+	opts = testOptions(root, inter)
+	opts.RevocationRequired = true
+	if _, err := leaf.Verify(opts); err == nil {
+		t.Errorf("test failed: verify without required crl")
+		return
+	}
+
+	// The CRL covers the certificate only between ThisUpdate and NextUpdate.
+	opts.CRLs = nil
+	for _, v := range []struct {
+		issuer *Certificate
+		priv   gkeys.PrivKey
+	}{
+		{root, PRIVATE_KEY},
+		{inter, PRIVATE_KEY_2},
+	} {
+		der, err := CreateRevocationList(&RevocationList{
+			ThisUpdate: TEST_TIME,
+			NextUpdate: TEST_TIME.AddDate(0, 0, 7),
+			Number:     big.NewInt(2),
+		}, v.issuer, v.priv)
+		if err != nil {
+			t.Errorf("test failed: create empty revocation list")
+			return
+		}
+		empty, err := ParseRevocationList(der)
+		if err != nil {
+			t.Errorf("test failed: parse empty revocation list")
+			return
+		}
+		opts.CRLs = append(opts.CRLs, empty)
+	}
+	for _, v := range []struct {
+		days  int
+		valid bool
+	}{
+		{-1, false},
+		{1, true},
+		{8, false},
+	} {
+		opts.CurrentTime = TEST_TIME.AddDate(0, 0, v.days)
+		if _, err := leaf.Verify(opts); (err == nil) != v.valid {
+			t.Errorf("test failed: verify with crl at %d days: %v", v.days, err)
+			return
+		}
+	}
+
+	// The partial CRL (critical issuingDistributionPoint) does not cover.
+	opts.CurrentTime = TEST_TIME.AddDate(0, 0, 1)
+	opts.CRLs[1].UnhandledCriticalExtensions = []asn1.ObjectIdentifier{{2, 5, 29, 28}}
+	if _, err := leaf.Verify(opts); err == nil {
+		t.Errorf("test failed: verify with partial crl")
+		return
+	}
+	opts.CRLs[1].UnhandledCriticalExtensions = nil
+
+	// The CRL with the bad signature is skipped with its revocations.
+	bad, err := ParseRevocationList(opts.CRLs[1].Raw)
+	if err != nil {
+		t.Errorf("test failed: parse revocation list")
+		return
+	}
+	bad.Signature = append([]byte{}, bad.Signature...)
+	bad.Signature[0] ^= 0xff
+	bad.RevokedCertificates = []RevokedCertificate{{
+		SerialNumber:   leaf.SerialNumber,
+		RevocationTime: TEST_TIME.AddDate(0, 0, -1),
+	}}
+	good := opts.CRLs[1]
+	for _, v := range []struct {
+		crls     []*RevocationList
+		required bool
+		valid    bool
+	}{
+		{[]*RevocationList{opts.CRLs[0], bad, good}, true, true},
+		{[]*RevocationList{opts.CRLs[0], bad}, true, false},
+		{[]*RevocationList{opts.CRLs[0], bad}, false, true},
+	} {
+		o := opts
+		o.CRLs = v.crls
+		o.RevocationRequired = v.required
+		if _, err := leaf.Verify(o); (err == nil) != v.valid {
+			t.Errorf("test failed: verify with bad crl (required %v): %v", v.required, err)
+			return
+		}
+	}
+
+	// The CRL of the intermediate CA signed by the root is rejected.
+	crl, err = ParseRevocationListPEM(crl.PEM())
+	if err != nil {
+		t.Errorf("test failed: parse revocation list pem")
+		return
+	}
+	if err := root.CheckCRLSignature(crl); err == nil {
+		t.Errorf("test failed: check crl signature of other issuer")
+		return
+	}
+}
+
+func BenchmarkVerify(b *testing.B) {
+	root, inter, leaf, err := testChain()
+	if err != nil {
+		b.Errorf("benchmark failed: create chain")
+		return
+	}
+	opts := testOptions(root, inter)
+	for i := 0; i < b.N; i++ {
+		_, err := leaf.Verify(opts)
+		if err != nil {
+			b.Errorf("benchmark failed: verify")
+			break
+		}
+	}
+}
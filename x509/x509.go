@@ -0,0 +1,195 @@
+// X.509 certificates (RFC 5280) with GOST R 34.10-2012 keys (RFC 9215).
+// https://datatracker.ietf.org/doc/html/rfc9215
+package x509
+
+import (
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"time"
+
+	gkeys "github.com/towleeee/go-cryptopro/gost_r_34_10_2012"
+)
+
+const (
+	PemCertificate = "CERTIFICATE"
+)
+
+/*
+ * ASN.1
+ */
+
+type certificate struct {
+	Raw                asn1.RawContent
+	TBSCertificate     tbsCertificate
+	SignatureAlgorithm pkix.AlgorithmIdentifier
+	SignatureValue     asn1.BitString
+}
+
+type tbsCertificate struct {
+	Raw                asn1.RawContent
+	Version            int `asn1:"optional,explicit,default:0,tag:0"`
+	SerialNumber       *big.Int
+	SignatureAlgorithm pkix.AlgorithmIdentifier
+	Issuer             asn1.RawValue
+	Validity           validity
+	Subject            asn1.RawValue
+	PublicKey          publicKeyInfo
+	UniqueId           asn1.BitString   `asn1:"optional,tag:1"`
+	SubjectUniqueId    asn1.BitString   `asn1:"optional,tag:2"`
+	Extensions         []pkix.Extension `asn1:"optional,explicit,tag:3"`
+}
+
+type validity struct {
+	NotBefore time.Time
+	NotAfter  time.Time
+}
+
+/*
+ * CERTIFICATE
+ */
+
+// Parsed X.509 certificate with GOST R 34.10-2012 key.
+type Certificate struct {
+	Raw                     []byte // DER of the certificate
+	RawTBSCertificate       []byte // DER of the signed part
+	RawSubjectPublicKeyInfo []byte
+	RawSubject              []byte
+	RawIssuer               []byte
+
+	Version      int
+	SerialNumber *big.Int
+	Issuer       Name
+	Subject      Name
+	NotBefore    time.Time
+	NotAfter     time.Time
+
+	SignatureAlgorithm asn1.ObjectIdentifier
+	// Big-endian s || r (RFC 4491),
+	// the reversed form is the signature of CryptoAPI.
+	Signature []byte
+
+	PublicKeyAlgorithm asn1.ObjectIdentifier
+	PublicKey          gkeys.PubKey
+	ParamSet           asn1.ObjectIdentifier
+	DigestSet          asn1.ObjectIdentifier
+
+	// All extensions including the parsed ones.
+	Extensions []pkix.Extension
+	// Extensions added to the certificate as is.
+	ExtraExtensions []pkix.Extension
+	// Critical extensions which are not parsed (e.g. nameConstraints),
+	// the certificate is rejected by Verify (RFC 5280, 4.2).
+	UnhandledCriticalExtensions []asn1.ObjectIdentifier
+
+	KeyUsage    KeyUsage
+	ExtKeyUsage []asn1.ObjectIdentifier
+
+	BasicConstraintsValid bool
+	IsCA                  bool
+	MaxPathLen            int
+	MaxPathLenZero        bool
+
+	SubjectKeyId   []byte
+	AuthorityKeyId []byte
+
+	DNSNames              []string
+	EmailAddresses        []string
+	CRLDistributionPoints []string
+	PolicyIdentifiers     []asn1.ObjectIdentifier
+
+	// Russian extensions: the signature tool of the owner
+	// and the signature tools of the issuer.
+	SubjectSignTool string
+	IssuerSignTool  []string
+}
+
+// Parsing of DER certificate.
+func ParseCertificate(der []byte) (*Certificate, error) {
+	var cert certificate
+	rest, err := asn1.Unmarshal(der, &cert)
+	if err != nil {
+		return nil, fmt.Errorf("error: parse certificate: %w", err)
+	}
+	if len(rest) != 0 {
+		return nil, fmt.Errorf("error: trailing data after certificate")
+	}
+
+	tbs := &cert.TBSCertificate
+	if tbs.SerialNumber == nil {
+		return nil, fmt.Errorf("error: certificate serial number")
+	}
+
+	out := &Certificate{
+		Raw:                     cert.Raw,
+		RawTBSCertificate:       tbs.Raw,
+		RawSubjectPublicKeyInfo: tbs.PublicKey.Raw,
+		RawSubject:              tbs.Subject.FullBytes,
+		RawIssuer:               tbs.Issuer.FullBytes,
+		Version:                 tbs.Version + 1,
+		SerialNumber:            tbs.SerialNumber,
+		NotBefore:               tbs.Validity.NotBefore,
+		NotAfter:                tbs.Validity.NotAfter,
+		SignatureAlgorithm:      cert.SignatureAlgorithm.Algorithm,
+		Signature:               cert.SignatureValue.RightAlign(),
+		PublicKeyAlgorithm:      tbs.PublicKey.Algorithm.Algorithm,
+		Extensions:              tbs.Extensions,
+	}
+
+	out.Issuer, err = parseName(tbs.Issuer.FullBytes)
+	if err != nil {
+		return nil, fmt.Errorf("error: parse issuer: %w", err)
+	}
+	out.Subject, err = parseName(tbs.Subject.FullBytes)
+	if err != nil {
+		return nil, fmt.Errorf("error: parse subject: %w", err)
+	}
+
+	pub, params, err := parsePublicKey(&tbs.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	out.PublicKey = pub
+	out.ParamSet = params.ParamSet
+	out.DigestSet = params.DigestSet
+
+	err = out.parseExtensions()
+	if err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// Parsing of PEM block "CERTIFICATE".
+func ParseCertificatePEM(data []byte) (*Certificate, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("error: pem block not found")
+	}
+	if block.Type != PemCertificate {
+		return nil, fmt.Errorf("error: pem type %q", block.Type)
+	}
+	return ParseCertificate(block.Bytes)
+}
+
+// PEM encoding of the certificate.
+func (c *Certificate) PEM() []byte {
+	return pem.EncodeToMemory(&pem.Block{
+		Type:  PemCertificate,
+		Bytes: c.Raw,
+	})
+}
+
+// Checking of the signature (big-endian s || r)
+// over the signed data with the key of the certificate.
+func (c *Certificate) CheckSignature(algo asn1.ObjectIdentifier, signed, signature []byte) error {
+	return checkSignature(algo, signed, signature, c.PublicKey)
+}
+
+// Certificates are equal by DER encoding.
+func (c *Certificate) Equal(cmp *Certificate) bool {
+	return string(c.Raw) == string(cmp.Raw)
+}
@@ -0,0 +1,243 @@
+// go test -v -bench=. -benchtime=100x
+package x509
+
+import (
+	"encoding/asn1"
+	"testing"
+
+	gkeys "github.com/towleeee/go-cryptopro/gost_r_34_10_2012"
+	ghash "github.com/towleeee/go-cryptopro/gost_r_34_11_2012"
+	"github.com/towleeee/go-cryptopro/internal/oids"
+)
+
+const (
+	TEST_SUBJECT  = "subject"
+	TEST_PASSWORD = "password"
+)
+
+var (
+	PRIVATE_KEY   gkeys.PrivKey
+	PRIVATE_KEY_2 gkeys.PrivKey
+	PRIVATE_KEY_3 gkeys.PrivKey
+)
+
+func init() {
+	PRIVATE_KEY = testKey(TEST_SUBJECT)
+	PRIVATE_KEY_2 = testKey(TEST_SUBJECT + "_2")
+	PRIVATE_KEY_3 = testKey(TEST_SUBJECT + "_3")
+}
+
+func testKey(subject string) gkeys.PrivKey {
+	cfg := gkeys.NewConfig(gkeys.K256, subject, TEST_PASSWORD)
+	err := gkeys.GenPrivKey(cfg)
+	if err != nil {
+		println("test warning: key already exist?")
+	}
+
+	priv, err := gkeys.NewPrivKey(cfg)
+	if err != nil {
+		panic("test failed: new priv key")
+	}
+
+	return priv
+}
+
+// Self-signed certificates with russian attributes and extensions,
+// the keys are GOST R 34.10-2012 256 (CryptoPro-A) and 512 (tc26 A).
+var (
+	TEST_CERTIFICATE_256 = []byte(`-----BEGIN CERTIFICATE-----
+MIIFGDCCBMWgAwIBAgIFAQIDBAUwCgYIKoUDBwEBAwIwggFNMQswCQYDVQQGEwJS
+VTEVMBMGA1UEBwwM0JzQvtGB0LrQstCwMRowGAYDVQQKDBHQntCe0J4gItCi0LXR
+gdGCIjEZMBcGA1UEDAwQ0JTQuNGA0LXQutGC0L7RgDEVMBMGA1UEBAwM0JjQstCw
+0L3QvtCyMSIwIAYDVQQqDBnQmNCy0LDQvSDQmNCy0LDQvdC+0LLQuNGHMR8wHQYJ
+KoZIhvcNAQkBFhB0ZXN0QGV4YW1wbGUuY29tMRowGAYIKoUDA4EDAQESDDc3MTIz
+NDU2Nzg5MDEVMBMGBSqFA2QEEgo3NzEyMzQ1Njc4MRgwFgYFKoUDZAESDTEwMjc3
+MDAwMDAwMDAxFjAUBgUqhQNkAxILMTIzNDU2Nzg5MDExLzAtBgNVBAMMJtCY0LLQ
+sNC90L7QsiDQmNCy0LDQvSDQmNCy0LDQvdC+0LLQuNGHMB4XDTI0MDEwMTAwMDAw
+MFoXDTQ5MDEwMTAwMDAwMFowggFNMQswCQYDVQQGEwJSVTEVMBMGA1UEBwwM0JzQ
+vtGB0LrQstCwMRowGAYDVQQKDBHQntCe0J4gItCi0LXRgdGCIjEZMBcGA1UEDAwQ
+0JTQuNGA0LXQutGC0L7RgDEVMBMGA1UEBAwM0JjQstCw0L3QvtCyMSIwIAYDVQQq
+DBnQmNCy0LDQvSDQmNCy0LDQvdC+0LLQuNGHMR8wHQYJKoZIhvcNAQkBFhB0ZXN0
+QGV4YW1wbGUuY29tMRowGAYIKoUDA4EDAQESDDc3MTIzNDU2Nzg5MDEVMBMGBSqF
+A2QEEgo3NzEyMzQ1Njc4MRgwFgYFKoUDZAESDTEwMjc3MDAwMDAwMDAxFjAUBgUq
+hQNkAxILMTIzNDU2Nzg5MDExLzAtBgNVBAMMJtCY0LLQsNC90L7QsiDQmNCy0LDQ
+vSDQmNCy0LDQvdC+0LLQuNGHMGYwHwYIKoUDBwEBAQEwEwYHKoUDAgIjAQYIKoUD
+BwEBAgIDQwAEQORy39AJWykyshT434v0/2TuCwTpGNLzVMGE3LAawiH9SVDkWBQd
+BIbfYlipgpojWQZ+MKLXASoaBgdPrMneJlCjggF/MIIBezAOBgNVHQ8BAf8EBAMC
+BsAwHQYDVR0lBBYwFAYIKwYBBQUHAwIGCCsGAQUFBwMEMAwGA1UdEwEB/wQCMAAw
+HQYDVR0OBBYEFAECAwQFBgcICQoLDA0ODxAREhMUMB8GA1UdIwQYMBaAFAECAwQF
+BgcICQoLDA0ODxAREhMUMBsGA1UdEQQUMBKBEHRlc3RAZXhhbXBsZS5jb20wLAYD
+VR0fBCUwIzAhoB+gHYYbaHR0cDovL2V4YW1wbGUuY29tL3Rlc3QuY3JsMBMGA1Ud
+IAQMMAowCAYGKoUDZHEBMCwGBSqFA2RvBCMMIdCh0JrQl9CYICLQmtGA0LjQv9GC
+0L7Qn9GA0L4gQ1NQIjBuBgUqhQNkcARlMGMMIdCh0JrQl9CYICLQmtGA0LjQv9GC
+0L7Qn9GA0L4gQ1NQIgwg0J/QkNCaICLQmtGA0LjQv9GC0L7Qn9GA0L4g0KPQpiIM
+DdCh0KQvMTI0LTAwMDEMDdCh0KQvMTI4LTAwMDEwCgYIKoUDBwEBAwIDQQDIdqOr
+F3WAh6/FzMy9TH6goF6W/Hk4fW5Je0d4z3WsoXTpOcY3p5pbfjncFZdr77MkrNt0
+4vqNQ0q6Danr+N6P
+-----END CERTIFICATE-----`)
+	TEST_CERTIFICATE_512 = []byte(`-----BEGIN CERTIFICATE-----
+MIIFlDCCBQCgAwIBAgIFAQIDBAUwCgYIKoUDBwEBAwMwggFNMQswCQYDVQQGEwJS
+VTEVMBMGA1UEBwwM0JzQvtGB0LrQstCwMRowGAYDVQQKDBHQntCe0J4gItCi0LXR
+gdGCIjEZMBcGA1UEDAwQ0JTQuNGA0LXQutGC0L7RgDEVMBMGA1UEBAwM0JjQstCw
+0L3QvtCyMSIwIAYDVQQqDBnQmNCy0LDQvSDQmNCy0LDQvdC+0LLQuNGHMR8wHQYJ
+KoZIhvcNAQkBFhB0ZXN0QGV4YW1wbGUuY29tMRowGAYIKoUDA4EDAQESDDc3MTIz
+NDU2Nzg5MDEVMBMGBSqFA2QEEgo3NzEyMzQ1Njc4MRgwFgYFKoUDZAESDTEwMjc3
+MDAwMDAwMDAxFjAUBgUqhQNkAxILMTIzNDU2Nzg5MDExLzAtBgNVBAMMJtCY0LLQ
+sNC90L7QsiDQmNCy0LDQvSDQmNCy0LDQvdC+0LLQuNGHMB4XDTI0MDEwMTAwMDAw
+MFoXDTQ5MDEwMTAwMDAwMFowggFNMQswCQYDVQQGEwJSVTEVMBMGA1UEBwwM0JzQ
+vtGB0LrQstCwMRowGAYDVQQKDBHQntCe0J4gItCi0LXRgdGCIjEZMBcGA1UEDAwQ
+0JTQuNGA0LXQutGC0L7RgDEVMBMGA1UEBAwM0JjQstCw0L3QvtCyMSIwIAYDVQQq
+DBnQmNCy0LDQvSDQmNCy0LDQvdC+0LLQuNGHMR8wHQYJKoZIhvcNAQkBFhB0ZXN0
+QGV4YW1wbGUuY29tMRowGAYIKoUDA4EDAQESDDc3MTIzNDU2Nzg5MDEVMBMGBSqF
+A2QEEgo3NzEyMzQ1Njc4MRgwFgYFKoUDZAESDTEwMjc3MDAwMDAwMDAxFjAUBgUq
+hQNkAxILMTIzNDU2Nzg5MDExLzAtBgNVBAMMJtCY0LLQsNC90L7QsiDQmNCy0LDQ
+vSDQmNCy0LDQvdC+0LLQuNGHMIGgMBcGCCqFAwcBAQECMAsGCSqFAwcBAgECAQOB
+hAAEgYBGzBtQvze38Pb3kRUffpZBVkg0ISpE2Q8VzP28/lABPx3F/14r/BI8vlwP
+28vKV4UCmtXu2kz7ho0STmi0zZ0iORg1FYy3kszkhf2xvN7+mY7rKpOglgTvcrDP
+s3yE5gQErKtOM2sjlai+pUve/uWc0KgyOB/aPmMhBEdHoQHTqKOCAX8wggF7MA4G
+A1UdDwEB/wQEAwIGwDAdBgNVHSUEFjAUBggrBgEFBQcDAgYIKwYBBQUHAwQwDAYD
+VR0TAQH/BAIwADAdBgNVHQ4EFgQUUQIDBAUGBwgJCgsMDQ4PEBESExQwHwYDVR0j
+BBgwFoAUUQIDBAUGBwgJCgsMDQ4PEBESExQwGwYDVR0RBBQwEoEQdGVzdEBleGFt
+cGxlLmNvbTAsBgNVHR8EJTAjMCGgH6AdhhtodHRwOi8vZXhhbXBsZS5jb20vdGVz
+dC5jcmwwEwYDVR0gBAwwCjAIBgYqhQNkcQEwLAYFKoUDZG8EIwwh0KHQmtCX0Jgg
+ItCa0YDQuNC/0YLQvtCf0YDQviBDU1AiMG4GBSqFA2RwBGUwYwwh0KHQmtCX0Jgg
+ItCa0YDQuNC/0YLQvtCf0YDQviBDU1AiDCDQn9CQ0JogItCa0YDQuNC/0YLQvtCf
+0YDQviDQo9CmIgwN0KHQpC8xMjQtMDAwMQwN0KHQpC8xMjgtMDAwMTAKBggqhQMH
+AQEDAwOBgQCVGw3EtT0ffdtlbzcIi4ajTIWYb227Pjz4ox0ygWMqTG1Tw+95jFIs
+Arv8eWcIvt2JKe2wvV+XoyO2KA+n6sbUx62DA7TVwhaXSLpyPN2Uq4xEYon7JBJh
+ZkfOex0oFjhhAKztWdyx6AKIhhhYBkAc43SEdAueEFXGwRTnvatA0A==
+-----END CERTIFICATE-----`)
+)
+
+func TestParseCertificate(t *testing.T) {
+	cert, err := ParseCertificatePEM(TEST_CERTIFICATE_256)
+	if err != nil {
+		t.Errorf("test failed: parse certificate")
+		return
+	}
+
+	if cert.Version != 3 || cert.SerialNumber.Int64() != 0x0102030405 {
+		t.Errorf("test failed: version and serial number")
+		return
+	}
+
+	subject := cert.Subject
+	if subject.CommonName != "Иванов Иван Иванович" ||
+		subject.Surname != "Иванов" ||
+		subject.GivenName != "Иван Иванович" ||
+		subject.Title != "Директор" ||
+		subject.EmailAddress != "test@example.com" ||
+		subject.INN != "771234567890" ||
+		subject.INNLE != "7712345678" ||
+		subject.OGRN != "1027700000000" ||
+		subject.SNILS != "12345678901" {
+		t.Errorf("test failed: subject %s", subject)
+		return
+	}
+
+	if !cert.ParamSet.Equal(oids.CryptoProA) || !cert.DigestSet.Equal(oids.GostR3411_12_256) {
+		t.Errorf("test failed: param set")
+		return
+	}
+
+	if cert.KeyUsage != KeyUsageDigitalSignature|KeyUsageContentCommitment {
+		t.Errorf("test failed: key usage")
+		return
+	}
+
+	if len(cert.ExtKeyUsage) != 2 ||
+		!cert.ExtKeyUsage[0].Equal(ExtKeyUsageClientAuth) ||
+		!cert.ExtKeyUsage[1].Equal(ExtKeyUsageEmailProtection) {
+		t.Errorf("test failed: ext key usage")
+		return
+	}
+
+	if !cert.BasicConstraintsValid || cert.IsCA {
+		t.Errorf("test failed: basic constraints")
+		return
+	}
+
+	if len(cert.SubjectKeyId) != 20 || string(cert.SubjectKeyId) != string(cert.AuthorityKeyId) {
+		t.Errorf("test failed: key identifiers")
+		return
+	}
+
+	if len(cert.EmailAddresses) != 1 ||
+		len(cert.CRLDistributionPoints) != 1 ||
+		len(cert.PolicyIdentifiers) != 1 ||
+		len(cert.IssuerSignTool) != 4 ||
+		cert.SubjectSignTool == "" {
+		t.Errorf("test failed: extensions")
+		return
+	}
+}
+
+func TestCertificatePublicKey(t *testing.T) {
+	for i, v := range []struct {
+		pem  []byte
+		prov ghash.ProvType
+	}{
+		{TEST_CERTIFICATE_256, ghash.H256},
+		{TEST_CERTIFICATE_512, ghash.H512},
+	} {
+		cert, err := ParseCertificatePEM(v.pem)
+		if err != nil {
+			t.Errorf("test failed: parse certificate (%d)", i)
+			return
+		}
+
+		// The certificate is self-signed.
+		digest := ghash.Sum(v.prov, cert.RawTBSCertificate)
+		if !cert.PublicKey.VerifyDigest(digest, cert.Signature, gkeys.FormatRFC4491) {
+			t.Errorf("test failed: verify (%d)", i)
+			return
+		}
+
+		err = cert.CheckSignature(cert.SignatureAlgorithm, cert.RawTBSCertificate, cert.Signature)
+		if err != nil {
+			t.Errorf("test failed: check signature (%d)", i)
+			return
+		}
+
+		err = cert.CheckSignature(cert.SignatureAlgorithm, cert.Raw, cert.Signature)
+		if err == nil {
+			t.Errorf("test failed: check signature of wrong data (%d)", i)
+			return
+		}
+	}
+}
+
+func TestName(t *testing.T) {
+	cert, err := ParseCertificatePEM(TEST_CERTIFICATE_256)
+	if err != nil {
+		t.Errorf("test failed: parse certificate")
+		return
+	}
+
+	der, err := asn1.Marshal(cert.Subject.ToRDNSequence())
+	if err != nil {
+		t.Errorf("test failed: marshal name")
+		return
+	}
+
+	name, err := parseName(der)
+	if err != nil {
+		t.Errorf("test failed: parse name")
+		return
+	}
+
+	if name.String() != cert.Subject.String() {
+		t.Errorf("test failed: name %s", name)
+		return
+	}
+}
+
+func BenchmarkParseCertificate(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, err := ParseCertificatePEM(TEST_CERTIFICATE_256)
+		if err != nil {
+			b.Errorf("benchmark failed: parse certificate")
+			break
+		}
+	}
+}